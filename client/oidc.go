@@ -0,0 +1,342 @@
+package client
+
+//go:generate mockery --name=OIDCClient --dir=. --output=../mocks/ --outpkg=mocks
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/config/model"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/golang-jwt/jwt"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	oidcDiscoveryPath      = "/.well-known/openid-configuration"
+	oidcMetadataTTL        = 24 * time.Hour
+	oidcJWKSRefreshBackoff = time.Minute
+	oidcClockSkew          = time.Minute
+	oidcMaxResponseSize    = 1 << 20
+)
+
+// OIDCClient speaks the OpenID Connect authorization code flow with PKCE to
+// every provider configured in config.Env.OIDCProviders.
+type OIDCClient interface {
+	HasProvider(provider string) bool
+	AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, provider, code, codeVerifier string) (*domain.OIDCTokenResponse, error)
+	VerifyIDToken(ctx context.Context, provider, rawIDToken, nonce string) (*domain.OIDCClaims, error)
+}
+
+type oidcProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcProvider struct {
+	config        model.OIDCProviderEnvironment
+	mu            sync.Mutex
+	metadata      *oidcProviderMetadata
+	discoveredAt  time.Time
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+type oidcClient struct {
+	di         *internal.Di
+	httpClient *http.Client
+	providers  map[string]*oidcProvider
+}
+
+func NewOIDCClient(di *internal.Di) (OIDCClient, error) {
+	providers := make(map[string]*oidcProvider, len(config.Env.OIDCProviders))
+	for name, providerConfig := range config.Env.OIDCProviders {
+		providers[name] = &oidcProvider{config: providerConfig}
+	}
+
+	return &oidcClient{
+		di:         di,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		providers:  providers,
+	}, nil
+}
+
+func (o *oidcClient) HasProvider(provider string) bool {
+	_, ok := o.providers[provider]
+	return ok
+}
+
+func (o *oidcClient) AuthCodeURL(ctx context.Context, provider, state, nonce, codeChallenge string) (string, error) {
+	p, metadata, err := o.discover(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (o *oidcClient) Exchange(ctx context.Context, provider, code, codeVerifier string) (*domain.OIDCTokenResponse, error) {
+	p, metadata, err := o.discover(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var tokenResponse domain.OIDCTokenResponse
+	if err := o.doJSON(req, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	return &tokenResponse, nil
+}
+
+// VerifyIDToken checks the signature against the provider's JWKS and the
+// claims required by OpenID Connect Core 3.1.3.7: issuer, audience, expiry,
+// issued-at and the nonce bound to the authorization request.
+func (o *oidcClient) VerifyIDToken(ctx context.Context, provider, rawIDToken, nonce string) (*domain.OIDCClaims, error) {
+	p, metadata, err := o.discover(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jwt.Parser{
+		ValidMethods:         []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()},
+		SkipClaimsValidation: true,
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.verificationKey(ctx, p, metadata, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrOIDCIDTokenInvalid, err.Error())
+	}
+
+	now := time.Now().UTC()
+	if !claims.VerifyIssuer(metadata.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	if azp, ok := claims["azp"].(string); ok && azp != p.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	if !claims.VerifyExpiresAt(now.Add(-oidcClockSkew).Unix(), true) {
+		return nil, fmt.Errorf("%w: token is expired", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	if !claims.VerifyIssuedAt(now.Add(oidcClockSkew).Unix(), true) {
+		return nil, fmt.Errorf("%w: token is issued in the future", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	tokenNonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domain.ErrOIDCIDTokenInvalid)
+	}
+
+	return &domain.OIDCClaims{
+		Subject:       subject,
+		Email:         strings.ToLower(stringClaim(claims, "email")),
+		EmailVerified: boolClaim(claims, "email_verified"),
+		GivenName:     stringClaim(claims, "given_name"),
+		FamilyName:    stringClaim(claims, "family_name"),
+		Name:          stringClaim(claims, "name"),
+		Picture:       stringClaim(claims, "picture"),
+	}, nil
+}
+
+// discover returns the provider metadata, fetching it on first use and again
+// once it is older than oidcMetadataTTL.
+func (o *oidcClient) discover(ctx context.Context, provider string) (*oidcProvider, *oidcProviderMetadata, error) {
+	p, ok := o.providers[provider]
+	if !ok {
+		return nil, nil, domain.ErrOIDCProviderNotFound
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil && time.Since(p.discoveredAt) < oidcMetadataTTL {
+		return p, p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+oidcDiscoveryPath, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create discovery request: %w", err)
+	}
+
+	var metadata oidcProviderMetadata
+	if err := o.doJSON(req, &metadata); err != nil {
+		return nil, nil, fmt.Errorf("discover provider %s: %w", provider, err)
+	}
+
+	if metadata.Issuer != p.config.Issuer {
+		return nil, nil, fmt.Errorf("discover provider %s: issuer %q does not match configured issuer", provider, metadata.Issuer)
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, nil, fmt.Errorf("discover provider %s: incomplete metadata", provider)
+	}
+
+	p.metadata = &metadata
+	p.discoveredAt = time.Now()
+
+	return p, p.metadata, nil
+}
+
+// verificationKey looks the key up in the cached JWKS, refetching it when the
+// key ID is unknown so provider key rotations are picked up. Refetches are
+// spaced by oidcJWKSRefreshBackoff so forged key IDs cannot flood the provider.
+func (o *oidcClient) verificationKey(ctx context.Context, p *oidcProvider, metadata *oidcProviderMetadata, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < oidcJWKSRefreshBackoff {
+		return nil, secure.ErrUnknownKeyID
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create JWKS request: %w", err)
+	}
+
+	var keySet secure.JSONWebKeySet
+	if err := o.doJSON(req, &keySet); err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+
+	return nil, secure.ErrUnknownKeyID
+}
+
+func (o *oidcClient) doJSON(req *http.Request, target any) error {
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseSize))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := jsoniter.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// lookupKey accepts a token without a key ID only when the provider publishes
+// a single key.
+func lookupKey(keys map[string]any, kid string) (any, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// boolClaim also accepts "true", which some providers send for email_verified.
+func boolClaim(claims jwt.MapClaims, name string) bool {
+	switch value := claims[name].(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/internal"
+)
+
+const (
+	defaultS3Region = "auto"
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3TimeFormat    = "20060102T150405Z"
+	s3DateFormat    = "20060102"
+)
+
+// s3Storage talks to an S3-compatible API such as AWS S3 or Cloudflare R2,
+// addressing objects path-style as <endpoint>/<bucket>/<key> and signing the
+// requests with AWS Signature Version 4.
+type s3Storage struct {
+	di              *internal.Di
+	httpClient      *http.Client
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+func NewS3Storage(di *internal.Di) (Storage, error) {
+	storageConfig := config.Env.Storage
+
+	if storageConfig.S3Endpoint == "" || storageConfig.S3Bucket == "" || storageConfig.S3AccessKeyID == "" || storageConfig.S3SecretAccessKey == "" {
+		return nil, errors.New("the s3 storage driver needs STORAGE_S3_ENDPOINT, STORAGE_S3_BUCKET, STORAGE_S3_ACCESS_KEY_ID and STORAGE_S3_SECRET_ACCESS_KEY")
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(storageConfig.S3Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid STORAGE_S3_ENDPOINT %q", storageConfig.S3Endpoint)
+	}
+
+	region := storageConfig.S3Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	return &s3Storage{
+		di:              di,
+		httpClient:      &http.Client{Timeout: 60 * time.Second},
+		endpoint:        endpoint,
+		region:          region,
+		bucket:          storageConfig.S3Bucket,
+		accessKeyID:     storageConfig.S3AccessKeyID,
+		secretAccessKey: storageConfig.S3SecretAccessKey,
+	}, nil
+}
+
+// Put buffers the content because the signature covers the payload hash and
+// the API needs its length up front.
+func (s *s3Storage) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	body, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.do(ctx, http.MethodPut, key, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s.responseError(response)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	response, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrFileNotFound
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, s.responseError(response)
+	}
+
+	return response.Body, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	response, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return s.responseError(response)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	objectURL := *s.endpoint
+	objectURL.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	objectURL.RawPath = s3Escape(objectURL.Path)
+
+	request, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(request, body, time.Now().UTC())
+
+	return s.httpClient.Do(request)
+}
+
+// sign adds the Signature Version 4 Authorization header. Only the host and
+// the x-amz-* headers are signed.
+func (s *s3Storage) sign(request *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format(s3TimeFormat)
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + request.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(s3DateFormat))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func (s *s3Storage) responseError(response *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("s3 storage responded with status %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// s3Escape percent-encodes a path the way Signature Version 4 expects: every
+// byte except the unreserved characters and "/".
+func s3Escape(path string) string {
+	var builder strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			builder.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&builder, "%%%02X", c)
+	}
+
+	return builder.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,61 @@
+package client
+
+//go:generate mockery --name=Storage --dir=. --output=../mocks/ --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/internal"
+)
+
+const (
+	StorageDriverLocal = "local"
+	StorageDriverS3    = "s3"
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrInvalidKey   = errors.New("invalid storage key")
+)
+
+// Storage keeps files under slash separated keys such as
+// "exports/<userId>/<exportId>.zip", hiding where the bytes actually live.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage returns the driver selected by STORAGE_DRIVER, the local file
+// system by default.
+func NewStorage(di *internal.Di) (Storage, error) {
+	switch config.Env.Storage.Driver {
+	case "", StorageDriverLocal:
+		return NewLocalStorage(di)
+	case StorageDriverS3:
+		return NewS3Storage(di)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", config.Env.Storage.Driver)
+	}
+}
+
+// checkKey refuses keys that are empty, absolute or try to leave the storage
+// root through "..".
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	accessTokenCookie  = "x.Token"
+	refreshTokenCookie = "x.RefreshToken"
+	refreshTokenPath   = "/v1/users/token"
+)
+
+// respondWithTokens sets the auth cookies used by browsers. Clients that send
+// domain.TokenDeliveryHeader with domain.TokenDeliveryBody receive the tokens
+// in the JSON body instead and no cookie is set. A new CSRF token is issued
+// alongside the cookies so it never outlives the session it protects.
+func respondWithTokens(ctx echo.Context, status int, tokens *domain.AuthTokens) error {
+	if strings.EqualFold(ctx.Request().Header.Get(domain.TokenDeliveryHeader), domain.TokenDeliveryBody) {
+		return ctx.JSON(http.StatusOK, tokens.ToTokenResponse())
+	}
+
+	if _, err := issueCSRFToken(ctx); err != nil {
+		slog.Error("error to issue CSRF token", slog.String("error", err.Error()))
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	setAuthCookies(ctx, tokens)
+
+	return ctx.NoContent(status)
+}
+
+func setAuthCookies(ctx echo.Context, tokens *domain.AuthTokens) {
+	ctx.SetCookie(&http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    tokens.AccessToken,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    tokens.RefreshToken,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     refreshTokenPath,
+		MaxAge:   int((time.Duration(config.Env.Cache.SessionExp) * time.Hour).Seconds()),
+	})
+}
+
+// issueCSRFToken sets the double-submit cookie. It is readable by scripts on
+// purpose: the front end copies it into domain.CSRFHeader, which a cross-site
+// form cannot do.
+func issueCSRFToken(ctx echo.Context) (string, error) {
+	token, err := secure.GenerateToken(domain.CSRFTokenSize)
+	if err != nil {
+		return "", err
+	}
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     domain.CSRFCookie,
+		Value:    token,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+
+	return token, nil
+}
+
+func clearAuthCookies(ctx echo.Context) {
+	ctx.SetCookie(&http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    "",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    "",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     refreshTokenPath,
+		MaxAge:   -1,
+	})
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     domain.CSRFCookie,
+		Value:    "",
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/domain"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondWithTokens_WhenBodyDeliveryRequested_ShouldReturnTokensWithoutCookies(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/v1/users/sign-in/verify", nil)
+	request.Header.Set(domain.TokenDeliveryHeader, "Body")
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(request, recorder)
+
+	err := respondWithTokens(ctx, http.StatusNoContent, &domain.AuthTokens{AccessToken: "access", RefreshToken: "refresh"})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Values(echo.HeaderSetCookie))
+
+	var response domain.TokenResponse
+	require.NoError(t, jsoniter.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "access", response.AccessToken)
+	assert.Equal(t, "refresh", response.RefreshToken)
+	assert.Equal(t, domain.TokenTypeBearer, response.TokenType)
+}
+
+func TestRespondWithTokens_WhenNoDeliveryRequested_ShouldSetCookies(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/v1/users/sign-in/verify", nil)
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(request, recorder)
+
+	err := respondWithTokens(ctx, http.StatusNoContent, &domain.AuthTokens{AccessToken: "access", RefreshToken: "refresh"})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+
+	cookies := make(map[string]string)
+	for _, cookie := range recorder.Result().Cookies() {
+		cookies[cookie.Name] = cookie.Value
+	}
+	assert.Equal(t, "access", cookies[accessTokenCookie])
+	assert.Equal(t, "refresh", cookies[refreshTokenCookie])
+	assert.NotEmpty(t, cookies[domain.CSRFCookie])
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/labstack/echo/v4"
+)
+
+type avatarHandler struct {
+	di            *internal.Di
+	avatarService domain.AvatarService
+}
+
+func NewAvatarHandler(di *internal.Di) (domain.AvatarHandler, error) {
+	avatarService, err := internal.Invoke[domain.AvatarService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &avatarHandler{
+		di:            di,
+		avatarService: avatarService,
+	}, nil
+}
+
+func (a *avatarHandler) UploadAvatar(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "avatar"),
+		slog.String("func", "UploadAvatar"),
+	)
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		log.Warn("error to parse multipart form", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	payload := domain.AvatarPayload{Avatar: form.File[domain.AvatarFormField]}
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	file, err := payload.Avatar[0].Open()
+	if err != nil {
+		log.Error("error to open avatar file", slog.String("error", err.Error()))
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+	defer file.Close()
+
+	response, err := a.avatarService.UploadAvatar(ctx.Request().Context(), file)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrInvalidImage {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, nil, "Unprocessable Entity", "The image could not be read. Please upload a PNG or JPEG file.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a *avatarHandler) DeleteAvatar(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "avatar"),
+		slog.String("func", "DeleteAvatar"),
+	)
+
+	if err := a.avatarService.DeleteAvatar(ctx.Request().Context()); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrAvatarNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "There is no uploaded avatar.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *avatarHandler) GetAvatar(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "avatar"),
+		slog.String("func", "GetAvatar"),
+	)
+
+	file, err := a.avatarService.GetAvatar(ctx.Request().Context(), domain.AvatarKeyPrefix+ctx.Param("*"))
+	if err != nil {
+		if err == domain.ErrAvatarNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The file does not exist.")
+		}
+
+		log.Error(err.Error())
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+	defer file.Content.Close()
+
+	// avatar keys are never reused, a new upload gets a new directory
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=31536000, immutable")
+	return ctx.Stream(http.StatusOK, file.ContentType, file.Content)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/labstack/echo/v4"
+)
+
+type dataExportHandler struct {
+	di                *internal.Di
+	dataExportService domain.DataExportService
+}
+
+func NewDataExportHandler(di *internal.Di) (domain.DataExportHandler, error) {
+	dataExportService, err := internal.Invoke[domain.DataExportService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataExportHandler{
+		di:                di,
+		dataExportService: dataExportService,
+	}, nil
+}
+
+func (d *dataExportHandler) RequestDataExport(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "dataExport"),
+		slog.String("func", "RequestDataExport"),
+	)
+
+	response, err := d.dataExportService.RequestDataExport(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrDataExportInProgress {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "A data export is already being prepared.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusAccepted, response)
+}
+
+func (d *dataExportHandler) DownloadDataExport(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "dataExport"),
+		slog.String("func", "DownloadDataExport"),
+	)
+
+	token := strings.TrimSpace(ctx.QueryParam("token"))
+	if token == "" {
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The data export does not exist or its link has expired.")
+	}
+
+	file, err := d.dataExportService.DownloadDataExport(ctx.Request().Context(), token)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrDataExportNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The data export does not exist or its link has expired.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+	defer file.Content.Close()
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.Name))
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.Stream(http.StatusOK, "application/zip", file.Content)
+}
@@ -59,6 +59,10 @@ func (f *followerHandler) FollowUser(ctx echo.Context) error {
 			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "The user cannot follow itself.")
 		}
 
+		if err == domain.ErrFollowBlocked {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "You cannot follow this user.")
+		}
+
 		return domain.InternalServerAPIErrorResponse(ctx)
 	}
 
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type personalAccessTokenHandler struct {
+	di                         *internal.Di
+	personalAccessTokenService domain.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(di *internal.Di) (domain.PersonalAccessTokenHandler, error) {
+	personalAccessTokenService, err := internal.Invoke[domain.PersonalAccessTokenService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &personalAccessTokenHandler{
+		di:                         di,
+		personalAccessTokenService: personalAccessTokenService,
+	}, nil
+}
+
+func (p *personalAccessTokenHandler) CreateToken(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("func", "CreateToken"),
+	)
+
+	var payload domain.PersonalAccessTokenPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	response, err := p.personalAccessTokenService.CreateToken(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+func (p *personalAccessTokenHandler) GetTokens(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("func", "GetTokens"),
+	)
+
+	response, err := p.personalAccessTokenService.GetTokens(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (p *personalAccessTokenHandler) RevokeToken(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("func", "RevokeToken"),
+	)
+
+	tokenID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "Invalid ID.")
+	}
+
+	if err := p.personalAccessTokenService.RevokeToken(ctx.Request().Context(), tokenID); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrPersonalAccessTokenNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The personal access token does not exist.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/labstack/echo/v4"
+)
+
+const maxUserSearchPageSize = 50
+
+type profileHandler struct {
+	di             *internal.Di
+	profileService domain.ProfileService
+}
+
+func NewProfileHandler(di *internal.Di) (domain.ProfileHandler, error) {
+	profileService, err := internal.Invoke[domain.ProfileService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profileHandler{
+		di:             di,
+		profileService: profileService,
+	}, nil
+}
+
+func (p *profileHandler) GetProfile(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "profile"),
+		slog.String("func", "GetProfile"),
+	)
+
+	response, err := p.profileService.GetProfile(ctx.Request().Context(), ctx.Param("username"))
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user does not exist.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (p *profileHandler) SearchUsers(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "profile"),
+		slog.String("func", "SearchUsers"),
+	)
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	if limit > maxUserSearchPageSize {
+		limit = maxUserSearchPageSize
+	}
+
+	response, err := p.profileService.SearchUsers(ctx.Request().Context(), ctx.QueryParam("q"), page, limit)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrInvalidSearchQuery {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Invalid Query", fmt.Sprintf("The search query must have between 1 and %d characters.", domain.MaxUserSearchQueryLength))
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/labstack/echo/v4"
+)
+
+const maxSecurityEventsPageSize = 100
+
+type securityEventHandler struct {
+	di                   *internal.Di
+	securityEventService domain.SecurityEventService
+}
+
+func NewSecurityEventHandler(di *internal.Di) (domain.SecurityEventHandler, error) {
+	securityEventService, err := internal.Invoke[domain.SecurityEventService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &securityEventHandler{
+		di:                   di,
+		securityEventService: securityEventService,
+	}, nil
+}
+
+func (s *securityEventHandler) GetSecurityEvents(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "securityEvent"),
+		slog.String("func", "GetSecurityEvents"),
+	)
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	if limit > maxSecurityEventsPageSize {
+		limit = maxSecurityEventsPageSize
+	}
+
+	response, err := s.securityEventService.GetSecurityEvents(ctx.Request().Context(), page, limit)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
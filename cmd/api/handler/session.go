@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/labstack/echo/v4"
+)
+
+type sessionHandler struct {
+	di             *internal.Di
+	sessionService domain.SessionService
+}
+
+func NewSessionHandler(di *internal.Di) (domain.SessionHandler, error) {
+	sessionService, err := internal.Invoke[domain.SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionHandler{
+		di:             di,
+		sessionService: sessionService,
+	}, nil
+}
+
+func (s *sessionHandler) GetSessions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "GetSessions"),
+	)
+
+	response, err := s.sessionService.GetSessions(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *sessionHandler) RevokeSession(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "RevokeSession"),
+	)
+
+	sessionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Invalid UUID", "The ID provided is not a valid UUID.")
+	}
+
+	if err := s.sessionService.RevokeSession(ctx.Request().Context(), sessionID); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The session does not exist.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *sessionHandler) RevokeOtherSessions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "RevokeOtherSessions"),
+	)
+
+	if err := s.sessionService.RevokeOtherSessions(ctx.Request().Context()); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *sessionHandler) GetJWKS(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "GetJWKS"),
+	)
+
+	response, err := s.sessionService.GetJWKS()
+	if err != nil {
+		log.Error(err.Error())
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *sessionHandler) RefreshToken(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "RefreshToken"),
+	)
+
+	var refreshToken string
+	if cookie, err := ctx.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+		refreshToken = cookie.Value
+	} else {
+		var payload domain.RefreshTokenPayload
+		if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+			log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+			return domain.CannotBindPayloadAPIErrorResponse(ctx)
+		}
+		refreshToken = strings.TrimSpace(payload.RefreshToken)
+	}
+
+	if refreshToken == "" {
+		return domain.AccessDeniedAPIErrorResponse(ctx)
+	}
+
+	tokens, err := s.sessionService.RefreshSession(ctx.Request().Context(), refreshToken)
+	if err != nil {
+		if err == domain.ErrRefreshTokenInvalid || err == domain.ErrRefreshTokenReused {
+			log.Warn(err.Error())
+			clearAuthCookies(ctx)
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+			log.Warn(err.Error())
+			clearAuthCookies(ctx)
+			return domain.AccountStatusAPIErrorResponse(ctx, err)
+		}
+
+		log.Error(err.Error())
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return respondWithTokens(ctx, http.StatusNoContent, tokens)
+}
+
+// GetCSRFToken bootstraps the CSRF token for browsers that already hold a
+// session cookie, e.g. after a reload. An existing token is reused so other
+// open tabs keep working.
+func (s *sessionHandler) GetCSRFToken(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "session"),
+		slog.String("func", "GetCSRFToken"),
+	)
+
+	if cookie, err := ctx.Cookie(domain.CSRFCookie); err == nil && cookie.Value != "" {
+		return ctx.JSON(http.StatusOK, domain.CSRFTokenResponse{CSRFToken: cookie.Value})
+	}
+
+	token, err := issueCSRFToken(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, domain.CSRFTokenResponse{CSRFToken: token})
+}
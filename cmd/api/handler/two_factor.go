@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+type twoFactorHandler struct {
+	di               *internal.Di
+	twoFactorService domain.TwoFactorService
+}
+
+func NewTwoFactorHandler(di *internal.Di) (domain.TwoFactorHandler, error) {
+	twoFactorService, err := internal.Invoke[domain.TwoFactorService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactorHandler{
+		di:               di,
+		twoFactorService: twoFactorService,
+	}, nil
+}
+
+func (t *twoFactorHandler) BeginEnrollment(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "twoFactor"),
+		slog.String("func", "BeginEnrollment"),
+	)
+
+	response, err := t.twoFactorService.BeginEnrollment(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrTwoFactorAlreadyEnabled {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "Two-factor authentication is already enabled.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (t *twoFactorHandler) ConfirmEnrollment(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "twoFactor"),
+		slog.String("func", "ConfirmEnrollment"),
+	)
+
+	var payload domain.ConfirmTwoFactorPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	response, err := t.twoFactorService.ConfirmEnrollment(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrTwoFactorAlreadyEnabled {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "Two-factor authentication is already enabled.")
+		}
+
+		if err == domain.ErrTwoFactorEnrollmentNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "There is no two-factor enrollment to confirm. Please start again.")
+		}
+
+		if err == domain.ErrTwoFactorCodeWrong {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, nil, "Unprocessable Entity", "The code is incorrect. Please check it and try again.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (t *twoFactorHandler) DisableTwoFactor(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "twoFactor"),
+		slog.String("func", "DisableTwoFactor"),
+	)
+
+	var payload domain.DisableTwoFactorPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := t.twoFactorService.DisableTwoFactor(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrTwoFactorNotEnabled {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "Two-factor authentication is not enabled.")
+		}
+
+		if err == domain.ErrInvalidPassword || err == domain.ErrTwoFactorCodeWrong {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "The password or code is incorrect.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
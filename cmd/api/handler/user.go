@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/G-Villarinho/social-network/domain"
 
 	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/labstack/echo/v4"
@@ -45,8 +49,7 @@ func (u *userHandler) CreateUser(ctx echo.Context) error {
 		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
 	}
 
-	token, err := u.userService.CreateUser(ctx.Request().Context(), payload)
-	if err != nil {
+	if err := u.userService.CreateUser(ctx.Request().Context(), payload); err != nil {
 		log.Error(err.Error())
 
 		if err == domain.ErrUsernameAlreadyExists {
@@ -60,16 +63,69 @@ func (u *userHandler) CreateUser(ctx echo.Context) error {
 		return domain.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := &http.Cookie{
-		Name:     "x.Token",
-		Value:    token,
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (u *userHandler) ConfirmEmail(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ConfirmEmail"),
+	)
+
+	var payload domain.ConfirmEmailPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
 	}
-	ctx.SetCookie(cookie)
 
-	return ctx.NoContent(http.StatusCreated)
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ConfirmEmail(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrEmailConfirmationTokenInvalid || err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "The confirmation link is invalid or has expired. Please request a new one.")
+		}
+
+		if err == domain.ErrEmailAlreadyConfirmed {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "conflict", "The email has already been confirmed.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (u *userHandler) ResendEmailConfirmation(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ResendEmailConfirmation"),
+	)
+
+	var payload domain.ResendEmailConfirmationPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ResendEmailConfirmation(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrEmailConfirmationResendLimit {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusTooManyRequests, nil, "Too Many Requests", "You have requested too many confirmation emails. Please try again later.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
 }
 
 func (u *userHandler) SignIn(ctx echo.Context) error {
@@ -88,7 +144,7 @@ func (u *userHandler) SignIn(ctx echo.Context) error {
 		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
 	}
 
-	token, err := u.userService.SignIn(ctx.Request().Context(), payload)
+	response, err := u.userService.SignIn(ctx.Request().Context(), payload)
 	if err != nil {
 		log.Error(err.Error())
 
@@ -96,17 +152,250 @@ func (u *userHandler) SignIn(ctx echo.Context) error {
 			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "Invalid email or username and password. Please check your credentials and try again.")
 		}
 
+		if err == domain.ErrEmailConfirmationPending {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "Please confirm your email address before signing in.")
+		}
+
+		if err == domain.ErrPasswordResetRequired {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "Your password must be reset before signing in. Check your email for the reset link.")
+		}
+
+		if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+			return domain.AccountStatusAPIErrorResponse(ctx, err)
+		}
+
+		if err == domain.ErrAccountLocked {
+			ctx.Response().Header().Set("Retry-After", strconv.Itoa(domain.SignInLockoutDuration*60))
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusLocked, nil, "Account Locked", "Your account is temporarily locked after too many failed sign-in attempts. Please try again later or reset your password.")
+		}
+
+		if err == domain.ErrTooManySignInAttempts {
+			ctx.Response().Header().Set("Retry-After", strconv.Itoa(domain.SignInFailureWindow*60))
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusTooManyRequests, nil, "Too Many Requests", "Too many failed sign-in attempts. Please try again later.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (u *userHandler) VerifySignIn(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "VerifySignIn"),
+	)
+
+	var payload domain.VerifySignInPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	tokens, err := u.userService.VerifySignIn(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrHashExpired || err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "Your sign-in request has expired. Please sign in again.")
+		}
+
+		if err == domain.ErrCodeOTPExpired {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "The code has expired. Please sign in again to receive a new code.")
+		}
+
+		if err == domain.ErrCodeOTPWrong {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "The code is incorrect. Please check it and try again.")
+		}
+
+		if err == domain.ErrCodeOTPAttemptsExceeded {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "Too many incorrect codes. Please sign in again to receive a new code.")
+		}
+
+		if err == domain.ErrPasswordResetRequired {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "Your password must be reset before signing in. Check your email for the reset link.")
+		}
+
+		if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+			return domain.AccountStatusAPIErrorResponse(ctx, err)
+		}
+
 		return domain.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := &http.Cookie{
-		Name:     "x.Token",
-		Value:    token,
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
+	return respondWithTokens(ctx, http.StatusOK, tokens)
+}
+
+func (u *userHandler) VerifyTwoFactorSignIn(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "VerifyTwoFactorSignIn"),
+	)
+
+	var payload domain.VerifyTwoFactorSignInPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	tokens, err := u.userService.VerifyTwoFactorSignIn(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrHashExpired || err == domain.ErrUserNotFound || err == domain.ErrTwoFactorNotEnabled {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "Your sign-in request has expired. Please sign in again.")
+		}
+
+		if err == domain.ErrTwoFactorCodeWrong {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "The code is incorrect. Please check it and try again.")
+		}
+
+		if err == domain.ErrCodeOTPAttemptsExceeded {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "Too many incorrect codes. Please sign in again.")
+		}
+
+		if err == domain.ErrAccountLocked {
+			ctx.Response().Header().Set("Retry-After", strconv.Itoa(domain.SignInLockoutDuration*60))
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusLocked, nil, "Account Locked", "Your account is temporarily locked after too many failed sign-in attempts. Please try again later or reset your password.")
+		}
+
+		if err == domain.ErrPasswordResetRequired {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "Your password must be reset before signing in. Check your email for the reset link.")
+		}
+
+		if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+			return domain.AccountStatusAPIErrorResponse(ctx, err)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return respondWithTokens(ctx, http.StatusOK, tokens)
+}
+
+func (u *userHandler) ForgotPassword(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ForgotPassword"),
+	)
+
+	var payload domain.ForgotPasswordPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ForgotPassword(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+func (u *userHandler) ResetPassword(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ResetPassword"),
+	)
+
+	var payload domain.ResetPasswordPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ResetPassword(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrPasswordResetTokenInvalid {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "The password reset link is invalid or has expired. Please request a new one.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (u *userHandler) ReportSignIn(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ReportSignIn"),
+	)
+
+	var payload domain.ReportSignInPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ReportSignIn(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSignInReportTokenInvalid {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "The link is invalid or has expired. Reset your password to secure your account.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (u *userHandler) ChangePassword(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "ChangePassword"),
+	)
+
+	var payload domain.ChangePasswordPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.ChangePassword(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrInvalidPassword {
+			return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, domain.ValidationErrors{"currentpassword": "Current password is incorrect"})
+		}
+
+		if err == domain.ErrPasswordReused {
+			return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, domain.ValidationErrors{"newpassword": "New password must be different from the current one"})
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
 	}
-	ctx.SetCookie(cookie)
 
 	return ctx.NoContent(http.StatusOK)
 }
@@ -127,14 +416,7 @@ func (u *userHandler) SignOut(ctx echo.Context) error {
 		return domain.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := &http.Cookie{
-		Name:     "x.Token",
-		Value:    "",
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-	}
-
-	ctx.SetCookie(cookie)
+	clearAuthCookies(ctx)
 
 	return ctx.NoContent(http.StatusOK)
 }
@@ -202,21 +484,14 @@ func (u *userHandler) DeleteUser(ctx echo.Context) error {
 
 	if err := u.userService.DeleteUser(ctx.Request().Context()); err != nil {
 		log.Error(err.Error())
-		if err == domain.ErrSessionNotFound {
+		if err == domain.ErrSessionNotFound || err == domain.ErrUserNotFound {
 			return domain.AccessDeniedAPIErrorResponse(ctx)
 		}
 
 		return domain.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := &http.Cookie{
-		Name:     "x.Token",
-		Value:    "",
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-	}
-
-	ctx.SetCookie(cookie)
+	clearAuthCookies(ctx)
 
 	return ctx.NoContent(http.StatusOK)
 }
@@ -266,5 +541,209 @@ func (u *userHandler) CheckPasswordStrong(ctx echo.Context) error {
 		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
 	}
 
-	return ctx.NoContent(http.StatusOK)
+	return ctx.JSON(http.StatusOK, domain.EstimatePasswordStrength(payload.Password))
+}
+
+func (u *userHandler) UpdateUserRole(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "UpdateUserRole"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "Invalid ID.")
+	}
+
+	var payload domain.UpdateUserRolePayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.UpdateUserRole(ctx.Request().Context(), userID, payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user does not exist.")
+		}
+
+		if err == domain.ErrCannotChangeOwnRole {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "You cannot change your own role.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (u *userHandler) BlockUser(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "BlockUser"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "Invalid ID.")
+	}
+
+	var payload domain.ModerationReasonPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.BlockUser(ctx.Request().Context(), userID, payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user does not exist.")
+		}
+
+		if err == domain.ErrCannotBlockSelf {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "You cannot block your own account.")
+		}
+
+		if err == domain.ErrUserAlreadyBlocked {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "The user is already blocked.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (u *userHandler) UnblockUser(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "UnblockUser"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Bad Request", "Invalid ID.")
+	}
+
+	var payload domain.ModerationReasonPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	if err := u.userService.UnblockUser(ctx.Request().Context(), userID, payload); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user does not exist.")
+		}
+
+		if err == domain.ErrUserNotBlocked {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "The user is not blocked.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (u *userHandler) GetOIDCAuthorizationURL(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "GetOIDCAuthorizationURL"),
+	)
+
+	response, err := u.userService.GetOIDCAuthorizationURL(ctx.Request().Context(), strings.ToLower(ctx.Param("provider")))
+	if err != nil {
+		var twoFactorRequired *domain.TwoFactorRequiredError
+		if errors.As(err, &twoFactorRequired) {
+			return ctx.JSON(http.StatusOK, domain.SignInResponse{Hash: twoFactorRequired.Hash, Method: domain.SignInMethodTOTP})
+		}
+
+		log.Error(err.Error())
+
+		if err == domain.ErrOIDCProviderNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The sign-in provider is not supported.")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (u *userHandler) SignInWithOIDC(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "SignInWithOIDC"),
+	)
+
+	var payload domain.OIDCCallbackPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("error to decode JSON payload", slog.String("error", err.Error()))
+		return domain.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if validationErrors := payload.Validate(); validationErrors != nil {
+		return domain.NewValidationAPIErrorResponse(ctx, http.StatusUnprocessableEntity, validationErrors)
+	}
+
+	tokens, err := u.userService.SignInWithOIDC(ctx.Request().Context(), strings.ToLower(ctx.Param("provider")), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrOIDCProviderNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The sign-in provider is not supported.")
+		}
+
+		if err == domain.ErrOIDCStateInvalid || err == domain.ErrOIDCIDTokenInvalid || err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, nil, "Unauthorized", "The sign-in with the provider could not be verified. Please try again.")
+		}
+
+		if err == domain.ErrOIDCEmailNotVerified {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "The provider account has no verified email address.")
+		}
+
+		if err == domain.ErrPasswordResetRequired {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, nil, "Forbidden", "Your password must be reset before signing in. Check your email for the reset link.")
+		}
+
+		if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+			return domain.AccountStatusAPIErrorResponse(ctx, err)
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return respondWithTokens(ctx, http.StatusOK, tokens)
 }
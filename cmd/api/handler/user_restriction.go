@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type userRestrictionHandler struct {
+	di                     *internal.Di
+	userRestrictionService domain.UserRestrictionService
+}
+
+func NewUserRestrictionHandler(di *internal.Di) (domain.UserRestrictionHandler, error) {
+	userRestrictionService, err := internal.Invoke[domain.UserRestrictionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userRestrictionHandler{
+		di:                     di,
+		userRestrictionService: userRestrictionService,
+	}, nil
+}
+
+func (u *userRestrictionHandler) BlockUser(ctx echo.Context) error {
+	return u.restrict(ctx, "BlockUser", domain.RestrictionBlock)
+}
+
+func (u *userRestrictionHandler) UnblockUser(ctx echo.Context) error {
+	return u.unrestrict(ctx, "UnblockUser", domain.RestrictionBlock)
+}
+
+func (u *userRestrictionHandler) MuteUser(ctx echo.Context) error {
+	return u.restrict(ctx, "MuteUser", domain.RestrictionMute)
+}
+
+func (u *userRestrictionHandler) UnmuteUser(ctx echo.Context) error {
+	return u.unrestrict(ctx, "UnmuteUser", domain.RestrictionMute)
+}
+
+func (u *userRestrictionHandler) restrict(ctx echo.Context, funcName string, restrictionType domain.RestrictionType) error {
+	log := slog.With(
+		slog.String("handler", "userRestriction"),
+		slog.String("func", funcName),
+	)
+
+	targetID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Invalid UUID", "The ID provided is not a valid UUID.")
+	}
+
+	if err := u.userRestrictionService.Restrict(ctx.Request().Context(), targetID, restrictionType); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user does not exist.")
+		}
+
+		if err == domain.ErrCannotRestrictSelf {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "You cannot block or mute yourself.")
+		}
+
+		if err == domain.ErrUserRestrictionAlreadyExists {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, nil, "Conflict", "The user is already "+restrictedLabel(restrictionType)+".")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (u *userRestrictionHandler) unrestrict(ctx echo.Context, funcName string, restrictionType domain.RestrictionType) error {
+	log := slog.With(
+		slog.String("handler", "userRestriction"),
+		slog.String("func", funcName),
+	)
+
+	targetID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Warn("Error to parse UUID", slog.String("error", err.Error()))
+		return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, nil, "Invalid UUID", "The ID provided is not a valid UUID.")
+	}
+
+	if err := u.userRestrictionService.Unrestrict(ctx.Request().Context(), targetID, restrictionType); err != nil {
+		log.Error(err.Error())
+
+		if err == domain.ErrSessionNotFound {
+			return domain.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if err == domain.ErrUserRestrictionNotFound {
+			return domain.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, nil, "Not Found", "The user is not "+restrictedLabel(restrictionType)+".")
+		}
+
+		return domain.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func restrictedLabel(restrictionType domain.RestrictionType) string {
+	if restrictionType == domain.RestrictionMute {
+		return "muted"
+	}
+
+	return "blocked"
+}
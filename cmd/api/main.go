@@ -12,6 +12,7 @@ import (
 	"github.com/G-Villarinho/social-network/cmd/api/router"
 	"github.com/G-Villarinho/social-network/config"
 	"github.com/G-Villarinho/social-network/database"
+	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/internal"
 	"github.com/G-Villarinho/social-network/repository"
 	"github.com/G-Villarinho/social-network/service"
@@ -39,7 +40,7 @@ func main() {
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{config.Env.FrontURL},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, domain.TokenDeliveryHeader, domain.CSRFHeader},
 		AllowCredentials: true,
 	}))
 
@@ -82,29 +83,64 @@ func main() {
 	})
 
 	internal.Provide(di, client.NewMailerSendClient)
+	internal.Provide(di, client.NewOIDCClient)
+	internal.Provide(di, client.NewStorage)
 
+	internal.Provide(di, handler.NewAvatarHandler)
+	internal.Provide(di, handler.NewDataExportHandler)
 	internal.Provide(di, handler.NewFeedHandler)
 	internal.Provide(di, handler.NewFollowerHandler)
+	internal.Provide(di, handler.NewPersonalAccessTokenHandler)
 	internal.Provide(di, handler.NewPostHandler)
+	internal.Provide(di, handler.NewProfileHandler)
+	internal.Provide(di, handler.NewSecurityEventHandler)
+	internal.Provide(di, handler.NewSessionHandler)
+	internal.Provide(di, handler.NewTwoFactorHandler)
 	internal.Provide(di, handler.NewUserHandler)
+	internal.Provide(di, handler.NewUserRestrictionHandler)
 
+	internal.Provide(di, service.NewAvatarService)
 	internal.Provide(di, service.NewContextService)
 	internal.Provide(di, service.NewClientInfoService)
+	internal.Provide(di, service.NewDataExportService)
+	internal.Provide(di, service.NewEmailConfirmationService)
 	internal.Provide(di, service.NewEmailService)
 	internal.Provide(di, service.NewFeedService)
 	internal.Provide(di, service.NewFollowerService)
 	internal.Provide(di, service.NewLikeService)
+	internal.Provide(di, service.NewOIDCService)
+	internal.Provide(di, service.NewPersonalAccessTokenService)
 	internal.Provide(di, service.NewPostService)
+	internal.Provide(di, service.NewProfileService)
 	internal.Provide(di, service.NewQueueService)
+	internal.Provide(di, service.NewSecurityEventService)
 	internal.Provide(di, service.NewSessionService)
+	internal.Provide(di, service.NewTwoFactorService)
 	internal.Provide(di, service.NewUserService)
+	internal.Provide(di, service.NewUserRestrictionService)
 
+	internal.Provide(di, repository.NewAccountStatusRepository)
+	internal.Provide(di, repository.NewDataExportRepository)
+	internal.Provide(di, repository.NewEmailConfirmationRepository)
+	internal.Provide(di, repository.NewExternalIdentityRepository)
 	internal.Provide(di, repository.NewFollowerRepository)
+	internal.Provide(di, repository.NewKnownDeviceRepository)
 	internal.Provide(di, repository.NewLikeRepository)
 	internal.Provide(di, repository.NewMemoryCacheRepository)
+	internal.Provide(di, repository.NewModerationRepository)
+	internal.Provide(di, repository.NewOIDCStateRepository)
+	internal.Provide(di, repository.NewOTPRepository)
+	internal.Provide(di, repository.NewPasswordResetRepository)
+	internal.Provide(di, repository.NewPersonalAccessTokenRepository)
 	internal.Provide(di, repository.NewPostRepository)
+	internal.Provide(di, repository.NewProfileRepository)
+	internal.Provide(di, repository.NewSecurityEventRepository)
 	internal.Provide(di, repository.NewSessionRepository)
+	internal.Provide(di, repository.NewSignInAttemptRepository)
+	internal.Provide(di, repository.NewSignInReportRepository)
+	internal.Provide(di, repository.NewTwoFactorRepository)
 	internal.Provide(di, repository.NewUserRepository)
+	internal.Provide(di, repository.NewUserRestrictionRepository)
 
 	router.SetupRoutes(e, di)
 	e.Logger.Fatal(e.Start(fmt.Sprintf(":%s", config.Env.APIPort)))
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupAdminRoutes(e *echo.Echo, di *internal.Di) {
+	userHandler, err := internal.Invoke[domain.UserHandler](di)
+	if err != nil {
+		log.Fatal("error to create user handler: ", err)
+	}
+
+	group := e.Group("/v1/admin", middleware.EnsureAuthenticated(di), middleware.RequireRole(domain.RoleAdmin))
+
+	group.PUT("/users/:id/role", userHandler.UpdateUserRole)
+	group.POST("/users/:id/block", userHandler.BlockUser)
+	group.POST("/users/:id/unblock", userHandler.UnblockUser)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+func setupAvatarRoutes(e *echo.Echo, di *internal.Di) {
+	avatarHandler, err := internal.Invoke[domain.AvatarHandler](di)
+	if err != nil {
+		log.Fatal("error to create avatar handler: ", err)
+	}
+
+	uploadRateLimiter := newIPRateLimiter(rate.Every(time.Minute), 5, 10*time.Minute)
+
+	group := e.Group("/v1/users/me/avatar", middleware.EnsureAuthenticated(di))
+
+	group.PUT("", avatarHandler.UploadAvatar, echomiddleware.BodyLimit("6M"), uploadRateLimiter)
+	group.DELETE("", avatarHandler.DeleteAvatar)
+
+	e.GET("/v1/media/avatars/*", avatarHandler.GetAvatar)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupDataExportRoutes(e *echo.Echo, di *internal.Di) {
+	dataExportHandler, err := internal.Invoke[domain.DataExportHandler](di)
+	if err != nil {
+		log.Fatal("error to create data export handler: ", err)
+	}
+
+	e.POST("/v1/users/me/data-exports", dataExportHandler.RequestDataExport, middleware.EnsureAuthenticated(di))
+	e.GET("/v1/users/data-exports/download", dataExportHandler.DownloadDataExport)
+}
@@ -15,7 +15,7 @@ func setupFeedRoutes(e *echo.Echo, di *internal.Di) {
 		log.Fatal("error to create user handler: ", err)
 	}
 
-	group := e.Group("/v1/feed", middleware.EnsureAuthenticated(di))
+	group := e.Group("/v1/feed", middleware.EnsureAuthenticated(di, domain.ScopeFeedRead))
 
 	group.GET("", feedHandler.GetFeed)
 }
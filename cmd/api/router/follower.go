@@ -16,11 +16,14 @@ func setupFollowerRoutes(e *echo.Echo, di *internal.Di) {
 		log.Fatal("error to create follower handler: ", err)
 	}
 
-	group := e.Group("/v1/followers", middleware.EnsureAuthenticated(di))
+	canRead := middleware.EnsureAuthenticated(di, domain.ScopeFollowersRead)
+	canWrite := middleware.EnsureAuthenticated(di, domain.ScopeFollowersWrite)
 
-	group.POST("/:userId", followerHandler.FollowUser)
-	group.DELETE("/:userId", followerHandler.UnfollowUser)
-	group.GET("", followerHandler.GetFollowers)
-	group.GET("/fowllings", followerHandler.GetFollowings)
+	group := e.Group("/v1/followers")
+
+	group.POST("/:userId", followerHandler.FollowUser, canWrite)
+	group.DELETE("/:userId", followerHandler.UnfollowUser, canWrite)
+	group.GET("", followerHandler.GetFollowers, canRead)
+	group.GET("/fowllings", followerHandler.GetFollowings, canRead)
 
 }
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newIPExtractor reads the client IP from X-Forwarded-For only when the
+// request comes through one of the trusted proxies. Without any, the header is
+// ignored and the address of the connection is used, so clients cannot spoof
+// their IP to get around the per-IP limits.
+func newIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
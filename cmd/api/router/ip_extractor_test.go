@@ -0,0 +1,37 @@
+package router
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	_, proxyRange, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		remoteAddr     string
+		forwardedFor   string
+		expected       string
+	}{
+		{"no trusted proxies ignores the header", nil, "203.0.113.7:4000", "198.51.100.1", "203.0.113.7"},
+		{"untrusted peer cannot spoof the header", []*net.IPNet{proxyRange}, "203.0.113.7:4000", "198.51.100.1", "203.0.113.7"},
+		{"trusted proxy forwards the client IP", []*net.IPNet{proxyRange}, "10.1.2.3:4000", "198.51.100.1", "198.51.100.1"},
+		{"spoofed entries before the real client are skipped", []*net.IPNet{proxyRange}, "10.1.2.3:4000", "1.1.1.1, 198.51.100.1", "198.51.100.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/", nil)
+			request.RemoteAddr = test.remoteAddr
+			request.Header.Set(echo.HeaderXForwardedFor, test.forwardedFor)
+
+			assert.Equal(t, test.expected, newIPExtractor(test.trustedProxies)(request))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func setupPersonalAccessTokenRoutes(e *echo.Echo, di *internal.Di) {
+	personalAccessTokenHandler, err := internal.Invoke[domain.PersonalAccessTokenHandler](di)
+	if err != nil {
+		log.Fatal("error to create personal access token handler: ", err)
+	}
+
+	group := e.Group("/v1/users/tokens", middleware.EnsureAuthenticated(di))
+
+	group.POST("", personalAccessTokenHandler.CreateToken)
+	group.GET("", personalAccessTokenHandler.GetTokens)
+	group.DELETE("/:id", personalAccessTokenHandler.RevokeToken)
+}
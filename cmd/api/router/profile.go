@@ -0,0 +1,38 @@
+package router
+
+import (
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+func setupProfileRoutes(e *echo.Echo, di *internal.Di) {
+	profileHandler, err := internal.Invoke[domain.ProfileHandler](di)
+	if err != nil {
+		log.Fatal("error to create profile handler: ", err)
+	}
+
+	userRestrictionHandler, err := internal.Invoke[domain.UserRestrictionHandler](di)
+	if err != nil {
+		log.Fatal("error to create user restriction handler: ", err)
+	}
+
+	canRead := middleware.EnsureAuthenticated(di, domain.ScopeFollowersRead)
+	canWrite := middleware.EnsureAuthenticated(di, domain.ScopeFollowersWrite)
+
+	searchRateLimiter := newIPRateLimiter(rate.Limit(5), 20, 3*time.Minute)
+
+	group := e.Group("/v1/users")
+
+	group.GET("/search", profileHandler.SearchUsers, canRead, searchRateLimiter)
+	group.GET("/:username", profileHandler.GetProfile, canRead)
+	group.POST("/:userId/block", userRestrictionHandler.BlockUser, canWrite)
+	group.DELETE("/:userId/block", userRestrictionHandler.UnblockUser, canWrite)
+	group.POST("/:userId/mute", userRestrictionHandler.MuteUser, canWrite)
+	group.DELETE("/:userId/mute", userRestrictionHandler.UnmuteUser, canWrite)
+}
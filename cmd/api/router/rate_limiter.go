@@ -0,0 +1,29 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+func newIPRateLimiter(limit rate.Limit, burst int, expiresIn time.Duration) echo.MiddlewareFunc {
+	return echomiddleware.RateLimiterWithConfig(echomiddleware.RateLimiterConfig{
+		Skipper: echomiddleware.DefaultSkipper,
+		Store: echomiddleware.NewRateLimiterMemoryStoreWithConfig(
+			echomiddleware.RateLimiterMemoryStoreConfig{Rate: limit, Burst: burst, ExpiresIn: expiresIn},
+		),
+		IdentifierExtractor: func(ctx echo.Context) (string, error) {
+			id := ctx.RealIP()
+			return id, nil
+		},
+		ErrorHandler: func(context echo.Context, err error) error {
+			return context.JSON(http.StatusForbidden, nil)
+		},
+		DenyHandler: func(context echo.Context, identifier string, err error) error {
+			return context.JSON(http.StatusTooManyRequests, nil)
+		},
+	})
+}
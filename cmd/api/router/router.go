@@ -1,13 +1,29 @@
 package router
 
 import (
+	"github.com/G-Villarinho/social-network/config"
 	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
 	"github.com/labstack/echo/v4"
 )
 
 func SetupRoutes(e *echo.Echo, di *internal.Di) {
+	// the rate limiters and ClientInfo rely on RealIP, so the extractor has to
+	// be in place before any request is served
+	e.IPExtractor = newIPExtractor(config.Env.TrustedProxyRanges)
+
+	e.Use(middleware.CSRF)
+
 	setupUserRoutes(e, di)
+	setupAvatarRoutes(e, di)
+	setupSessionRoutes(e, di)
+	setupSecurityEventRoutes(e, di)
+	setupTwoFactorRoutes(e, di)
+	setupDataExportRoutes(e, di)
+	setupPersonalAccessTokenRoutes(e, di)
 	setupFollowerRoutes(e, di)
+	setupProfileRoutes(e, di)
 	setupPostRoutes(e, di)
 	setupFeedRoutes(e, di)
+	setupAdminRoutes(e, di)
 }
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupSecurityEventRoutes(e *echo.Echo, di *internal.Di) {
+	securityEventHandler, err := internal.Invoke[domain.SecurityEventHandler](di)
+	if err != nil {
+		log.Fatal("error to create security event handler: ", err)
+	}
+
+	e.GET("/v1/users/me/security-events", securityEventHandler.GetSecurityEvents, middleware.EnsureAuthenticated(di))
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func setupSessionRoutes(e *echo.Echo, di *internal.Di) {
+	sessionHandler, err := internal.Invoke[domain.SessionHandler](di)
+	if err != nil {
+		log.Fatal("error to create session handler: ", err)
+	}
+
+	e.GET("/.well-known/jwks.json", sessionHandler.GetJWKS)
+	e.GET("/v1/csrf", sessionHandler.GetCSRFToken)
+	e.POST("/v1/users/token/refresh", sessionHandler.RefreshToken)
+
+	group := e.Group("/v1/users/sessions", middleware.EnsureAuthenticated(di))
+
+	group.GET("", sessionHandler.GetSessions)
+	group.DELETE("", sessionHandler.RevokeOtherSessions, middleware.ClientInfo)
+	group.DELETE("/:id", sessionHandler.RevokeSession, middleware.ClientInfo)
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/middleware"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+func setupTwoFactorRoutes(e *echo.Echo, di *internal.Di) {
+	twoFactorHandler, err := internal.Invoke[domain.TwoFactorHandler](di)
+	if err != nil {
+		log.Fatal("error to create two-factor handler: ", err)
+	}
+
+	codeRateLimiter := newIPRateLimiter(rate.Every(12*time.Second), 5, 10*time.Minute)
+
+	group := e.Group("/v1/users/me/2fa", middleware.EnsureAuthenticated(di))
+
+	group.POST("/totp", twoFactorHandler.BeginEnrollment)
+	group.POST("/totp/confirm", twoFactorHandler.ConfirmEnrollment, codeRateLimiter, middleware.ClientInfo)
+	group.DELETE("", twoFactorHandler.DisableTwoFactor, codeRateLimiter, middleware.ClientInfo)
+}
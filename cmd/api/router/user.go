@@ -2,12 +2,14 @@ package router
 
 import (
 	"log"
+	"time"
 
 	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/internal"
 	"github.com/G-Villarinho/social-network/middleware"
 
 	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
 )
 
 func setupUserRoutes(e *echo.Echo, di *internal.Di) {
@@ -16,11 +18,24 @@ func setupUserRoutes(e *echo.Echo, di *internal.Di) {
 		log.Fatal("error to create user handler: ", err)
 	}
 
+	emailRateLimiter := newIPRateLimiter(rate.Every(time.Minute), 3, 10*time.Minute)
+	twoFactorRateLimiter := newIPRateLimiter(rate.Every(12*time.Second), 5, 10*time.Minute)
+
 	group := e.Group("/v1/users")
 
 	group.POST("", userHandler.CreateUser)
+	group.POST("/confirm-email", userHandler.ConfirmEmail)
+	group.POST("/confirm-email/resend", userHandler.ResendEmailConfirmation, emailRateLimiter)
 	group.POST("/sign-in", userHandler.SignIn, middleware.ClientInfo)
-	group.POST("/sign-out", userHandler.SignOut, middleware.EnsureAuthenticated(di))
+	group.POST("/sign-in/verify", userHandler.VerifySignIn, twoFactorRateLimiter, middleware.ClientInfo)
+	group.POST("/sign-in/2fa", userHandler.VerifyTwoFactorSignIn, twoFactorRateLimiter, middleware.ClientInfo)
+	group.POST("/sign-in/report", userHandler.ReportSignIn, middleware.ClientInfo)
+	group.GET("/oidc/:provider", userHandler.GetOIDCAuthorizationURL)
+	group.POST("/oidc/:provider/callback", userHandler.SignInWithOIDC, middleware.ClientInfo)
+	group.POST("/password/forgot", userHandler.ForgotPassword, emailRateLimiter)
+	group.POST("/password/reset", userHandler.ResetPassword, middleware.ClientInfo)
+	group.PUT("/password", userHandler.ChangePassword, middleware.EnsureAuthenticated(di), middleware.ClientInfo)
+	group.POST("/sign-out", userHandler.SignOut, middleware.EnsureAuthenticated(di), middleware.ClientInfo)
 	group.GET("/me", userHandler.GetUser, middleware.EnsureAuthenticated(di))
 	group.PUT("", userHandler.UpdateUser, middleware.EnsureAuthenticated(di))
 	group.DELETE("", userHandler.DeleteUser, middleware.EnsureAuthenticated(di))
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/database"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/repository"
+	"github.com/G-Villarinho/social-network/service"
+	"github.com/go-redis/redis/v8"
+	jsoniter "github.com/json-iterator/go"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	di := internal.NewDi()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to mysql: ", err)
+	}
+
+	redisClient, err := database.NewRedisConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to redis: ", err)
+	}
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	internal.Provide(di, func(d *internal.Di) (*redis.Client, error) {
+		return redisClient, nil
+	})
+
+	rabbitMQClient, err := client.NewRabbitMQClient(di)
+	if err != nil {
+		log.Fatal("error initializing RabbitMQ client: ", err)
+	}
+	if err := rabbitMQClient.Connect(); err != nil {
+		log.Fatal("error connecting to RabbitMQ: ", err)
+	}
+	defer func() {
+		if err := rabbitMQClient.Disconnect(); err != nil {
+			log.Println("error disconnecting from RabbitMQ:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, client.NewStorage)
+
+	internal.Provide(di, service.NewContextService)
+	internal.Provide(di, service.NewDataExportService)
+	internal.Provide(di, service.NewQueueService)
+
+	internal.Provide(di, repository.NewDataExportRepository)
+	internal.Provide(di, repository.NewFollowerRepository)
+	internal.Provide(di, repository.NewLikeRepository)
+	internal.Provide(di, repository.NewPostRepository)
+	internal.Provide(di, repository.NewSecurityEventRepository)
+	internal.Provide(di, repository.NewUserRepository)
+
+	dataExportService, err := internal.Invoke[domain.DataExportService](di)
+	if err != nil {
+		log.Fatal("error to create data export service: ", err)
+	}
+
+	queueService, err := internal.Invoke[domain.QueueService](di)
+	if err != nil {
+		log.Fatal("error to create queue service: ", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(domain.DataExportPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := dataExportService.PurgeExpiredExports(context.Background())
+			if err != nil {
+				log.Println("error purging expired data exports: ", err)
+			} else {
+				log.Printf("expired data exports purged: %d", purged)
+			}
+
+			<-ticker.C
+		}
+	}()
+
+	for {
+		messages, err := queueService.Consume(domain.QueueDataExport)
+		if err != nil {
+			log.Fatal("error to consume message from queue: ", err)
+		}
+
+		for message := range messages {
+			var task domain.DataExportTask
+			if err := jsoniter.Unmarshal(message, &task); err != nil {
+				log.Println("error unmarshalling data export task: ", err)
+				continue
+			}
+
+			if err := dataExportService.ProcessDataExport(context.Background(), task.ExportID); err != nil {
+				log.Println("error processing data export: ", err)
+				continue
+			}
+
+			log.Printf("data export processed: %s", task.ExportID)
+		}
+	}
+}
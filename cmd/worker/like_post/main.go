@@ -70,6 +70,7 @@ func main() {
 	internal.Provide(di, service.NewLikeService)
 
 	internal.Provide(di, repository.NewMemoryCacheRepository)
+	internal.Provide(di, repository.NewModerationRepository)
 	internal.Provide(di, repository.NewPostRepository)
 	internal.Provide(di, repository.NewSessionRepository)
 	internal.Provide(di, repository.NewLikeRepository)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/database"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/repository"
+	"github.com/G-Villarinho/social-network/service"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	di := internal.NewDi()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to mysql: ", err)
+	}
+
+	redisClient, err := database.NewRedisConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to redis: ", err)
+	}
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	internal.Provide(di, func(d *internal.Di) (*redis.Client, error) {
+		return redisClient, nil
+	})
+
+	internal.Provide(di, client.NewStorage)
+
+	internal.Provide(di, service.NewAccountPurgeService)
+
+	internal.Provide(di, repository.NewAccountPurgeRepository)
+	internal.Provide(di, repository.NewDataExportRepository)
+	internal.Provide(di, repository.NewSessionRepository)
+	internal.Provide(di, repository.NewUserRepository)
+
+	accountPurgeService, err := internal.Invoke[domain.AccountPurgeService](di)
+	if err != nil {
+		log.Fatal("error to create account purge service: ", err)
+	}
+
+	ticker := time.NewTicker(domain.AccountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := accountPurgeService.PurgeDeletedAccounts(context.Background())
+		if err != nil {
+			log.Println("error purging deleted accounts: ", err)
+		} else {
+			log.Printf("deleted accounts purged: %d", purged)
+		}
+
+		<-ticker.C
+	}
+}
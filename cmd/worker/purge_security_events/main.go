@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/database"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/repository"
+	"github.com/G-Villarinho/social-network/service"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	di := internal.NewDi()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to mysql: ", err)
+	}
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	internal.Provide(di, service.NewClientInfoService)
+	internal.Provide(di, service.NewContextService)
+	internal.Provide(di, service.NewSecurityEventService)
+
+	internal.Provide(di, repository.NewSecurityEventRepository)
+
+	securityEventService, err := internal.Invoke[domain.SecurityEventService](di)
+	if err != nil {
+		log.Fatal("error to create security event service: ", err)
+	}
+
+	ticker := time.NewTicker(domain.SecurityEventPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := securityEventService.PurgeExpiredEvents(context.Background())
+		if err != nil {
+			log.Println("error purging security events: ", err)
+		} else {
+			log.Printf("security events purged: %d", deleted)
+		}
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/service"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	di := internal.NewDi()
+
+	rabbitMQClient, err := client.NewRabbitMQClient(di)
+	if err != nil {
+		log.Fatal("error initializing RabbitMQ client: ", err)
+	}
+	if err := rabbitMQClient.Connect(); err != nil {
+		log.Fatal("error connecting to RabbitMQ: ", err)
+	}
+	defer func() {
+		if err := rabbitMQClient.Disconnect(); err != nil {
+			log.Println("error disconnecting from RabbitMQ:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, service.NewEmailService)
+	internal.Provide(di, service.NewQueueService)
+
+	emailService, err := internal.Invoke[domain.EmailService](di)
+	if err != nil {
+		log.Fatal("error to create email service: ", err)
+	}
+
+	queueService, err := internal.Invoke[domain.QueueService](di)
+	if err != nil {
+		log.Fatal("error to create queue service: ", err)
+	}
+
+	for {
+		messages, err := queueService.Consume(domain.QueueSendEmail)
+		if err != nil {
+			log.Fatal("error to consume message from queue: ", err)
+		}
+
+		for message := range messages {
+			var task domain.EmailPayloadTask
+			if err := jsoniter.Unmarshal(message, &task); err != nil {
+				log.Println("error unmarshalling email task: ", err)
+				continue
+			}
+
+			if err := emailService.SendEmail(context.Background(), task); err != nil {
+				log.Println("error sending email: ", err)
+				continue
+			}
+
+			log.Printf("email processed: %s", task.Template)
+		}
+	}
+}
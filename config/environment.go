@@ -3,12 +3,18 @@ package config
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/G-Villarinho/social-network/config/model"
+	"github.com/G-Villarinho/social-network/secure"
 	"github.com/Netflix/go-env"
 	"github.com/joho/godotenv"
 )
@@ -26,48 +32,185 @@ func LoadEnvironments() {
 		panic(err)
 	}
 
-	Env.PrivateKey, err = loadPrivateKey()
+	Env.KeyRing, err = loadKeyRing()
 	if err != nil {
 		panic(err)
 	}
 
-	Env.PublicKey, err = loadPublicKey()
+	Env.OIDCProviders, err = loadOIDCProviders()
 	if err != nil {
 		panic(err)
 	}
 
+	Env.TrustedProxyRanges, err = loadTrustedProxyRanges()
+	if err != nil {
+		panic(err)
+	}
+
+	Env.TwoFactorKey, err = loadTwoFactorKey()
+	if err != nil {
+		panic(err)
+	}
+
+	secure.SetPasswordHasher(secure.NewArgon2idHasher(loadArgon2idParams()))
 }
 
-func loadPrivateKey() (*ecdsa.PrivateKey, error) {
-	keyData, err := os.ReadFile("ec_private_key.pem")
+// loadArgon2idParams overrides the defaults with the ARGON2_* variables that
+// are set. Changing them makes existing hashes get upgraded on next sign-in.
+func loadArgon2idParams() secure.Argon2idParams {
+	params := secure.DefaultArgon2idParams
+
+	if Env.PasswordHash.Memory > 0 {
+		params.Memory = Env.PasswordHash.Memory
+	}
+
+	if Env.PasswordHash.Iterations > 0 {
+		params.Iterations = Env.PasswordHash.Iterations
+	}
+
+	if Env.PasswordHash.Parallelism > 0 {
+		params.Parallelism = Env.PasswordHash.Parallelism
+	}
+
+	return params
+}
+
+// loadTrustedProxyRanges parses TRUSTED_PROXIES, a comma separated list of
+// CIDRs or single IPs of the proxies allowed to set X-Forwarded-For.
+func loadTrustedProxyRanges() ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+
+	for _, value := range strings.Split(Env.TrustedProxies, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q", value)
+			}
+
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipRange, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", value, err)
+		}
+		ranges = append(ranges, ipRange)
+	}
+
+	return ranges, nil
+}
+
+// loadTwoFactorKey decodes TWO_FACTOR_ENCRYPTION_KEY. It is optional so that
+// services which do not handle TOTP can start without it.
+func loadTwoFactorKey() ([]byte, error) {
+	if Env.TwoFactor.EncryptionKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(Env.TwoFactor.EncryptionKey)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error to decode TWO_FACTOR_ENCRYPTION_KEY: %w", err)
 	}
 
-	block, _ := pem.Decode(keyData)
-	if block == nil || block.Type != "EC PRIVATE KEY" {
-		return nil, errors.New("error to decode PEM block containing private key")
+	if len(key) != 32 {
+		return nil, errors.New("TWO_FACTOR_ENCRYPTION_KEY must decode to 32 bytes")
 	}
 
-	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+	return key, nil
+}
+
+// loadKeyRing reads every "<kid>.pem" file in JWT_KEYS_DIR. Private keys can
+// sign and verify, public keys are kept only to verify tokens issued before a
+// rotation. Without JWT_KEYS_DIR the single ec_private_key.pem is used and its
+// thumbprint becomes the key ID.
+func loadKeyRing() (*secure.KeyRing, error) {
+	if Env.JWT.KeysDir == "" {
+		privateKey, err := loadPrivateKey("ec_private_key.pem")
+		if err != nil {
+			return nil, err
+		}
+
+		keyID, err := secure.KeyID(&privateKey.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return secure.NewKeyRing(keyID, privateKey, nil)
+	}
+
+	if Env.JWT.ActiveKeyID == "" {
+		return nil, errors.New("JWT_ACTIVE_KEY_ID is required when JWT_KEYS_DIR is set")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(Env.JWT.KeysDir, "*.pem"))
 	if err != nil {
 		return nil, err
 	}
 
-	return privateKey, nil
+	var signingKey *ecdsa.PrivateKey
+	verificationKeys := make(map[string]*ecdsa.PublicKey, len(paths))
+	for _, path := range paths {
+		keyID := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+		block, err := readPEMBlock(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch block.Type {
+		case "EC PRIVATE KEY":
+			privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error to parse private key %s: %w", path, err)
+			}
+
+			if keyID == Env.JWT.ActiveKeyID {
+				signingKey = privateKey
+			}
+			verificationKeys[keyID] = &privateKey.PublicKey
+		case "PUBLIC KEY":
+			publicKey, err := parsePublicKey(block)
+			if err != nil {
+				return nil, fmt.Errorf("error to parse public key %s: %w", path, err)
+			}
+			verificationKeys[keyID] = publicKey
+		default:
+			return nil, fmt.Errorf("unexpected PEM block %q in %s", block.Type, path)
+		}
+	}
+
+	return secure.NewKeyRing(Env.JWT.ActiveKeyID, signingKey, verificationKeys)
 }
 
-func loadPublicKey() (*ecdsa.PublicKey, error) {
-	keyData, err := os.ReadFile("ec_public_key.pem")
+func loadPrivateKey(path string) (*ecdsa.PrivateKey, error) {
+	block, err := readPEMBlock(path)
 	if err != nil {
 		return nil, err
 	}
 
-	block, _ := pem.Decode(keyData)
-	if block == nil || block.Type != "PUBLIC KEY" {
-		return nil, errors.New("error to decode PEM block containing public key")
+	if block.Type != "EC PRIVATE KEY" {
+		return nil, errors.New("error to decode PEM block containing private key")
 	}
 
+	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey, nil
+}
+
+func parsePublicKey(block *pem.Block) (*ecdsa.PublicKey, error) {
 	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		return nil, err
@@ -81,9 +224,58 @@ func loadPublicKey() (*ecdsa.PublicKey, error) {
 	return ecdsaPubKey, nil
 }
 
+func readPEMBlock(path string) (*pem.Block, error) {
+	keyData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return nil, fmt.Errorf("error to decode PEM block in %s", path)
+	}
+
+	return block, nil
+}
+
 func ConfigureLogger() {
 	handler := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: false,
 	}))
 	slog.SetDefault(handler)
 }
+
+// loadOIDCProviders reads OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID,
+// OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL and the optional
+// OIDC_<NAME>_SCOPES for every provider listed in OIDC_PROVIDERS.
+func loadOIDCProviders() (map[string]model.OIDCProviderEnvironment, error) {
+	providers := make(map[string]model.OIDCProviderEnvironment)
+
+	for _, name := range strings.Split(Env.OIDC.Providers, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := fmt.Sprintf("OIDC_%s_", strings.ToUpper(name))
+		provider := model.OIDCProviderEnvironment{
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			return nil, fmt.Errorf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", prefix, prefix, prefix)
+		}
+
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		providers[name] = provider
+	}
+
+	return providers, nil
+}
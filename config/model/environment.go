@@ -1,10 +1,24 @@
 package model
 
-import "crypto/ecdsa"
+import (
+	"net"
+
+	"github.com/G-Villarinho/social-network/secure"
+)
 
 type Environment struct {
-	PrivateKey          *ecdsa.PrivateKey
-	PublicKey           *ecdsa.PublicKey
+	KeyRing             *secure.KeyRing
+	TwoFactorKey        []byte
+	OIDCProviders       map[string]OIDCProviderEnvironment
+	TrustedProxyRanges  []*net.IPNet
+	JWT                 JWTEnvironment
+	OIDC                OIDCEnvironment
+	PasswordHash        PasswordHashEnvironment
+	SecurityEvent       SecurityEventEnvironment
+	AccountDeletion     AccountDeletionEnvironment
+	DataExport          DataExportEnvironment
+	TwoFactor           TwoFactorEnvironment
+	Storage             StorageEnvironment
 	Redis               RedisEnvironment
 	CloudFlare          CloudFlareEnvironment
 	Cache               CacheEnvironment
@@ -16,6 +30,7 @@ type Environment struct {
 	APIPort             string `env:"API_PORT"`
 	ConnectionString    string `env:"CONNECTION_STRING"`
 	FrontURL            string `env:"FRONT_URL"`
+	TrustedProxies      string `env:"TRUSTED_PROXIES"`
 }
 
 type RedisEnvironment struct {
@@ -24,16 +39,81 @@ type RedisEnvironment struct {
 	Password string `env:"REDIS_PASSWORD"`
 }
 
+type JWTEnvironment struct {
+	KeysDir     string `env:"JWT_KEYS_DIR"`
+	ActiveKeyID string `env:"JWT_ACTIVE_KEY_ID"`
+	Issuer      string `env:"JWT_ISSUER"`
+}
+
+type OIDCEnvironment struct {
+	Providers string `env:"OIDC_PROVIDERS"`
+	StateExp  int    `env:"OIDC_STATE_EXP"`
+}
+
+// OIDCProviderEnvironment is read from OIDC_<NAME>_* variables for every name
+// listed in OIDC_PROVIDERS.
+type OIDCProviderEnvironment struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// PasswordHashEnvironment tunes Argon2id. Zero values fall back to
+// secure.DefaultArgon2idParams.
+type PasswordHashEnvironment struct {
+	Memory      uint32 `env:"ARGON2_MEMORY"`
+	Iterations  uint32 `env:"ARGON2_ITERATIONS"`
+	Parallelism uint8  `env:"ARGON2_PARALLELISM"`
+}
+
+type SecurityEventEnvironment struct {
+	RetentionDays int `env:"SECURITY_EVENT_RETENTION_DAYS"`
+}
+
+type AccountDeletionEnvironment struct {
+	GraceDays int `env:"ACCOUNT_DELETION_GRACE_DAYS"`
+}
+
+type DataExportEnvironment struct {
+	LinkExp int `env:"DATA_EXPORT_LINK_EXP"`
+}
+
+// TwoFactorEnvironment configures TOTP. EncryptionKey is a base64 encoded
+// 32-byte key used to encrypt the secrets at rest.
+type TwoFactorEnvironment struct {
+	Issuer        string `env:"TWO_FACTOR_ISSUER"`
+	EncryptionKey string `env:"TWO_FACTOR_ENCRYPTION_KEY"`
+}
+
+// StorageEnvironment selects where files are kept. PublicURL is the base URL
+// public files such as avatars are served from, the S3 settings are only read
+// by the s3 driver and work with any S3-compatible service like Cloudflare R2.
+type StorageEnvironment struct {
+	Driver            string `env:"STORAGE_DRIVER"`
+	LocalDir          string `env:"STORAGE_LOCAL_DIR"`
+	PublicURL         string `env:"STORAGE_PUBLIC_URL"`
+	S3Endpoint        string `env:"STORAGE_S3_ENDPOINT"`
+	S3Region          string `env:"STORAGE_S3_REGION"`
+	S3Bucket          string `env:"STORAGE_S3_BUCKET"`
+	S3AccessKeyID     string `env:"STORAGE_S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `env:"STORAGE_S3_SECRET_ACCESS_KEY"`
+}
+
 type CloudFlareEnvironment struct {
 	CloudFlareAccountAPI string `env:"CLOUD_FLARE_ACCOUNT_API"`
 	CloudFlareApiKey     string `env:"CLOUD_FLARE_API_KEY"`
 }
 
 type CacheEnvironment struct {
-	SessionExp      int `env:"SESSION_EXP"`
-	CacheExp        int `env:"CACHE_EXP"`
-	Hash2FADuration int `env:"HASH_2FA_DURATION"`
-	Code2FADuration int `env:"CODE_2FA_DURATION"`
+	SessionExp           int `env:"SESSION_EXP"`
+	AccessTokenExp       int `env:"ACCESS_TOKEN_EXP"`
+	CacheExp             int `env:"CACHE_EXP"`
+	Hash2FADuration      int `env:"HASH_2FA_DURATION"`
+	Code2FADuration      int `env:"CODE_2FA_DURATION"`
+	EmailConfirmationExp int `env:"EMAIL_CONFIRMATION_EXP"`
+	PasswordResetExp     int `env:"PASSWORD_RESET_EXP"`
 }
 
 type IpStacker struct {
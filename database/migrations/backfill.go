@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/G-Villarinho/social-network/domain"
+	"gorm.io/gorm"
+)
+
+// backfillEmailConfirmation marks every user without a confirmation date as
+// confirmed when they signed up, so that accounts older than the email
+// confirmation flow can still sign in. UpdateColumn leaves updatedAt alone.
+func backfillEmailConfirmation(db *gorm.DB) *gorm.DB {
+	return db.Model(&domain.User{}).
+		Where("emailConfirmedAt IS NULL").
+		UpdateColumn("emailConfirmedAt", gorm.Expr("createdAt"))
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestBackfillEmailConfirmation_ShouldConfirmOnlyUsersWithoutConfirmationDate(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(localhost:3306)/social_network",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	statement := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return backfillEmailConfirmation(tx)
+	})
+
+	assert.Equal(t, "UPDATE `User` SET `emailConfirmedAt`=createdAt WHERE emailConfirmedAt IS NULL", statement)
+}
@@ -20,14 +20,35 @@ func main() {
 		log.Fatal("error to connect to mysql: ", err)
 	}
 
+	// accounts created before email confirmation existed are trusted as
+	// confirmed, the backfill only runs when the column is first added
+	confirmExistingEmails := !db.Migrator().HasColumn(&domain.User{}, "emailConfirmedAt")
+
 	if err := db.AutoMigrate(
 		&domain.User{},
 		&domain.Follower{},
 		&domain.Post{},
 		&domain.Like{},
+		&domain.PersonalAccessToken{},
+		&domain.ModerationAction{},
+		&domain.ExternalIdentity{},
+		&domain.SecurityEvent{},
+		&domain.KnownDevice{},
+		&domain.DataExport{},
+		&domain.TwoFactor{},
+		&domain.RecoveryCode{},
+		&domain.UserRestriction{},
 	); err != nil {
 		log.Fatal("error to migrate: ", err)
 	}
 
+	if confirmExistingEmails {
+		result := backfillEmailConfirmation(db)
+		if result.Error != nil {
+			log.Fatal("error to backfill email confirmation: ", result.Error)
+		}
+		log.Printf("Email confirmation backfilled for %d users", result.RowsAffected)
+	}
+
 	log.Println("Migration executed successfully")
 }
@@ -0,0 +1,33 @@
+package domain
+
+//go:generate mockery --name=AccountPurgeService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=AccountPurgeRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAccountDeletionGraceDays = 30
+	AccountPurgeBatchSize           = 500
+	AccountPurgeInterval            = time.Hour
+	AccountDeletionReason           = "deletion requested by the user"
+)
+
+type AccountPurgeService interface {
+	PurgeDeletedAccounts(ctx context.Context) (int, error)
+}
+
+// AccountPurgeRepository removes what an account leaves behind once its
+// deletion grace period is over. Deletes run in batches so that an account with
+// a long history does not hold locks for long.
+type AccountPurgeRepository interface {
+	GetAccountsDueForPurge(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+	DeleteLikes(ctx context.Context, userID uuid.UUID, limit int) (int64, error)
+	DeletePosts(ctx context.Context, userID uuid.UUID, limit int) (int64, error)
+	DeleteFollows(ctx context.Context, userID uuid.UUID, limit int) (int64, error)
+	DeleteCachedKeys(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package domain
+
+//go:generate mockery --name=AccountStatusRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AccountStatusRepository mirrors the status of accounts that are not active
+// so that every authenticated request can honour it without reading the user
+// from the database.
+type AccountStatusRepository interface {
+	SetStatus(ctx context.Context, userID uuid.UUID, status UserStatus) error
+	GetStatus(ctx context.Context, userID uuid.UUID) (UserStatus, error)
+}
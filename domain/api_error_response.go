@@ -85,6 +85,31 @@ func ForbiddenPermissionAPIErrorResponse(ctx echo.Context) error {
 	return ctx.JSON(http.StatusForbidden, errorResponse)
 }
 
+func AccountStatusAPIErrorResponse(ctx echo.Context, err error) error {
+	details := "Your account is inactive."
+	if err == ErrUserBlocked {
+		details = "Your account has been blocked. Please contact support."
+	}
+
+	errorResponse := ErrorResponse{
+		StatusCode: http.StatusForbidden,
+		Title:      "Account Unavailable",
+		Details:    details,
+		Errors:     nil,
+	}
+	return ctx.JSON(http.StatusForbidden, errorResponse)
+}
+
+func CSRFTokenAPIErrorResponse(ctx echo.Context) error {
+	errorResponse := ErrorResponse{
+		StatusCode: http.StatusForbidden,
+		Title:      "Invalid CSRF Token",
+		Details:    "The request is missing a valid CSRF token. Fetch a new token and try again.",
+		Errors:     nil,
+	}
+	return ctx.JSON(http.StatusForbidden, errorResponse)
+}
+
 func convertToValidationErrorList(validationErrors ValidationErrors) []ValidationError {
 	errorList := make([]ValidationError, 0, len(validationErrors))
 	for field, message := range validationErrors {
@@ -0,0 +1,100 @@
+package domain
+
+//go:generate mockery --name=AvatarHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=AvatarService --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"path"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInvalidImage   = errors.New("invalid image")
+	ErrAvatarNotFound = errors.New("avatar not found")
+)
+
+const (
+	AvatarFormField   = "avatar"
+	AvatarKeyPrefix   = "avatars/"
+	AvatarMaxPixels   = 40_000_000
+	DefaultAvatarSize = "medium"
+	DefaultMediaURL   = "/v1/media"
+)
+
+type AvatarSize struct {
+	Name   string
+	Pixels int
+}
+
+// AvatarSizes lists the square variants generated for every upload, largest
+// first so that each one can be scaled down from the previous.
+var AvatarSizes = []AvatarSize{
+	{Name: "large", Pixels: 512},
+	{Name: DefaultAvatarSize, Pixels: 256},
+	{Name: "small", Pixels: 64},
+}
+
+type AvatarPayload struct {
+	Avatar []*multipart.FileHeader `validate:"required,min=1,validateImages=1"`
+}
+
+type AvatarResponse struct {
+	URL      string            `json:"url"`
+	Variants map[string]string `json:"variants"`
+}
+
+type AvatarFile struct {
+	Content     io.ReadCloser
+	ContentType string
+}
+
+type AvatarHandler interface {
+	UploadAvatar(ctx echo.Context) error
+	DeleteAvatar(ctx echo.Context) error
+	GetAvatar(ctx echo.Context) error
+}
+
+type AvatarService interface {
+	UploadAvatar(ctx context.Context, content io.Reader) (*AvatarResponse, error)
+	DeleteAvatar(ctx context.Context) error
+	GetAvatar(ctx context.Context, key string) (*AvatarFile, error)
+}
+
+func (p *AvatarPayload) Validate() ValidationErrors {
+	return ValidateStruct(p)
+}
+
+// AvatarVariantKey returns the key of another size of the avatar stored at
+// key, variants share the directory and the extension.
+func AvatarVariantKey(key string, size string) string {
+	return path.Join(path.Dir(key), size+path.Ext(key))
+}
+
+// MediaURL is the public URL a stored file is served from.
+func MediaURL(key string) string {
+	baseURL := config.Env.Storage.PublicURL
+	if baseURL == "" {
+		baseURL = DefaultMediaURL
+	}
+
+	return strings.TrimRight(baseURL, "/") + "/" + key
+}
+
+func NewAvatarResponse(key string) *AvatarResponse {
+	variants := make(map[string]string, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		variants[size.Name] = MediaURL(AvatarVariantKey(key, size.Name))
+	}
+
+	return &AvatarResponse{
+		URL:      variants[DefaultAvatarSize],
+		Variants: variants,
+	}
+}
@@ -1,14 +1,39 @@
 package domain
 
-import "context"
+//go:generate mockery --name=ClientInfoService --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"sync"
+)
 
 type ClientInfoResponse struct {
 	Device    string `json:"device"`
 	Location  string `json:"location"`
 	LoginTime string `json:"login_time"`
 	IP        string `json:"ip"`
+	Browser   string `json:"browser"`
+	OS        string `json:"os"`
+	Country   string `json:"country"`
 }
 
 type ClientInfoService interface {
 	GetClientInfo(ctx context.Context) (*ClientInfoResponse, error)
 }
+
+// ClientInfoCache holds the client info of one request so that every caller
+// within it shares a single lookup. middleware.ClientInfo puts an empty one in
+// the request context under ClientInfoKey.
+type ClientInfoCache struct {
+	once       sync.Once
+	clientInfo *ClientInfoResponse
+	err        error
+}
+
+func (c *ClientInfoCache) Resolve(resolve func() (*ClientInfoResponse, error)) (*ClientInfoResponse, error) {
+	c.once.Do(func() {
+		c.clientInfo, c.err = resolve()
+	})
+
+	return c.clientInfo, c.err
+}
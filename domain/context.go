@@ -11,9 +11,10 @@ import (
 type ContextKey string
 
 const (
-	SessionKey   ContextKey = "session"
-	UserAgentKey ContextKey = "user-agent"
-	ClientIPKey  ContextKey = "client-ip"
+	SessionKey    ContextKey = "session"
+	UserAgentKey  ContextKey = "user-agent"
+	ClientIPKey   ContextKey = "client-ip"
+	ClientInfoKey ContextKey = "client-info"
 )
 
 type ContextService interface {
@@ -2,11 +2,15 @@ package domain
 
 import (
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/G-Villarinho/social-network/secure"
 	"github.com/dlclark/regexp2"
 
 	"github.com/go-playground/validator/v10"
@@ -16,8 +20,15 @@ const (
 	StrongPasswordTag = "strongpassword"
 	ValidateImagesTag = "validateImages"
 	UsernameTag       = "username"
+	ScopeTag          = "scope"
+	WebsiteTag        = "website"
+	NoControlTag      = "nocontrol"
+	BirthdayTag       = "birthday"
 	General           = "general"
 	MaxImageSize      = 5 * 1024 * 1024
+	MinUsernameLength = 3
+	MaxUsernameLength = 20
+	MinPasswordScore  = 3
 )
 
 var AllowedImagesExtensions = map[string]bool{
@@ -39,18 +50,87 @@ func SetupCustomValidations(validator *validator.Validate) error {
 		return err
 	}
 
+	if err := validator.RegisterValidation(ScopeTag, scopeValidator); err != nil {
+		return err
+	}
+
+	if err := validator.RegisterValidation(WebsiteTag, websiteValidator); err != nil {
+		return err
+	}
+
+	if err := validator.RegisterValidation(NoControlTag, noControlValidator); err != nil {
+		return err
+	}
+
+	if err := validator.RegisterValidation(BirthdayTag, birthdayValidator); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func strongPasswordValidator(fl validator.FieldLevel) bool {
+	return EstimatePasswordStrength(fl.Field().String()).Strong
+}
+
+func meetsPasswordComposition(password string) bool {
 	pattern := `^(?=.*[a-z])(?=.*[A-Z])(?=.*\d)(?=.*[!@#$&*])[A-Za-z\d!@#$&*]{8,}$`
 
 	re := regexp2.MustCompile(pattern, 0)
 
-	match, _ := re.MatchString(fl.Field().String())
+	match, _ := re.MatchString(password)
 	return match
 }
 
+// EstimatePasswordStrength scores the password with secure.EstimatePasswordStrength
+// and adds feedback for the composition rules enforced at sign-up.
+func EstimatePasswordStrength(password string) PasswordStrengthResponse {
+	strength := secure.EstimatePasswordStrength(password)
+
+	suggestions := passwordCompositionFeedback(password)
+	suggestions = append(suggestions, strength.Suggestions...)
+
+	return PasswordStrengthResponse{
+		Score:       strength.Score,
+		Strong:      strength.Score >= MinPasswordScore && !strength.Breached && meetsPasswordComposition(password),
+		Breached:    strength.Breached,
+		Warning:     strength.Warning,
+		Suggestions: suggestions,
+	}
+}
+
+func passwordCompositionFeedback(password string) []string {
+	feedback := make([]string, 0)
+
+	if len([]rune(password)) < 8 {
+		feedback = append(feedback, "Use at least 8 characters.")
+	}
+
+	if !strings.ContainsAny(password, "abcdefghijklmnopqrstuvwxyz") {
+		feedback = append(feedback, "Add a lowercase letter.")
+	}
+
+	if !strings.ContainsAny(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		feedback = append(feedback, "Add an uppercase letter.")
+	}
+
+	if !strings.ContainsAny(password, "0123456789") {
+		feedback = append(feedback, "Add a number.")
+	}
+
+	if !strings.ContainsAny(password, "!@#$&*") {
+		feedback = append(feedback, "Add one of these special characters: ! @ # $ & *.")
+	}
+
+	if strings.IndexFunc(password, func(r rune) bool {
+		return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$&*", r)
+	}) >= 0 {
+		feedback = append(feedback, "Use only letters, numbers and the special characters ! @ # $ & *.")
+	}
+
+	return feedback
+}
+
 func imageFileValidator(fl validator.FieldLevel) bool {
 	param := fl.Param()
 	maxImagesAllowed, err := strconv.Atoi(param)
@@ -85,7 +165,7 @@ func imageFileValidator(fl validator.FieldLevel) bool {
 func usernameValidator(fl validator.FieldLevel) bool {
 	username := fl.Field().String()
 
-	if len(username) < 3 || len(username) > 20 {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
 		return false
 	}
 
@@ -102,3 +182,59 @@ func usernameValidator(fl validator.FieldLevel) bool {
 
 	return true
 }
+
+func scopeValidator(fl validator.FieldLevel) bool {
+	return IsValidScope(fl.Field().String())
+}
+
+// websiteValidator accepts absolute URLs with a scheme from
+// AllowedWebsiteSchemes, so that links like javascript: never reach a
+// profile. An empty value clears the field.
+func websiteValidator(fl validator.FieldLevel) bool {
+	website := fl.Field().String()
+	if website == "" {
+		return true
+	}
+
+	if hasControlCharacters(website, false) || strings.ContainsRune(website, ' ') {
+		return false
+	}
+
+	parsed, err := url.Parse(website)
+	if err != nil {
+		return false
+	}
+
+	return AllowedWebsiteSchemes[strings.ToLower(parsed.Scheme)] && parsed.Host != "" && parsed.User == nil
+}
+
+// noControlValidator rejects control characters. With the "multiline"
+// parameter line breaks are allowed.
+func noControlValidator(fl validator.FieldLevel) bool {
+	return !hasControlCharacters(fl.Field().String(), fl.Param() == "multiline")
+}
+
+// birthdayValidator accepts a YYYY-MM-DD date between MinBirthdayYear and
+// today. An empty value clears the field.
+func birthdayValidator(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true
+	}
+
+	birthday, err := time.Parse(BirthdayLayout, value)
+	if err != nil {
+		return false
+	}
+
+	return birthday.Year() >= MinBirthdayYear && !birthday.After(time.Now().UTC())
+}
+
+func hasControlCharacters(value string, allowLineBreaks bool) bool {
+	return strings.IndexFunc(value, func(r rune) bool {
+		if allowLineBreaks && (r == '\n' || r == '\r') {
+			return false
+		}
+		return unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r)
+	}) >= 0
+}
@@ -0,0 +1,180 @@
+package domain
+
+//go:generate mockery --name=DataExportHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=DataExportService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=DataExportRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "pending"
+	DataExportReady   DataExportStatus = "ready"
+	DataExportFailed  DataExportStatus = "failed"
+	DataExportExpired DataExportStatus = "expired"
+)
+
+const (
+	DefaultDataExportLinkExp = 48
+	DataExportPurgeBatchSize = 100
+	DataExportPurgeInterval  = time.Hour
+	DataExportPageSize       = 100
+)
+
+var (
+	ErrDataExportInProgress = errors.New("a data export is already in progress")
+	ErrDataExportNotFound   = errors.New("data export not found or expired")
+)
+
+// DataExport tracks a request for a copy of everything stored about a user,
+// from the moment it is queued until its download link expires.
+type DataExport struct {
+	ID          uuid.UUID        `gorm:"column:id;type:char(36);primaryKey"`
+	UserID      uuid.UUID        `gorm:"column:userId;type:char(36);not null;index"`
+	User        User             `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Status      DataExportStatus `gorm:"column:status;type:enum('pending','ready','failed','expired');default:'pending';not null"`
+	FileKey     string           `gorm:"column:fileKey;type:varchar(255);default:null"`
+	TokenHash   *string          `gorm:"column:tokenHash;type:char(64);uniqueIndex;default:null"`
+	ExpiresAt   *time.Time       `gorm:"column:expiresAt;default:null;index"`
+	CompletedAt *time.Time       `gorm:"column:completedAt;default:null"`
+	CreatedAt   time.Time        `gorm:"column:createdAt;not null"`
+}
+
+type DataExportResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	Status      DataExportStatus `json:"status"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	CompletedAt *time.Time       `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time       `json:"expiresAt,omitempty"`
+}
+
+type DataExportTask struct {
+	ExportID uuid.UUID `json:"exportId"`
+}
+
+// DataExportFile is a finished export being downloaded. The caller closes
+// Content.
+type DataExportFile struct {
+	Name    string
+	Content io.ReadCloser
+}
+
+// DataExportProfile is the account as written to the export, without
+// credentials.
+type DataExportProfile struct {
+	ID               uuid.UUID  `json:"id"`
+	FirstName        string     `json:"firstName"`
+	LastName         string     `json:"lastName"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	Avatar           string     `json:"avatar"`
+	Bio              string     `json:"bio"`
+	Website          string     `json:"website"`
+	Location         string     `json:"location"`
+	Pronouns         string     `json:"pronouns"`
+	Birthday         *string    `json:"birthday"`
+	Role             Role       `json:"role"`
+	Status           UserStatus `json:"status"`
+	EmailConfirmedAt *time.Time `json:"emailConfirmedAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+type DataExportPost struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Likes     uint64    `json:"likes"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type DataExportLike struct {
+	PostID    uuid.UUID `json:"postId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type DataExportFollow struct {
+	UserID    uuid.UUID `json:"userId"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type DataExportHandler interface {
+	RequestDataExport(ctx echo.Context) error
+	DownloadDataExport(ctx echo.Context) error
+}
+
+type DataExportService interface {
+	RequestDataExport(ctx context.Context) (*DataExportResponse, error)
+	ProcessDataExport(ctx context.Context, exportID uuid.UUID) error
+	DownloadDataExport(ctx context.Context, token string) (*DataExportFile, error)
+	PurgeExpiredExports(ctx context.Context) (int, error)
+}
+
+type DataExportRepository interface {
+	CreateExport(ctx context.Context, export DataExport) (*DataExport, error)
+	GetExportByID(ctx context.Context, ID uuid.UUID) (*DataExport, error)
+	GetPendingExport(ctx context.Context, userID uuid.UUID) (*DataExport, error)
+	GetExportByTokenHash(ctx context.Context, tokenHash string) (*DataExport, error)
+	GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*DataExport, error)
+	GetExportsByUserID(ctx context.Context, userID uuid.UUID) ([]*DataExport, error)
+	UpdateExport(ctx context.Context, export DataExport) error
+}
+
+func (e *DataExport) IsDownloadable() bool {
+	return e.Status == DataExportReady && e.ExpiresAt != nil && time.Now().UTC().Before(*e.ExpiresAt)
+}
+
+func (e *DataExport) ToDataExportResponse() *DataExportResponse {
+	return &DataExportResponse{
+		ID:          e.ID,
+		Status:      e.Status,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
+
+func (u *User) ToDataExportProfile() DataExportProfile {
+	return DataExportProfile{
+		ID:               u.ID,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		Username:         u.Username,
+		Email:            u.Email,
+		Avatar:           u.Avatar,
+		Bio:              u.Bio,
+		Website:          u.Website,
+		Location:         u.Location,
+		Pronouns:         u.Pronouns,
+		Birthday:         u.FormattedBirthday(),
+		Role:             u.Role,
+		Status:           u.Status,
+		EmailConfirmedAt: u.EmailConfirmedAt,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+	}
+}
+
+func (DataExport) TableName() string {
+	return "DataExport"
+}
+
+func (e *DataExport) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	e.CreatedAt = time.Now().UTC()
+	return
+}
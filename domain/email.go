@@ -5,8 +5,13 @@ import "context"
 type EmailTemplate string
 
 const (
-	OTP                EmailTemplate = "otp"
-	SignInNotification EmailTemplate = "sign-in-notification"
+	OTP                 EmailTemplate = "otp"
+	SignInNotification  EmailTemplate = "sign-in-notification"
+	EmailConfirmation   EmailTemplate = "email-confirmation"
+	PasswordReset       EmailTemplate = "password-reset"
+	AccountLocked       EmailTemplate = "account-locked"
+	PasswordChanged     EmailTemplate = "password-changed"
+	DataExportAvailable EmailTemplate = "data-export-ready"
 )
 
 type EmailPayload struct {
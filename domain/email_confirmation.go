@@ -0,0 +1,63 @@
+package domain
+
+//go:generate mockery --name=EmailConfirmationService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=EmailConfirmationRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmailConfirmationPurpose      = "email_confirmation"
+	MaxEmailConfirmationResends   = 3
+	EmailConfirmationResendWindow = 60 // in minutes
+)
+
+var (
+	ErrEmailConfirmationTokenInvalid = errors.New("email confirmation token is invalid or expired")
+	ErrEmailAlreadyConfirmed         = errors.New("email already confirmed")
+	ErrEmailConfirmationResendLimit  = errors.New("email confirmation resend limit reached")
+)
+
+type ConfirmEmailPayload struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendEmailConfirmationPayload struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type EmailConfirmationService interface {
+	SendConfirmation(ctx context.Context, user User) error
+	ResendConfirmation(ctx context.Context, user User) error
+	Confirm(ctx context.Context, token string) (uuid.UUID, error)
+}
+
+type EmailConfirmationRepository interface {
+	SetTokenID(ctx context.Context, userID uuid.UUID, tokenID string) error
+	GetTokenID(ctx context.Context, userID uuid.UUID) (string, error)
+	DeleteTokenID(ctx context.Context, userID uuid.UUID) error
+	IncrementResendAttempts(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+func (c *ConfirmEmailPayload) trim() {
+	c.Token = strings.TrimSpace(c.Token)
+}
+
+func (r *ResendEmailConfirmationPayload) trim() {
+	r.Email = strings.TrimSpace(strings.ToLower(r.Email))
+}
+
+func (c *ConfirmEmailPayload) Validate() ValidationErrors {
+	c.trim()
+	return ValidateStruct(c)
+}
+
+func (r *ResendEmailConfirmationPayload) Validate() ValidationErrors {
+	r.trim()
+	return ValidateStruct(r)
+}
@@ -0,0 +1,103 @@
+package domain
+
+//go:generate mockery --name=KnownDeviceRepository --output=../mocks --outpkg=mocks
+//go:generate mockery --name=SignInReportRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSignInReportTokenInvalid = errors.New("sign-in report token is invalid or expired")
+)
+
+// NewDeviceCheckTimeout bounds the device check and notification that run in
+// the background after a sign-in.
+const NewDeviceCheckTimeout = 10 * time.Second
+
+// KnownDevice is a client a user has already signed in from. Its fingerprint
+// is coarse on purpose, so browser or OS updates and moving around the same
+// country do not count as a new device.
+type KnownDevice struct {
+	ID          uuid.UUID `gorm:"column:id;type:char(36);primaryKey"`
+	UserID      uuid.UUID `gorm:"column:userId;type:char(36);not null;uniqueIndex:idx_user_fingerprint"`
+	User        User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Fingerprint string    `gorm:"column:fingerprint;type:char(64);not null;uniqueIndex:idx_user_fingerprint"`
+	Browser     string    `gorm:"column:browser;type:varchar(100);default:null"`
+	OS          string    `gorm:"column:os;type:varchar(100);default:null"`
+	Country     string    `gorm:"column:country;type:varchar(100);default:null"`
+	CreatedAt   time.Time `gorm:"column:createdAt;not null"`
+	LastSeenAt  time.Time `gorm:"column:lastSeenAt;not null"`
+}
+
+// SignInReport is what a "this wasn't me" link points to: the session opened
+// by the sign-in and the device it was opened from.
+type SignInReport struct {
+	UserID      uuid.UUID `json:"userId"`
+	SessionID   uuid.UUID `json:"sessionId"`
+	Fingerprint string    `json:"fingerprint"`
+}
+
+type ReportSignInPayload struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type KnownDeviceRepository interface {
+	CreateDevice(ctx context.Context, device KnownDevice) error
+	GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*KnownDevice, error)
+	HasDevices(ctx context.Context, userID uuid.UUID) (bool, error)
+	UpdateLastSeen(ctx context.Context, ID uuid.UUID) error
+	DeleteDevice(ctx context.Context, userID uuid.UUID, fingerprint string) error
+}
+
+type SignInReportRepository interface {
+	SetToken(ctx context.Context, tokenHash string, report SignInReport) error
+	ConsumeToken(ctx context.Context, tokenHash string) (*SignInReport, error)
+}
+
+// DeviceFingerprint identifies a client by user-agent family, OS family and
+// country.
+func DeviceFingerprint(clientInfo ClientInfoResponse) string {
+	return secure.HashToken(strings.Join([]string{
+		strings.ToLower(strings.TrimSpace(clientInfo.Browser)),
+		strings.ToLower(strings.TrimSpace(clientInfo.OS)),
+		strings.ToLower(strings.TrimSpace(clientInfo.Country)),
+	}, "|"))
+}
+
+func NewKnownDevice(userID uuid.UUID, clientInfo ClientInfoResponse) *KnownDevice {
+	return &KnownDevice{
+		UserID:      userID,
+		Fingerprint: DeviceFingerprint(clientInfo),
+		Browser:     clientInfo.Browser,
+		OS:          clientInfo.OS,
+		Country:     clientInfo.Country,
+	}
+}
+
+func (r *ReportSignInPayload) trim() {
+	r.Token = strings.TrimSpace(r.Token)
+}
+
+func (r *ReportSignInPayload) Validate() ValidationErrors {
+	r.trim()
+	return ValidateStruct(r)
+}
+
+func (KnownDevice) TableName() string {
+	return "KnownDevice"
+}
+
+func (d *KnownDevice) BeforeCreate(tx *gorm.DB) (err error) {
+	d.ID = uuid.New()
+	d.CreatedAt = time.Now().UTC()
+	d.LastSeenAt = d.CreatedAt
+	return
+}
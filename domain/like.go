@@ -38,6 +38,7 @@ type LikeRepository interface {
 	UserLikedPost(ctx context.Context, ID uuid.UUID, userID uuid.UUID) (bool, error)
 	UserLikedPosts(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) ([]uuid.UUID, error)
 	GetLikedPostIDs(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]bool, error)
+	GetLikesByUserID(ctx context.Context, userID uuid.UUID) ([]*Like, error)
 }
 
 func (l *LikePayload) ToLike() *Like {
@@ -18,6 +18,12 @@ type MemoryCacheRepository interface {
 	RemovePostLike(ctx context.Context, postID uuid.UUID, userID uuid.UUID) error
 	SetPost(ctx context.Context, userID uuid.UUID, posts *Pagination[*PostResponse], page, limit int) error
 	GetPosts(ctx context.Context, userID uuid.UUID, page, limit int) (*Pagination[*PostResponse], error)
+	InvalidateFeeds(ctx context.Context) error
 	GetCachedLikes(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (*LikeCache, error)
 	SetLikesByPostIDs(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) error
+	InvalidateUserFeed(ctx context.Context, userID uuid.UUID) error
+	GetProfileCounts(ctx context.Context, userID uuid.UUID) (*ProfileCounts, error)
+	SetProfileCounts(ctx context.Context, userID uuid.UUID, counts ProfileCounts) error
+	IncrementProfileCount(ctx context.Context, userID uuid.UUID, count ProfileCount, delta int64) error
+	InvalidateProfileCounts(ctx context.Context) error
 }
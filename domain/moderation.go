@@ -0,0 +1,73 @@
+package domain
+
+//go:generate mockery --name=ModerationRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ModerationActionDeletePost  = "post.delete"
+	ModerationActionUpdateRole  = "user.role.update"
+	ModerationActionBlockUser   = "user.block"
+	ModerationActionUnblockUser = "user.unblock"
+)
+
+// ModerationAction records every time a moderator or an admin acts on content
+// or accounts they do not own.
+type ModerationAction struct {
+	ID            uuid.UUID `gorm:"column:id;type:char(36);primaryKey"`
+	ActorID       uuid.UUID `gorm:"column:actorId;type:char(36);not null;index"`
+	ActorRole     Role      `gorm:"column:actorRole;type:varchar(20);not null"`
+	Action        string    `gorm:"column:action;type:varchar(50);not null;index"`
+	TargetID      uuid.UUID `gorm:"column:targetId;type:char(36);not null;index"`
+	TargetOwnerID uuid.UUID `gorm:"column:targetOwnerId;type:char(36);not null"`
+	Details       string    `gorm:"column:details;type:varchar(255);default:null"`
+	CreatedAt     time.Time `gorm:"column:createdAt;not null"`
+}
+
+type ModerationReasonPayload struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"`
+}
+
+// ModerationRepository applies a moderation change together with its audit
+// record, so that neither is ever persisted without the other.
+type ModerationRepository interface {
+	UpdateUser(ctx context.Context, action ModerationAction, user User) error
+	DeletePost(ctx context.Context, action ModerationAction, postID uuid.UUID) error
+}
+
+func NewModerationAction(actor *Session, action string, targetID uuid.UUID, targetOwnerID uuid.UUID, details string) *ModerationAction {
+	return &ModerationAction{
+		ActorID:       actor.UserID,
+		ActorRole:     actor.Role,
+		Action:        action,
+		TargetID:      targetID,
+		TargetOwnerID: targetOwnerID,
+		Details:       details,
+	}
+}
+
+func (m *ModerationReasonPayload) trim() {
+	m.Reason = strings.TrimSpace(m.Reason)
+}
+
+func (m *ModerationReasonPayload) Validate() ValidationErrors {
+	m.trim()
+	return ValidateStruct(m)
+}
+
+func (ModerationAction) TableName() string {
+	return "ModerationAction"
+}
+
+func (m *ModerationAction) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	m.CreatedAt = time.Now().UTC()
+	return
+}
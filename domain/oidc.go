@@ -0,0 +1,129 @@
+package domain
+
+//go:generate mockery --name=OIDCService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=OIDCStateRepository --output=../mocks --outpkg=mocks
+//go:generate mockery --name=ExternalIdentityRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.New("OIDC provider not found")
+	ErrOIDCStateInvalid     = errors.New("OIDC state is invalid or expired")
+	ErrOIDCIDTokenInvalid   = errors.New("OIDC ID token is invalid")
+	ErrOIDCEmailNotVerified = errors.New("OIDC provider did not return a verified email")
+)
+
+// ExternalIdentity links an account at an OpenID Connect provider, identified
+// by the issuer-scoped subject, to a local user.
+type ExternalIdentity struct {
+	ID        uuid.UUID `gorm:"column:id;type:char(36);primaryKey"`
+	UserID    uuid.UUID `gorm:"column:userId;type:char(36);not null;index"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Provider  string    `gorm:"column:provider;type:varchar(50);not null;uniqueIndex:idx_provider_subject"`
+	Subject   string    `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_provider_subject"`
+	Email     string    `gorm:"column:email;type:varchar(255);default:null"`
+	CreatedAt time.Time `gorm:"column:createdAt;not null"`
+}
+
+// OIDCClaims are the ID token claims used to sign a user in, after the token
+// has been verified.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+	Picture       string
+}
+
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// OIDCAuthState is kept between the authorization request and the callback,
+// keyed by the hash of the state parameter.
+type OIDCAuthState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+}
+
+type OIDCCallbackPayload struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+type OIDCService interface {
+	GetAuthorizationURL(ctx context.Context, provider string) (string, error)
+	Authenticate(ctx context.Context, provider string, payload OIDCCallbackPayload) (*OIDCClaims, error)
+}
+
+type OIDCStateRepository interface {
+	SetState(ctx context.Context, stateHash string, state OIDCAuthState) error
+	ConsumeState(ctx context.Context, stateHash string) (*OIDCAuthState, error)
+}
+
+type ExternalIdentityRepository interface {
+	CreateIdentity(ctx context.Context, identity ExternalIdentity) error
+	GetIdentity(ctx context.Context, provider string, subject string) (*ExternalIdentity, error)
+}
+
+func NewExternalIdentity(userID uuid.UUID, provider string, claims OIDCClaims) *ExternalIdentity {
+	return &ExternalIdentity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+}
+
+func (o *OIDCCallbackPayload) trim() {
+	o.Code = strings.TrimSpace(o.Code)
+	o.State = strings.TrimSpace(o.State)
+}
+
+func (o *OIDCCallbackPayload) Validate() ValidationErrors {
+	o.trim()
+	return ValidateStruct(o)
+}
+
+// Names returns the first and last name to use for a new user, falling back
+// to splitting the full name and then to the email's local part.
+func (c *OIDCClaims) Names() (string, string) {
+	if c.GivenName != "" {
+		return c.GivenName, c.FamilyName
+	}
+
+	if fields := strings.Fields(c.Name); len(fields) > 0 {
+		return fields[0], strings.Join(fields[1:], " ")
+	}
+
+	localPart, _, _ := strings.Cut(c.Email, "@")
+	return localPart, ""
+}
+
+func (ExternalIdentity) TableName() string {
+	return "ExternalIdentity"
+}
+
+func (e *ExternalIdentity) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	e.CreatedAt = time.Now().UTC()
+	return
+}
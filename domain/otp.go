@@ -0,0 +1,25 @@
+package domain
+
+//go:generate mockery --name=OTPRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const OTPDigits = 6
+
+// MaxOTPCodeFailures is how many wrong codes a sign-in request accepts before
+// it is discarded and the user has to sign in again.
+const MaxOTPCodeFailures = 5
+
+type OTPRepository interface {
+	SetHash(ctx context.Context, hash string, userID uuid.UUID) error
+	GetUserIDByHash(ctx context.Context, hash string) (uuid.UUID, error)
+	DeleteHash(ctx context.Context, hash string) error
+	SetCode(ctx context.Context, hash string, code string) error
+	GetCode(ctx context.Context, hash string) (string, error)
+	DeleteCode(ctx context.Context, hash string) error
+	IncrementCodeFailures(ctx context.Context, hash string) (int64, error)
+}
@@ -0,0 +1,48 @@
+package domain
+
+//go:generate mockery --name=PasswordResetRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+)
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type ResetPasswordPayload struct {
+	Token           string `json:"token" validate:"required"`
+	Password        string `json:"password" validate:"required,strongpassword"`
+	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=Password"`
+}
+
+type PasswordResetRepository interface {
+	SetToken(ctx context.Context, tokenHash string, userID uuid.UUID) error
+	ConsumeToken(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+func (f *ForgotPasswordPayload) trim() {
+	f.Email = strings.TrimSpace(strings.ToLower(f.Email))
+}
+
+func (r *ResetPasswordPayload) trim() {
+	r.Token = strings.TrimSpace(r.Token)
+}
+
+func (f *ForgotPasswordPayload) Validate() ValidationErrors {
+	f.trim()
+	return ValidateStruct(f)
+}
+
+func (r *ResetPasswordPayload) Validate() ValidationErrors {
+	r.trim()
+	return ValidateStruct(r)
+}
@@ -0,0 +1,177 @@
+package domain
+
+//go:generate mockery --name=PersonalAccessTokenHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=PersonalAccessTokenService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=PersonalAccessTokenRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrInsufficientScope           = errors.New("insufficient scope")
+)
+
+const (
+	ScopePostsRead      = "posts:read"
+	ScopePostsWrite     = "posts:write"
+	ScopeFeedRead       = "feed:read"
+	ScopeFollowersRead  = "followers:read"
+	ScopeFollowersWrite = "followers:write"
+
+	// PersonalAccessTokenPrefix tells personal access tokens apart from session
+	// JWTs in the Authorization header.
+	PersonalAccessTokenPrefix = "snp_"
+	PersonalAccessTokenSize   = 32
+)
+
+var Scopes = []string{
+	ScopePostsRead,
+	ScopePostsWrite,
+	ScopeFeedRead,
+	ScopeFollowersRead,
+	ScopeFollowersWrite,
+}
+
+type PersonalAccessToken struct {
+	ID         uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	UserID     uuid.UUID  `gorm:"column:userId;type:char(36);not null;index"`
+	User       User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Name       string     `gorm:"column:name;type:varchar(50);not null"`
+	TokenHash  string     `gorm:"column:tokenHash;type:char(64);uniqueIndex;not null"`
+	Scopes     string     `gorm:"column:scopes;type:varchar(255);not null"`
+	ExpiresAt  *time.Time `gorm:"column:expiresAt;default:null"`
+	LastUsedAt *time.Time `gorm:"column:lastUsedAt;default:null"`
+	CreatedAt  time.Time  `gorm:"column:createdAt;not null"`
+}
+
+type PersonalAccessTokenPayload struct {
+	Name          string   `json:"name" validate:"required,max=50"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,scope"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"omitempty,min=1,max=365"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type PersonalAccessTokenCreatedResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
+
+type PersonalAccessTokenHandler interface {
+	CreateToken(ctx echo.Context) error
+	GetTokens(ctx echo.Context) error
+	RevokeToken(ctx echo.Context) error
+}
+
+type PersonalAccessTokenService interface {
+	CreateToken(ctx context.Context, payload PersonalAccessTokenPayload) (*PersonalAccessTokenCreatedResponse, error)
+	GetTokens(ctx context.Context) ([]*PersonalAccessTokenResponse, error)
+	RevokeToken(ctx context.Context, tokenID uuid.UUID) error
+	GetSessionByToken(ctx context.Context, token string) (*Session, error)
+}
+
+type PersonalAccessTokenRepository interface {
+	CreateToken(ctx context.Context, token PersonalAccessToken) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	GetTokensByUserID(ctx context.Context, userID uuid.UUID) ([]*PersonalAccessToken, error)
+	UpdateLastUsedAt(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error
+	DeleteToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) (bool, error)
+}
+
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+func IsValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+func (p *PersonalAccessTokenPayload) trim() {
+	p.Name = strings.TrimSpace(p.Name)
+	for i, scope := range p.Scopes {
+		p.Scopes[i] = strings.TrimSpace(scope)
+	}
+}
+
+func (p *PersonalAccessTokenPayload) Validate() ValidationErrors {
+	p.trim()
+	return ValidateStruct(p)
+}
+
+func (p *PersonalAccessTokenPayload) ToPersonalAccessToken(userID uuid.UUID, tokenHash string) *PersonalAccessToken {
+	token := &PersonalAccessToken{
+		UserID:    userID,
+		Name:      p.Name,
+		TokenHash: tokenHash,
+		Scopes:    strings.Join(slices.Compact(slices.Sorted(slices.Values(p.Scopes))), " "),
+	}
+
+	if p.ExpiresInDays > 0 {
+		expiresAt := time.Now().UTC().AddDate(0, 0, p.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	return token
+}
+
+func (p *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(p.Scopes)
+}
+
+func (p *PersonalAccessToken) IsExpired() bool {
+	return p.ExpiresAt != nil && time.Now().After(*p.ExpiresAt)
+}
+
+func (p *PersonalAccessToken) ToSession() *Session {
+	return &Session{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		FirstName: p.User.FirstName,
+		LastName:  p.User.LastName,
+		Username:  p.User.Username,
+		Email:     p.User.Email,
+		Avatar:    p.User.Avatar,
+		// personal access tokens never carry moderation or admin powers
+		Role:      RoleUser,
+		Scopes:    p.ScopeList(),
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func (p *PersonalAccessToken) ToPersonalAccessTokenResponse() *PersonalAccessTokenResponse {
+	return &PersonalAccessTokenResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		Scopes:     p.ScopeList(),
+		ExpiresAt:  p.ExpiresAt,
+		LastUsedAt: p.LastUsedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "PersonalAccessToken"
+}
+
+func (p *PersonalAccessToken) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	p.CreatedAt = time.Now().UTC()
+	return
+}
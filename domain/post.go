@@ -78,6 +78,7 @@ type PostRepository interface {
 	CreatePost(ctx context.Context, post Post) error
 	GetPaginatedPosts(ctx context.Context, userID uuid.UUID, page int, limit int) (*Pagination[*Post], error)
 	GetPostById(ctx context.Context, ID uuid.UUID, preload bool) (*Post, error)
+	GetPostByIdIncludingHidden(ctx context.Context, ID uuid.UUID) (*Post, error)
 	UpdatePost(ctx context.Context, ID uuid.UUID, post Post) error
 	DeletePost(ctx context.Context, ID uuid.UUID) error
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Post, error)
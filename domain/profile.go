@@ -0,0 +1,158 @@
+package domain
+
+//go:generate mockery --name=ProfileHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=ProfileService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=ProfileRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+type ProfileCount string
+
+const (
+	ProfileCountFollowers  ProfileCount = "followers"
+	ProfileCountFollowings ProfileCount = "followings"
+	ProfileCountPosts      ProfileCount = "posts"
+)
+
+// Visibility controls who can see an optional profile field.
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "public"
+	VisibilityFollowers Visibility = "followers"
+	VisibilityPrivate   Visibility = "private"
+)
+
+const (
+	BirthdayLayout  = "2006-01-02"
+	MinBirthdayYear = 1900
+)
+
+const MaxUserSearchQueryLength = 100
+
+var AllowedWebsiteSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+}
+
+type ProfileCounts struct {
+	Followers  int64 `json:"followers"`
+	Followings int64 `json:"followings"`
+	Posts      int64 `json:"posts"`
+}
+
+// ProfileRelationship describes how the viewer relates to the profile owner.
+type ProfileRelationship struct {
+	FollowsYou bool `json:"followsYou"`
+	YouFollow  bool `json:"youFollow"`
+	Blocked    bool `json:"blocked"`
+	Muted      bool `json:"muted"`
+}
+
+type ProfileResponse struct {
+	ID           uuid.UUID           `json:"id"`
+	FirstName    string              `json:"firstName"`
+	LastName     string              `json:"lastName"`
+	Username     string              `json:"username"`
+	Avatar       string              `json:"avatar"`
+	Bio          string              `json:"bio"`
+	Website      string              `json:"website"`
+	Location     string              `json:"location"`
+	Pronouns     string              `json:"pronouns"`
+	Birthday     *string             `json:"birthday,omitempty"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	Counts       ProfileCounts       `json:"counts"`
+	Relationship ProfileRelationship `json:"relationship"`
+}
+
+// UserSearchResult is a user matched by a search along with the columns the
+// results are ranked by.
+type UserSearchResult struct {
+	User
+	ExactMatch bool  `gorm:"column:exactMatch"`
+	YouFollow  bool  `gorm:"column:youFollow"`
+	Followers  int64 `gorm:"column:followers"`
+}
+
+type UserSearchResponse struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Username  string    `json:"username"`
+	Avatar    string    `json:"avatar"`
+	YouFollow bool      `json:"youFollow"`
+}
+
+type ProfileHandler interface {
+	GetProfile(ctx echo.Context) error
+	SearchUsers(ctx echo.Context) error
+}
+
+type ProfileService interface {
+	GetProfile(ctx context.Context, username string) (*ProfileResponse, error)
+	SearchUsers(ctx context.Context, query string, page, limit int) (*Pagination[*UserSearchResponse], error)
+}
+
+type ProfileRepository interface {
+	GetProfileCounts(ctx context.Context, userID uuid.UUID) (*ProfileCounts, error)
+	SearchUsers(ctx context.Context, viewerID uuid.UUID, query string, page, limit int) (*Pagination[*UserSearchResult], error)
+}
+
+func (u *User) ToProfileResponse() *ProfileResponse {
+	return &ProfileResponse{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Username:  u.Username,
+		Avatar:    u.Avatar,
+		Bio:       u.Bio,
+		Website:   u.Website,
+		Location:  u.Location,
+		Pronouns:  u.Pronouns,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+// BirthdayVisibleTo reports whether a viewer other than the owner may see the
+// birthday, given whether they follow the owner.
+func (u *User) BirthdayVisibleTo(follows bool) bool {
+	switch u.BirthdayVisibility {
+	case VisibilityPublic:
+		return true
+	case VisibilityFollowers:
+		return follows
+	default:
+		return false
+	}
+}
+
+// FormattedBirthday returns the birthday as YYYY-MM-DD, nil when it is not
+// set.
+func (u *User) FormattedBirthday() *string {
+	if u.Birthday == nil {
+		return nil
+	}
+
+	birthday := u.Birthday.Format(BirthdayLayout)
+	return &birthday
+}
+
+func (r *UserSearchResult) ToUserSearchResponse() *UserSearchResponse {
+	return &UserSearchResponse{
+		ID:        r.ID,
+		FirstName: r.FirstName,
+		LastName:  r.LastName,
+		Username:  r.Username,
+		Avatar:    r.Avatar,
+		YouFollow: r.YouFollow,
+	}
+}
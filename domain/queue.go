@@ -6,6 +6,7 @@ const (
 	QueueSendEmail  = "send_email_queue"
 	QueueLikePost   = "like_post_queue"
 	QueueUnlikePost = "unlike_post_queue"
+	QueueDataExport = "data_export_queue"
 )
 
 type QueueService interface {
@@ -0,0 +1,27 @@
+package domain
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// roleRanks orders roles so that a higher role is granted everything a lower
+// one is.
+var roleRanks = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Includes reports whether r grants at least the permissions of required.
+func (r Role) Includes(required Role) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[required]
+}
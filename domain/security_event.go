@@ -0,0 +1,111 @@
+package domain
+
+//go:generate mockery --name=SecurityEventHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=SecurityEventService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=SecurityEventRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventSignInSuccess             SecurityEventType = "sign_in.success"
+	SecurityEventSignInFailure             SecurityEventType = "sign_in.failure"
+	SecurityEventSignInReported            SecurityEventType = "sign_in.reported"
+	SecurityEventSignOut                   SecurityEventType = "sign_out"
+	SecurityEventPasswordChange            SecurityEventType = "password.change"
+	SecurityEventPasswordReset             SecurityEventType = "password.reset"
+	SecurityEventSessionRevoke             SecurityEventType = "session.revoke"
+	SecurityEventOtherSessionsRevoke       SecurityEventType = "session.revoke_others"
+	SecurityEventTwoFactorEnable           SecurityEventType = "two_factor.enable"
+	SecurityEventTwoFactorDisable          SecurityEventType = "two_factor.disable"
+	SecurityEventTwoFactorRecoveryCodeUsed SecurityEventType = "two_factor.recovery_code_used"
+	SecurityEventAccountDelete             SecurityEventType = "account.delete"
+	SecurityEventAccountRestore            SecurityEventType = "account.restore"
+)
+
+const (
+	DefaultSecurityEventRetentionDays = 90
+	SecurityEventPurgeBatchSize       = 500
+	SecurityEventPurgeInterval        = 24 * time.Hour
+)
+
+// SecurityEvent is an entry in a user's security audit log, stamped with the
+// client that triggered it.
+type SecurityEvent struct {
+	ID        uuid.UUID         `gorm:"column:id;type:char(36);primaryKey"`
+	UserID    uuid.UUID         `gorm:"column:userId;type:char(36);not null;index:idx_user_created"`
+	User      User              `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Type      SecurityEventType `gorm:"column:type;type:varchar(50);not null"`
+	Device    string            `gorm:"column:device;type:varchar(255);not null"`
+	Location  string            `gorm:"column:location;type:varchar(255);not null"`
+	IP        string            `gorm:"column:ip;type:varchar(45);not null"`
+	Details   string            `gorm:"column:details;type:varchar(255);default:null"`
+	CreatedAt time.Time         `gorm:"column:createdAt;not null;index:idx_user_created;index"`
+}
+
+type SecurityEventResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      SecurityEventType `json:"type"`
+	Device    string            `json:"device"`
+	Location  string            `json:"location"`
+	IP        string            `json:"ip"`
+	Details   string            `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type SecurityEventHandler interface {
+	GetSecurityEvents(ctx echo.Context) error
+}
+
+type SecurityEventService interface {
+	Record(ctx context.Context, userID uuid.UUID, eventType SecurityEventType, details string)
+	GetSecurityEvents(ctx context.Context, page, limit int) (*Pagination[*SecurityEventResponse], error)
+	PurgeExpiredEvents(ctx context.Context) (int64, error)
+}
+
+type SecurityEventRepository interface {
+	CreateEvent(ctx context.Context, event SecurityEvent) error
+	GetEvents(ctx context.Context, userID uuid.UUID, page, limit int) (*Pagination[*SecurityEvent], error)
+	DeleteEventsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+func NewSecurityEvent(userID uuid.UUID, eventType SecurityEventType, details string) *SecurityEvent {
+	return &SecurityEvent{
+		UserID:   userID,
+		Type:     eventType,
+		Device:   "Unknown",
+		Location: "Unknown",
+		IP:       "Unknown",
+		Details:  details,
+	}
+}
+
+func (e *SecurityEvent) ToSecurityEventResponse() *SecurityEventResponse {
+	return &SecurityEventResponse{
+		ID:        e.ID,
+		Type:      e.Type,
+		Device:    e.Device,
+		Location:  e.Location,
+		IP:        e.IP,
+		Details:   e.Details,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func (SecurityEvent) TableName() string {
+	return "SecurityEvent"
+}
+
+func (e *SecurityEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	e.CreatedAt = time.Now().UTC()
+	return
+}
@@ -1,13 +1,19 @@
 package domain
 
+//go:generate mockery --name=SessionHandler --output=../mocks --outpkg=mocks
 //go:generate mockery --name=SessionService --output=../mocks --outpkg=mocks
 //go:generate mockery --name=SessionRepository --output=../mocks --outpkg=mocks
 
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/secure"
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 )
 
 var (
@@ -16,26 +22,158 @@ var (
 	ErrorUnexpectedMethod     = errors.New("unexpected signing method")
 	ErrTokenNotFoundInContext = errors.New("token not found in context")
 	ErrSessionMismatch        = errors.New("session icompatible for user requested")
+	ErrRefreshTokenInvalid    = errors.New("invalid refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token reused")
+)
+
+const (
+	SessionLastSeenInterval = time.Minute
+	RefreshTokenSize        = 32
+
+	// TokenDeliveryHeader lets non-browser clients ask for issued tokens in the
+	// response body instead of cookies by sending TokenDeliveryBody.
+	TokenDeliveryHeader = "X-Token-Delivery"
+	TokenDeliveryBody   = "body"
+	TokenTypeBearer     = "Bearer"
+
+	// CSRFCookie and CSRFHeader carry the double-submit token browsers must
+	// echo back on unsafe requests authenticated by cookie.
+	CSRFCookie    = "x.CSRF"
+	CSRFHeader    = "X-CSRF-Token"
+	CSRFTokenSize = 32
 )
 
 type Session struct {
-	UserID    uuid.UUID `json:"id"`
-	Token     string    `json:"token"`
-	FirstName string    `json:"firstName"`
-	LastName  string    `json:"lastName"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Avatar    string    `json:"avatar"`
+	ID               uuid.UUID `json:"sid"`
+	UserID           uuid.UUID `json:"id"`
+	Token            string    `json:"token"`
+	RefreshTokenHash string    `json:"refreshTokenHash"`
+	FirstName        string    `json:"firstName"`
+	LastName         string    `json:"lastName"`
+	Username         string    `json:"username"`
+	Email            string    `json:"email"`
+	Avatar           string    `json:"avatar"`
+	Role             Role      `json:"role"`
+	Device           string    `json:"device"`
+	IP               string    `json:"ip"`
+	Location         string    `json:"location"`
+	Scopes           []string  `json:"scopes,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+	LastSeenAt       time.Time `json:"lastSeenAt"`
+}
+
+type AuthTokens struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	SessionID    uuid.UUID `json:"-"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+// RefreshToken points a hashed refresh token back to the session that issued
+// it. Rotated tokens keep their record until the session expires so that a
+// replayed token can still be traced to its session and revoke it.
+type RefreshToken struct {
+	UserID    uuid.UUID `json:"userId"`
+	SessionID uuid.UUID `json:"sessionId"`
+}
+
+type CSRFTokenResponse struct {
+	CSRFToken string `json:"csrfToken"`
+}
+
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	Location   string    `json:"location"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	Current    bool      `json:"current"`
+}
+
+type SessionHandler interface {
+	GetSessions(ctx echo.Context) error
+	RevokeSession(ctx echo.Context) error
+	RevokeOtherSessions(ctx echo.Context) error
+	GetJWKS(ctx echo.Context) error
+	RefreshToken(ctx echo.Context) error
+	GetCSRFToken(ctx echo.Context) error
 }
 
 type SessionService interface {
-	CreateSession(ctx context.Context, user User) (string, error)
+	CreateSession(ctx context.Context, user User) (*AuthTokens, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*AuthTokens, error)
 	GetSessionByToken(ctx context.Context, token string) (*Session, error)
-	DeleteSession(ctx context.Context, userID uuid.UUID) error
+	GetSessions(ctx context.Context) ([]*SessionResponse, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context) error
+	GetJWKS() (*secure.JSONWebKeySet, error)
+	DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	DeleteAllSessions(ctx context.Context, userID uuid.UUID) error
 }
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session Session) error
-	GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*Session, error)
-	DeleteSession(ctx context.Context, userId uuid.UUID) error
+	UpdateSession(ctx context.Context, session Session) error
+	RenewSession(ctx context.Context, session Session) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	GetSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*Session, error)
+	GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// IsScoped reports whether the session was opened with a personal access
+// token, which may only reach routes that declare a scope it was granted.
+func (s *Session) IsScoped() bool {
+	return s.Scopes != nil
+}
+
+func (s *Session) HasScopes(scopes ...string) bool {
+	if !s.IsScoped() {
+		return true
+	}
+
+	if len(scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(s.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *AuthTokens) ToTokenResponse() *TokenResponse {
+	return &TokenResponse{
+		AccessToken:  a.AccessToken,
+		RefreshToken: a.RefreshToken,
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    config.Env.Cache.AccessTokenExp * 60,
+	}
+}
+
+func (s *Session) ToSessionResponse(currentSessionID uuid.UUID) *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID,
+		Device:     s.Device,
+		IP:         s.IP,
+		Location:   s.Location,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		Current:    s.ID == currentSessionID,
+	}
 }
@@ -0,0 +1,54 @@
+package domain
+
+//go:generate mockery --name=SignInAttemptRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxSignInFailuresPerAccount = 5
+	MaxSignInFailuresPerIP      = 20
+	SignInFailureWindow         = 15 // in minutes
+	SignInLockoutDuration       = 15 // in minutes
+
+	// Failures beyond SignInDelayThreshold slow every further attempt down,
+	// doubling from SignInBaseDelay up to SignInMaxDelay.
+	SignInDelayThreshold = 2
+	SignInBaseDelay      = 500 * time.Millisecond
+	SignInMaxDelay       = 8 * time.Second
+)
+
+var (
+	ErrAccountLocked         = errors.New("account temporarily locked")
+	ErrTooManySignInAttempts = errors.New("too many sign-in attempts from this IP")
+)
+
+type SignInAttemptRepository interface {
+	GetAccountFailures(ctx context.Context, userID uuid.UUID) (int64, error)
+	IncrementAccountFailures(ctx context.Context, userID uuid.UUID) (int64, error)
+	ResetAccountFailures(ctx context.Context, userID uuid.UUID) error
+	GetIPFailures(ctx context.Context, ip string) (int64, error)
+	IncrementIPFailures(ctx context.Context, ip string) (int64, error)
+	LockAccount(ctx context.Context, userID uuid.UUID, duration time.Duration) error
+	IsAccountLocked(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// SignInDelay returns how long to hold an attempt back after the given number
+// of consecutive failures.
+func SignInDelay(failures int64) time.Duration {
+	if failures <= SignInDelayThreshold {
+		return 0
+	}
+
+	delay := SignInBaseDelay << (failures - SignInDelayThreshold - 1)
+	if delay <= 0 || delay > SignInMaxDelay {
+		return SignInMaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,176 @@
+package domain
+
+//go:generate mockery --name=TwoFactorHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=TwoFactorService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=TwoFactorRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultTwoFactorIssuer = "Social Network"
+	RecoveryCodeCount      = 10
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled         = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorEnrollmentNotFound = errors.New("two-factor enrollment not found")
+	ErrTwoFactorCodeWrong          = errors.New("the two-factor code is wrong")
+	ErrTwoFactorKeyMissing         = errors.New("two-factor encryption key is not configured")
+)
+
+// TwoFactorRequiredError is returned when the first factor of a sign-in was
+// accepted and a TOTP or recovery code is still needed. Hash identifies the
+// pending sign-in.
+type TwoFactorRequiredError struct {
+	Hash string
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return "two-factor authentication is required"
+}
+
+type SignInMethod string
+
+const (
+	SignInMethodEmail SignInMethod = "email"
+	SignInMethodTOTP  SignInMethod = "totp"
+)
+
+// TwoFactor holds a user's TOTP secret, encrypted. It exists without EnabledAt
+// while the enrolment waits for its first code.
+type TwoFactor struct {
+	ID           uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	UserID       uuid.UUID  `gorm:"column:userId;type:char(36);not null;uniqueIndex"`
+	User         User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Secret       string     `gorm:"column:secret;type:varchar(255);not null"`
+	LastUsedStep int64      `gorm:"column:lastUsedStep;not null;default:0"`
+	EnabledAt    *time.Time `gorm:"column:enabledAt;default:null"`
+	CreatedAt    time.Time  `gorm:"column:createdAt;not null"`
+}
+
+// RecoveryCode is a hashed single-use code that stands in for a TOTP code when
+// the authenticator app is lost.
+type RecoveryCode struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	UserID    uuid.UUID  `gorm:"column:userId;type:char(36);not null;index"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CodeHash  string     `gorm:"column:codeHash;type:char(64);not null"`
+	UsedAt    *time.Time `gorm:"column:usedAt;default:null"`
+	CreatedAt time.Time  `gorm:"column:createdAt;not null"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type ConfirmTwoFactorPayload struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// DisableTwoFactorPayload re-authenticates the user with both factors before
+// two-factor authentication is turned off.
+type DisableTwoFactorPayload struct {
+	Password     string `json:"password" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recoveryCode" validate:"omitempty,max=20"`
+}
+
+type VerifyTwoFactorSignInPayload struct {
+	Hash         string `json:"hash" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recoveryCode" validate:"omitempty,max=20"`
+}
+
+type TwoFactorHandler interface {
+	BeginEnrollment(ctx echo.Context) error
+	ConfirmEnrollment(ctx echo.Context) error
+	DisableTwoFactor(ctx echo.Context) error
+}
+
+type TwoFactorService interface {
+	BeginEnrollment(ctx context.Context) (*TwoFactorEnrollmentResponse, error)
+	ConfirmEnrollment(ctx context.Context, payload ConfirmTwoFactorPayload) (*RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, payload DisableTwoFactorPayload) error
+	VerifyCode(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+}
+
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, twoFactor TwoFactor) error
+	DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+}
+
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+func (c *ConfirmTwoFactorPayload) trim() {
+	c.Code = strings.TrimSpace(c.Code)
+}
+
+func (c *ConfirmTwoFactorPayload) Validate() ValidationErrors {
+	c.trim()
+	return ValidateStruct(c)
+}
+
+func (d *DisableTwoFactorPayload) trim() {
+	d.Code = strings.TrimSpace(d.Code)
+	d.RecoveryCode = strings.TrimSpace(d.RecoveryCode)
+}
+
+func (d *DisableTwoFactorPayload) Validate() ValidationErrors {
+	d.trim()
+	return ValidateStruct(d)
+}
+
+func (v *VerifyTwoFactorSignInPayload) trim() {
+	v.Hash = strings.TrimSpace(v.Hash)
+	v.Code = strings.TrimSpace(v.Code)
+	v.RecoveryCode = strings.TrimSpace(v.RecoveryCode)
+}
+
+func (v *VerifyTwoFactorSignInPayload) Validate() ValidationErrors {
+	v.trim()
+	return ValidateStruct(v)
+}
+
+func (TwoFactor) TableName() string {
+	return "TwoFactor"
+}
+
+func (RecoveryCode) TableName() string {
+	return "RecoveryCode"
+}
+
+func (t *TwoFactor) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	t.CreatedAt = time.Now().UTC()
+	return
+}
+
+func (r *RecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	r.CreatedAt = time.Now().UTC()
+	return
+}
@@ -15,7 +15,7 @@ import (
 //go:generate mockery --name=UserHandler --output=../mocks --outpkg=mocks
 //go:generate mockery --name=UserService --output=../mocks --outpkg=mocks
 //go:generate mockery --name=UserRepository --output=../mocks --outpkg=mocks
-type statusType string
+type UserStatus string
 
 var (
 	ErrUserNotFound             = errors.New("user not found")
@@ -25,27 +25,50 @@ var (
 	ErrHashExpired              = errors.New("the 2FA hash has expired")
 	ErrCodeOTPExpired           = errors.New("the code OTP has expired")
 	ErrCodeOTPWrong             = errors.New("the code OTP is wrong")
+	ErrCodeOTPAttemptsExceeded  = errors.New("too many wrong OTP codes")
 	ErrEmailConfirmationPending = errors.New("email confirmation is pending")
 	ErrUsernameAlreadyExists    = errors.New("username already exists")
+	ErrPasswordReused           = errors.New("new password must differ from the current one")
+	ErrCannotChangeOwnRole      = errors.New("cannot change own role")
+	ErrCannotBlockSelf          = errors.New("cannot block own account")
+	ErrUserBlocked              = errors.New("user is blocked")
+	ErrUserInactive             = errors.New("user is inactive")
+	ErrUserAlreadyBlocked       = errors.New("user is already blocked")
+	ErrUserNotBlocked           = errors.New("user is not blocked")
+	ErrPasswordResetRequired    = errors.New("password reset is required")
 )
 
 const (
-	Active   statusType = "active"
-	Inactive statusType = "inactive"
-	Block    statusType = "block"
+	Active   UserStatus = "active"
+	Inactive UserStatus = "inactive"
+	Block    UserStatus = "block"
 )
 
 type User struct {
-	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
-	FirstName string     `gorm:"column:firstName;type:varchar(255);not null"`
-	LastName  string     `gorm:"column:lastName;type:varchar(255);not null"`
-	Username  string     `gorm:"column:username;type:varchar(20);uniqueIndex;not null"`
-	Email     string     `gorm:"column:email;type:varchar(255);uniqueIndex;not null"`
-	Password  string     `gorm:"column:password;type:varchar(255);not null"`
-	Avatar    string     `gorm:"column:avatar;type:varchar(255);default:null"`
-	Status    statusType `gorm:"type:enum('active','inactive','block');default:'active';index"`
-	CreatedAt time.Time  `gorm:"column:createdAt;not null"`
-	UpdatedAt time.Time  `gorm:"column:updatedAt;default:null"`
+	ID                    uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	FirstName             string     `gorm:"column:firstName;type:varchar(255);not null;index:idx_user_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	LastName              string     `gorm:"column:lastName;type:varchar(255);not null;index:idx_user_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	Username              string     `gorm:"column:username;type:varchar(20);uniqueIndex;not null;index:idx_user_search,class:FULLTEXT,option:WITH PARSER ngram,priority:1"`
+	Email                 string     `gorm:"column:email;type:varchar(255);uniqueIndex;not null"`
+	Password              string     `gorm:"column:password;type:varchar(255);not null"`
+	Avatar                string     `gorm:"column:avatar;type:varchar(255);default:null"`
+	AvatarKey             string     `gorm:"column:avatarKey;type:varchar(255);default:null"`
+	Bio                   string     `gorm:"column:bio;type:varchar(160);default:null"`
+	Website               string     `gorm:"column:website;type:varchar(255);default:null"`
+	Location              string     `gorm:"column:location;type:varchar(100);default:null"`
+	Pronouns              string     `gorm:"column:pronouns;type:varchar(40);default:null"`
+	Birthday              *time.Time `gorm:"column:birthday;type:date;default:null"`
+	BirthdayVisibility    Visibility `gorm:"column:birthdayVisibility;type:enum('public','followers','private');default:'private';not null"`
+	Status                UserStatus `gorm:"type:enum('active','inactive','block');default:'active';index"`
+	StatusReason          string     `gorm:"column:statusReason;type:varchar(255);default:null"`
+	StatusChangedAt       *time.Time `gorm:"column:statusChangedAt;default:null"`
+	ScheduledPurgeAt      *time.Time `gorm:"column:scheduledPurgeAt;default:null;index"`
+	Role                  Role       `gorm:"column:role;type:enum('user','moderator','admin');default:'user';not null"`
+	EmailConfirmedAt      *time.Time `gorm:"column:emailConfirmedAt;default:null"`
+	PasswordResetRequired bool       `gorm:"column:passwordResetRequired;not null;default:false"`
+	TwoFactorEnabled      bool       `gorm:"column:twoFactorEnabled;not null;default:false"`
+	CreatedAt             time.Time  `gorm:"column:createdAt;not null"`
+	UpdatedAt             time.Time  `gorm:"column:updatedAt;default:null"`
 }
 
 type UserPayload struct {
@@ -57,10 +80,28 @@ type UserPayload struct {
 	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=Password"`
 }
 
+// UserUpdatePayload only changes the fields that are sent. The optional
+// profile fields are pointers so that an empty string clears them.
 type UserUpdatePayload struct {
-	FirstName string `json:"firstName" validate:"omitempty,min=1,max=255"`
-	LastName  string `json:"lastName" validate:"omitempty,min=1,max=255"`
-	Username  string `json:"username" validate:"omitempty,username,min=3,max=20"`
+	FirstName          string      `json:"firstName" validate:"omitempty,min=1,max=255"`
+	LastName           string      `json:"lastName" validate:"omitempty,min=1,max=255"`
+	Username           string      `json:"username" validate:"omitempty,username,min=3,max=20"`
+	Bio                *string     `json:"bio" validate:"omitempty,max=160,nocontrol=multiline"`
+	Website            *string     `json:"website" validate:"omitempty,max=255,website"`
+	Location           *string     `json:"location" validate:"omitempty,max=100,nocontrol"`
+	Pronouns           *string     `json:"pronouns" validate:"omitempty,max=40,nocontrol"`
+	Birthday           *string     `json:"birthday" validate:"omitempty,birthday"`
+	BirthdayVisibility *Visibility `json:"birthdayVisibility" validate:"omitempty,oneof=public followers private"`
+}
+
+type ChangePasswordPayload struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required,strongpassword"`
+	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=NewPassword"`
+}
+
+type UpdateUserRolePayload struct {
+	Role Role `json:"role" validate:"required,oneof=user moderator admin"`
 }
 
 type CheckUsernamePayload struct {
@@ -68,16 +109,32 @@ type CheckUsernamePayload struct {
 }
 
 type CheckPasswordStrongPayload struct {
-	Password string `json:"password" validate:"required,strongpassword"`
+	Password string `json:"password" validate:"required"`
+}
+
+type PasswordStrengthResponse struct {
+	Score       int      `json:"score"`
+	Strong      bool     `json:"strong"`
+	Breached    bool     `json:"breached"`
+	Warning     string   `json:"warning,omitempty"`
+	Suggestions []string `json:"suggestions"`
 }
 
 type UserResponse struct {
-	ID        string `json:"id"`
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Avatar    string `json:"avatar"`
+	ID                 string     `json:"id"`
+	FirstName          string     `json:"firstName"`
+	LastName           string     `json:"lastName"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	Avatar             string     `json:"avatar"`
+	Bio                string     `json:"bio"`
+	Website            string     `json:"website"`
+	Location           string     `json:"location"`
+	Pronouns           string     `json:"pronouns"`
+	Birthday           *string    `json:"birthday"`
+	BirthdayVisibility Visibility `json:"birthdayVisibility"`
+	Role               Role       `json:"role"`
+	TwoFactorEnabled   bool       `json:"twoFactorEnabled"`
 }
 
 type UserFollowerResponse struct {
@@ -96,25 +153,61 @@ type SignInPayload struct {
 	Password        string `json:"password" validate:"required,min=8"`
 }
 
+type SignInResponse struct {
+	Hash   string       `json:"hash"`
+	Method SignInMethod `json:"method"`
+}
+
+type VerifySignInPayload struct {
+	Hash string `json:"hash" validate:"required"`
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
 type UserHandler interface {
 	CreateUser(ctx echo.Context) error
+	ConfirmEmail(ctx echo.Context) error
+	ResendEmailConfirmation(ctx echo.Context) error
 	SignIn(ctx echo.Context) error
+	VerifySignIn(ctx echo.Context) error
+	VerifyTwoFactorSignIn(ctx echo.Context) error
+	ForgotPassword(ctx echo.Context) error
+	ResetPassword(ctx echo.Context) error
+	ReportSignIn(ctx echo.Context) error
+	ChangePassword(ctx echo.Context) error
 	SignOut(ctx echo.Context) error
 	GetUser(ctx echo.Context) error
 	UpdateUser(ctx echo.Context) error
 	DeleteUser(ctx echo.Context) error
 	CheckUsername(ctx echo.Context) error
 	CheckPasswordStrong(ctx echo.Context) error
+	UpdateUserRole(ctx echo.Context) error
+	BlockUser(ctx echo.Context) error
+	UnblockUser(ctx echo.Context) error
+	GetOIDCAuthorizationURL(ctx echo.Context) error
+	SignInWithOIDC(ctx echo.Context) error
 }
 
 type UserService interface {
-	CreateUser(ctx context.Context, payload UserPayload) (string, error)
-	SignIn(ctx context.Context, payload SignInPayload) (string, error)
+	CreateUser(ctx context.Context, payload UserPayload) error
+	ConfirmEmail(ctx context.Context, payload ConfirmEmailPayload) error
+	ResendEmailConfirmation(ctx context.Context, payload ResendEmailConfirmationPayload) error
+	SignIn(ctx context.Context, payload SignInPayload) (*SignInResponse, error)
+	VerifySignIn(ctx context.Context, payload VerifySignInPayload) (*AuthTokens, error)
+	VerifyTwoFactorSignIn(ctx context.Context, payload VerifyTwoFactorSignInPayload) (*AuthTokens, error)
+	ForgotPassword(ctx context.Context, payload ForgotPasswordPayload) error
+	ResetPassword(ctx context.Context, payload ResetPasswordPayload) error
+	ReportSignIn(ctx context.Context, payload ReportSignInPayload) error
+	ChangePassword(ctx context.Context, payload ChangePasswordPayload) error
 	SignOut(ctx context.Context) error
 	GetUser(ctx context.Context) (*UserResponse, error)
 	UpdateUser(ctx context.Context, payload UserUpdatePayload) error
 	DeleteUser(ctx context.Context) error
 	CheckUsername(ctx context.Context, payload CheckUsernamePayload) (*UsernameSuggestionResponse, error)
+	UpdateUserRole(ctx context.Context, userID uuid.UUID, payload UpdateUserRolePayload) error
+	BlockUser(ctx context.Context, userID uuid.UUID, payload ModerationReasonPayload) error
+	UnblockUser(ctx context.Context, userID uuid.UUID, payload ModerationReasonPayload) error
+	GetOIDCAuthorizationURL(ctx context.Context, provider string) (*OIDCAuthorizationResponse, error)
+	SignInWithOIDC(ctx context.Context, provider string, payload OIDCCallbackPayload) (*AuthTokens, error)
 }
 
 type UserRepository interface {
@@ -139,9 +232,27 @@ func (s *SignInPayload) trim() {
 	s.EmailOrUsername = strings.TrimSpace(strings.ToLower(s.EmailOrUsername))
 }
 
+func (v *VerifySignInPayload) trim() {
+	v.Hash = strings.TrimSpace(v.Hash)
+	v.Code = strings.TrimSpace(v.Code)
+}
+
+var lineBreakNormalizer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
 func (uup *UserUpdatePayload) trim() {
 	uup.FirstName = strings.TrimSpace(uup.FirstName)
 	uup.LastName = strings.TrimSpace(uup.LastName)
+
+	for _, field := range []*string{uup.Bio, uup.Website, uup.Location, uup.Pronouns, uup.Birthday} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+
+	// browsers send textarea line breaks as \r\n
+	if uup.Bio != nil {
+		*uup.Bio = lineBreakNormalizer.Replace(*uup.Bio)
+	}
 }
 
 func (c *CheckUsernamePayload) trim() {
@@ -158,16 +269,35 @@ func (s *SignInPayload) Validate() ValidationErrors {
 	return ValidateStruct(s)
 }
 
+func (v *VerifySignInPayload) Validate() ValidationErrors {
+	v.trim()
+	return ValidateStruct(v)
+}
+
+func (c *ChangePasswordPayload) Validate() ValidationErrors {
+	return ValidateStruct(c)
+}
+
 func (uup *UserUpdatePayload) Validate() ValidationErrors {
 	uup.trim()
 
-	if uup.FirstName == "" && uup.LastName == "" && uup.Username == "" {
-		return ValidationErrors{"General": "firstName or lastName or username is required"}
+	if uup.isEmpty() {
+		return ValidationErrors{"General": "at least one field is required"}
 	}
 
 	return ValidateStruct(uup)
 }
 
+func (uup *UserUpdatePayload) isEmpty() bool {
+	return uup.FirstName == "" && uup.LastName == "" && uup.Username == "" &&
+		uup.Bio == nil && uup.Website == nil && uup.Location == nil && uup.Pronouns == nil &&
+		uup.Birthday == nil && uup.BirthdayVisibility == nil
+}
+
+func (u *UpdateUserRolePayload) Validate() ValidationErrors {
+	return ValidateStruct(u)
+}
+
 func (c *CheckUsernamePayload) Validate() ValidationErrors {
 	c.trim()
 	return ValidateStruct(c)
@@ -195,20 +325,130 @@ func (up *UserPayload) ToUser(passwordHash string) *User {
 		Email:     up.Email,
 		Password:  passwordHash,
 		Avatar:    config.Env.AvatarPlaceholder,
+		Role:      RoleUser,
+	}
+}
+
+// UsernameFromEmail derives a username candidate from the email's local part,
+// keeping only the characters the username validator accepts. The result is
+// short enough to leave room for the suffixes of utils.GenerateSuggestions.
+func UsernameFromEmail(email string) string {
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	var builder strings.Builder
+	for _, r := range localPart {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			builder.WriteRune(r)
+		}
+		if builder.Len() == MaxUsernameLength-5 {
+			break
+		}
+	}
+
+	username := builder.String()
+	if len(username) < MinUsernameLength {
+		username = "user" + username
+	}
+
+	return username
+}
+
+// NewUserFromOIDC builds a user for a first sign-in through an OpenID Connect
+// provider. The email is already verified by the provider and the password
+// is random, so the account can only get one through the reset flow.
+func NewUserFromOIDC(claims OIDCClaims, username string, passwordHash string) *User {
+	firstName, lastName := claims.Names()
+	now := time.Now().UTC()
+
+	avatar := config.Env.AvatarPlaceholder
+	if claims.Picture != "" && len(claims.Picture) <= 255 {
+		avatar = claims.Picture
+	}
+
+	return &User{
+		ID:               uuid.New(),
+		FirstName:        firstName,
+		LastName:         lastName,
+		Username:         username,
+		Email:            claims.Email,
+		Password:         passwordHash,
+		Avatar:           avatar,
+		Role:             RoleUser,
+		EmailConfirmedAt: &now,
 	}
 }
 
 func (u *User) ToUserResponse() *UserResponse {
 	return &UserResponse{
-		ID:        u.ID.String(),
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		Username:  u.Username,
-		Email:     u.Email,
-		Avatar:    u.Avatar,
+		ID:                 u.ID.String(),
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		Username:           u.Username,
+		Email:              u.Email,
+		Avatar:             u.Avatar,
+		Bio:                u.Bio,
+		Website:            u.Website,
+		Location:           u.Location,
+		Pronouns:           u.Pronouns,
+		Birthday:           u.FormattedBirthday(),
+		BirthdayVisibility: u.BirthdayVisibility,
+		Role:               u.Role,
+		TwoFactorEnabled:   u.TwoFactorEnabled,
+	}
+}
+
+func (u *User) IsEmailConfirmed() bool {
+	return u.EmailConfirmedAt != nil
+}
+
+// CheckStatus returns the error matching a status that keeps the user from
+// signing in or using an existing session.
+func (u *User) CheckStatus() error {
+	return CheckUserStatus(u.Status)
+}
+
+func (u *User) SetStatus(status UserStatus, reason string) {
+	now := time.Now().UTC()
+	u.Status = status
+	u.StatusReason = reason
+	u.StatusChangedAt = &now
+}
+
+// ScheduleDeletion deactivates the account until it is purged at the end of
+// the grace period.
+func (u *User) ScheduleDeletion(gracePeriod time.Duration) {
+	purgeAt := time.Now().UTC().Add(gracePeriod)
+	u.SetStatus(Inactive, AccountDeletionReason)
+	u.ScheduledPurgeAt = &purgeAt
+}
+
+// IsPendingDeletion reports whether the account was deleted by its owner and
+// can still be restored.
+func (u *User) IsPendingDeletion() bool {
+	return u.Status == Inactive && u.ScheduledPurgeAt != nil && time.Now().UTC().Before(*u.ScheduledPurgeAt)
+}
+
+func (u *User) RestoreDeletion() {
+	u.SetStatus(Active, "")
+	u.ScheduledPurgeAt = nil
+}
+
+func CheckUserStatus(status UserStatus) error {
+	switch status {
+	case Block:
+		return ErrUserBlocked
+	case Inactive:
+		return ErrUserInactive
+	default:
+		return nil
 	}
 }
 
+func (u *User) ConfirmEmail() {
+	now := time.Now().UTC()
+	u.EmailConfirmedAt = &now
+}
+
 func (u *User) Update(payload UserUpdatePayload) {
 	if payload.FirstName != "" {
 		u.FirstName = payload.FirstName
@@ -221,6 +461,34 @@ func (u *User) Update(payload UserUpdatePayload) {
 	if payload.Username != "" {
 		u.Username = payload.Username
 	}
+
+	if payload.Bio != nil {
+		u.Bio = *payload.Bio
+	}
+
+	if payload.Website != nil {
+		u.Website = *payload.Website
+	}
+
+	if payload.Location != nil {
+		u.Location = *payload.Location
+	}
+
+	if payload.Pronouns != nil {
+		u.Pronouns = *payload.Pronouns
+	}
+
+	if payload.Birthday != nil {
+		u.Birthday = nil
+		// the validator only lets through empty or well formed dates
+		if birthday, err := time.Parse(BirthdayLayout, *payload.Birthday); err == nil {
+			u.Birthday = &birthday
+		}
+	}
+
+	if payload.BirthdayVisibility != nil {
+		u.BirthdayVisibility = *payload.BirthdayVisibility
+	}
 }
 
 func (User) TableName() string {
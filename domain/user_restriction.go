@@ -0,0 +1,76 @@
+package domain
+
+//go:generate mockery --name=UserRestrictionHandler --output=../mocks --outpkg=mocks
+//go:generate mockery --name=UserRestrictionService --output=../mocks --outpkg=mocks
+//go:generate mockery --name=UserRestrictionRepository --output=../mocks --outpkg=mocks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCannotRestrictSelf           = errors.New("cannot block or mute own account")
+	ErrUserRestrictionAlreadyExists = errors.New("user restriction already exists")
+	ErrUserRestrictionNotFound      = errors.New("user restriction not found")
+	ErrFollowBlocked                = errors.New("follow is blocked")
+)
+
+type RestrictionType string
+
+const (
+	RestrictionBlock RestrictionType = "block"
+	RestrictionMute  RestrictionType = "mute"
+)
+
+// UserRestriction is a block or mute one user placed on another. A block cuts
+// the follow relationship both ways and hides the blocker's profile from the
+// target, a mute only keeps the target's posts out of the user's feed.
+type UserRestriction struct {
+	ID        uuid.UUID       `gorm:"column:id;type:char(36);primaryKey"`
+	UserID    uuid.UUID       `gorm:"column:userId;type:char(36);not null;uniqueIndex:idx_user_target_type"`
+	User      User            `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TargetID  uuid.UUID       `gorm:"column:targetId;type:char(36);not null;uniqueIndex:idx_user_target_type;index"`
+	Target    User            `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE"`
+	Type      RestrictionType `gorm:"column:type;type:enum('block','mute');not null;uniqueIndex:idx_user_target_type"`
+	CreatedAt time.Time       `gorm:"column:createdAt;not null"`
+}
+
+type UserRestrictionHandler interface {
+	BlockUser(ctx echo.Context) error
+	UnblockUser(ctx echo.Context) error
+	MuteUser(ctx echo.Context) error
+	UnmuteUser(ctx echo.Context) error
+}
+
+type UserRestrictionService interface {
+	Restrict(ctx context.Context, targetID uuid.UUID, restrictionType RestrictionType) error
+	Unrestrict(ctx context.Context, targetID uuid.UUID, restrictionType RestrictionType) error
+}
+
+type UserRestrictionRepository interface {
+	CreateRestriction(ctx context.Context, restriction UserRestriction) error
+	DeleteRestriction(ctx context.Context, userID, targetID uuid.UUID, restrictionType RestrictionType) (bool, error)
+	GetRestrictionTypes(ctx context.Context, userID, targetID uuid.UUID) ([]RestrictionType, error)
+	HasBlock(ctx context.Context, userID, targetID uuid.UUID) (bool, error)
+}
+
+func HasRestriction(restrictionTypes []RestrictionType, restrictionType RestrictionType) bool {
+	return slices.Contains(restrictionTypes, restrictionType)
+}
+
+func (UserRestriction) TableName() string {
+	return "UserRestriction"
+}
+
+func (r *UserRestriction) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	r.CreatedAt = time.Now().UTC()
+	return
+}
@@ -9,15 +9,24 @@ import (
 type ValidationErrors map[string]string
 
 var ValidationMessages = ValidationErrors{
-	"required":        "This field is required",
-	"email":           "Invalid email format",
-	"min":             "Value is too short",
-	"max":             "Value is too long",
-	"eqfield":         "Fields do not match",
-	"gt":              "The value must be greater than zero",
-	"datetime":        "Invalid birth date",
-	StrongPasswordTag: "Password must be at least 8 characters long, contain an uppercase letter, a number, and a special character",
-	UsernameTag:       "Username must be between 3 and 20 characters and can only contain lowercase letters, numbers, and the characters ._-",
+	"required":         "This field is required",
+	"required_without": "Either this field or its alternative is required",
+	"email":            "Invalid email format",
+	"min":              "Value is too short",
+	"max":              "Value is too long",
+	"eqfield":          "Fields do not match",
+	"gt":               "The value must be greater than zero",
+	"len":              "Value has an invalid length",
+	"numeric":          "Value must contain only numbers",
+	"datetime":         "Invalid birth date",
+	"oneof":            "Value is not one of the allowed options",
+	StrongPasswordTag:  "Password must be at least 8 characters long, contain an uppercase letter, a number, and a special character, and must not be a common or easily guessed password",
+	ScopeTag:           "Unknown scope",
+	ValidateImagesTag:  "Image must be a single PNG or JPEG file of at most 5 MB",
+	WebsiteTag:         "Website must be a valid http or https URL",
+	NoControlTag:       "Value must not contain control characters",
+	BirthdayTag:        "Birthday must be a past date in the format YYYY-MM-DD",
+	UsernameTag:        "Username must be between 3 and 20 characters and can only contain lowercase letters, numbers, and the characters ._-",
 }
 
 func ValidateStruct(s any) ValidationErrors {
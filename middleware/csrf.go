@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	accessTokenCookie  = "x.Token"
+	refreshTokenCookie = "x.RefreshToken"
+)
+
+// CSRF enforces the double-submit token on unsafe requests that a browser
+// authenticates implicitly with the session cookies: domain.CSRFHeader must
+// match the domain.CSRFCookie issued at sign-in or by GET /v1/csrf.
+//
+// Requests carrying an Authorization header are exempt, since EnsureAuthenticated
+// ignores the cookies in that case and a cross-site page cannot set the header.
+// Requests without session cookies have no ambient credential to abuse.
+func CSRF(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if isSafeMethod(ctx.Request().Method) {
+			return next(ctx)
+		}
+
+		if ctx.Request().Header.Get(echo.HeaderAuthorization) != "" {
+			return next(ctx)
+		}
+
+		if !hasCookie(ctx, accessTokenCookie) && !hasCookie(ctx, refreshTokenCookie) {
+			return next(ctx)
+		}
+
+		cookie, err := ctx.Cookie(domain.CSRFCookie)
+		if err != nil || cookie.Value == "" {
+			return domain.CSRFTokenAPIErrorResponse(ctx)
+		}
+
+		header := ctx.Request().Header.Get(domain.CSRFHeader)
+		if subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+			return domain.CSRFTokenAPIErrorResponse(ctx)
+		}
+
+		return next(ctx)
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasCookie(ctx echo.Context, name string) bool {
+	cookie, err := ctx.Cookie(name)
+	return err == nil && cookie.Value != ""
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRF(t *testing.T) {
+	const csrfToken = "csrf-token"
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		cookies        map[string]string
+		header         string
+		expectedStatus int
+	}{
+		{
+			name:           "safe method passes without a token",
+			method:         http.MethodGet,
+			cookies:        map[string]string{accessTokenCookie: "session"},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "head passes without a token",
+			method:         http.MethodHead,
+			cookies:        map[string]string{accessTokenCookie: "session"},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "bearer token is exempt",
+			method:         http.MethodPost,
+			authorization:  "Bearer access-token",
+			cookies:        map[string]string{accessTokenCookie: "session"},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "personal access token is exempt",
+			method:         http.MethodDelete,
+			authorization:  "Bearer " + domain.PersonalAccessTokenPrefix + "token",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "request without session cookies passes",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing header is rejected",
+			method:         http.MethodPost,
+			cookies:        map[string]string{accessTokenCookie: "session", domain.CSRFCookie: csrfToken},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "header not matching the cookie is rejected",
+			method:         http.MethodPut,
+			cookies:        map[string]string{accessTokenCookie: "session", domain.CSRFCookie: csrfToken},
+			header:         "forged-token",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing csrf cookie is rejected",
+			method:         http.MethodPost,
+			cookies:        map[string]string{refreshTokenCookie: "refresh"},
+			header:         csrfToken,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "matching header and cookie pass",
+			method:         http.MethodPatch,
+			cookies:        map[string]string{accessTokenCookie: "session", domain.CSRFCookie: csrfToken},
+			header:         csrfToken,
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, "/", nil)
+			if test.authorization != "" {
+				request.Header.Set(echo.HeaderAuthorization, test.authorization)
+			}
+			if test.header != "" {
+				request.Header.Set(domain.CSRFHeader, test.header)
+			}
+			for name, value := range test.cookies {
+				request.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+
+			recorder := httptest.NewRecorder()
+			ctx := echo.New().NewContext(request, recorder)
+
+			err := CSRF(func(c echo.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			})(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
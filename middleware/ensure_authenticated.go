@@ -4,37 +4,61 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/internal"
 	"github.com/labstack/echo/v4"
 )
 
-func EnsureAuthenticated(di *internal.Di) echo.MiddlewareFunc {
+// EnsureAuthenticated resolves the caller's session from one of two credentials:
+//
+//  1. An "Authorization: Bearer <token>" header, used by the mobile app and
+//     scripts. The token is either a session JWT or a personal access token
+//     (see domain.PersonalAccessTokenPrefix). When the header is present it is
+//     the only credential considered: a malformed or rejected bearer token fails
+//     the request even if a valid cookie was also sent, so a client never acts
+//     with an identity it did not explicitly present.
+//  2. The x.Token cookie set for browsers by the sign-in flow.
+//
+// Sessions of accounts that are blocked or inactive are refused with 403.
+//
+// scopes declares what a personal access token needs to reach the route.
+// Interactive sessions are not scoped, while personal access tokens are
+// refused on routes that declare no scope at all.
+func EnsureAuthenticated(di *internal.Di, scopes ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
-			sessionService, err := internal.Invoke[domain.SessionService](di)
-			if err != nil {
-				slog.Error(err.Error())
-				return domain.InternalServerAPIErrorResponse(ctx)
-			}
-
-			cookie, err := ctx.Cookie("x.Token")
-			if err != nil || cookie == nil || cookie.Value == "" {
-				clearAuthCookie(ctx)
+			token, fromCookie, ok := extractToken(ctx)
+			if !ok {
+				if fromCookie {
+					clearAuthCookie(ctx)
+				}
 				return domain.AccessDeniedAPIErrorResponse(ctx)
 			}
 
-			session, err := sessionService.GetSessionByToken(ctx.Request().Context(), cookie.Value)
+			session, err := getSessionByToken(ctx.Request().Context(), di, token, fromCookie)
 			if err != nil {
 				if err == domain.ErrTokenInvalid || err == domain.ErrSessionMismatch || err == domain.ErrSessionNotFound {
-					clearAuthCookie(ctx)
+					if fromCookie {
+						clearAuthCookie(ctx)
+					}
 					return domain.AccessDeniedAPIErrorResponse(ctx)
 				}
+				if err == domain.ErrUserBlocked || err == domain.ErrUserInactive {
+					if fromCookie {
+						clearAuthCookie(ctx)
+					}
+					return domain.AccountStatusAPIErrorResponse(ctx, err)
+				}
 				slog.Error(err.Error())
 				return domain.InternalServerAPIErrorResponse(ctx)
 			}
 
+			if !session.HasScopes(scopes...) {
+				return domain.ForbiddenPermissionAPIErrorResponse(ctx)
+			}
+
 			newCtx := context.WithValue(ctx.Request().Context(), domain.SessionKey, session)
 			ctx.SetRequest(ctx.Request().WithContext(newCtx))
 
@@ -43,9 +67,48 @@ func EnsureAuthenticated(di *internal.Di) echo.MiddlewareFunc {
 	}
 }
 
+func getSessionByToken(ctx context.Context, di *internal.Di, token string, fromCookie bool) (*domain.Session, error) {
+	if !fromCookie && domain.IsPersonalAccessToken(token) {
+		personalAccessTokenService, err := internal.Invoke[domain.PersonalAccessTokenService](di)
+		if err != nil {
+			return nil, err
+		}
+
+		return personalAccessTokenService.GetSessionByToken(ctx, token)
+	}
+
+	sessionService, err := internal.Invoke[domain.SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionService.GetSessionByToken(ctx, token)
+}
+
+// extractToken returns the credential following the precedence documented on
+// EnsureAuthenticated and whether it was read from the cookie.
+func extractToken(ctx echo.Context) (string, bool, bool) {
+	if authorization := ctx.Request().Header.Get(echo.HeaderAuthorization); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return "", false, false
+		}
+
+		token = strings.TrimSpace(token)
+		return token, false, token != ""
+	}
+
+	cookie, err := ctx.Cookie(accessTokenCookie)
+	if err != nil || cookie == nil || cookie.Value == "" {
+		return "", true, false
+	}
+
+	return cookie.Value, true, true
+}
+
 func clearAuthCookie(ctx echo.Context) {
 	cookie := new(http.Cookie)
-	cookie.Name = "x.Token"
+	cookie.Name = accessTokenCookie
 	cookie.Value = ""
 	cookie.Path = "/"
 	cookie.HttpOnly = true
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuthContext(authorization, cookie string) (echo.Context, *httptest.ResponseRecorder) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		request.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	if cookie != "" {
+		request.AddCookie(&http.Cookie{Name: accessTokenCookie, Value: cookie})
+	}
+
+	recorder := httptest.NewRecorder()
+	return echo.New().NewContext(request, recorder), recorder
+}
+
+func TestExtractToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		cookie         string
+		expectedToken  string
+		expectedCookie bool
+		expectedOK     bool
+	}{
+		{"bearer takes precedence over the cookie", "Bearer header-token", "cookie-token", "header-token", false, true},
+		{"scheme is case insensitive", "bearer header-token", "", "header-token", false, true},
+		{"wrong scheme does not fall back to the cookie", "Basic dXNlcjpwYXNz", "cookie-token", "", false, false},
+		{"missing token does not fall back to the cookie", "Bearer", "cookie-token", "", false, false},
+		{"empty token does not fall back to the cookie", "Bearer   ", "cookie-token", "", false, false},
+		{"cookie is used without an authorization header", "", "cookie-token", "cookie-token", true, true},
+		{"no credential at all", "", "", "", true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, _ := newAuthContext(test.authorization, test.cookie)
+
+			token, fromCookie, ok := extractToken(ctx)
+
+			assert.Equal(t, test.expectedToken, token)
+			assert.Equal(t, test.expectedCookie, fromCookie)
+			assert.Equal(t, test.expectedOK, ok)
+		})
+	}
+}
+
+func TestEnsureAuthenticated_WhenBearerAndCookieSent_ShouldUseBearer(t *testing.T) {
+	sessionServiceMock := new(mocks.SessionService)
+	di := internal.NewDi()
+	internal.Provide(di, func(d *internal.Di) (domain.SessionService, error) {
+		return sessionServiceMock, nil
+	})
+
+	session := &domain.Session{UserID: uuid.New()}
+	sessionServiceMock.On("GetSessionByToken", mock.Anything, "header-token").Return(session, nil)
+
+	ctx, recorder := newAuthContext("Bearer header-token", "cookie-token")
+
+	var resolved *domain.Session
+	err := EnsureAuthenticated(di)(func(c echo.Context) error {
+		resolved, _ = c.Request().Context().Value(domain.SessionKey).(*domain.Session)
+		return c.NoContent(http.StatusNoContent)
+	})(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, session, resolved)
+	sessionServiceMock.AssertNotCalled(t, "GetSessionByToken", mock.Anything, "cookie-token")
+}
+
+func TestEnsureAuthenticated_WhenAuthorizationMalformed_ShouldRejectEvenWithValidCookie(t *testing.T) {
+	for _, authorization := range []string{"Basic dXNlcjpwYXNz", "Bearer "} {
+		sessionServiceMock := new(mocks.SessionService)
+		di := internal.NewDi()
+		internal.Provide(di, func(d *internal.Di) (domain.SessionService, error) {
+			return sessionServiceMock, nil
+		})
+
+		ctx, recorder := newAuthContext(authorization, "cookie-token")
+
+		err := EnsureAuthenticated(di)(func(c echo.Context) error {
+			t.Fatal("handler must not run")
+			return nil
+		})(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Empty(t, recorder.Header().Values(echo.HeaderSetCookie), "the cookie must be left alone")
+		sessionServiceMock.AssertNotCalled(t, "GetSessionByToken", mock.Anything, mock.Anything)
+	}
+}
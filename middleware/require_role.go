@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireRole only lets the request through when the session resolved by
+// EnsureAuthenticated holds at least the given role, so it must be registered
+// after it.
+func RequireRole(role domain.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			session, ok := ctx.Request().Context().Value(domain.SessionKey).(*domain.Session)
+			if !ok {
+				return domain.AccessDeniedAPIErrorResponse(ctx)
+			}
+
+			if !session.Role.Includes(role) {
+				return domain.ForbiddenPermissionAPIErrorResponse(ctx)
+			}
+
+			return next(ctx)
+		}
+	}
+}
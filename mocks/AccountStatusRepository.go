@@ -0,0 +1,77 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AccountStatusRepository is an autogenerated mock type for the AccountStatusRepository type
+type AccountStatusRepository struct {
+	mock.Mock
+}
+
+// GetStatus provides a mock function with given fields: ctx, userID
+func (_m *AccountStatusRepository) GetStatus(ctx context.Context, userID uuid.UUID) (domain.UserStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 domain.UserStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.UserStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.UserStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.UserStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetStatus provides a mock function with given fields: ctx, userID, status
+func (_m *AccountStatusRepository) SetStatus(ctx context.Context, userID uuid.UUID, status domain.UserStatus) error {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.UserStatus) error); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAccountStatusRepository creates a new instance of AccountStatusRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountStatusRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountStatusRepository {
+	mock := &AccountStatusRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AvatarService is an autogenerated mock type for the AvatarService type
+type AvatarService struct {
+	mock.Mock
+}
+
+// DeleteAvatar provides a mock function with given fields: ctx
+func (_m *AvatarService) DeleteAvatar(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvatar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAvatar provides a mock function with given fields: ctx, key
+func (_m *AvatarService) GetAvatar(ctx context.Context, key string) (*domain.AvatarFile, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvatar")
+	}
+
+	var r0 *domain.AvatarFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.AvatarFile, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.AvatarFile); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AvatarFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadAvatar provides a mock function with given fields: ctx, content
+func (_m *AvatarService) UploadAvatar(ctx context.Context, content io.Reader) (*domain.AvatarResponse, error) {
+	ret := _m.Called(ctx, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAvatar")
+	}
+
+	var r0 *domain.AvatarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) (*domain.AvatarResponse, error)); ok {
+		return rf(ctx, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) *domain.AvatarResponse); ok {
+		r0 = rf(ctx, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AvatarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = rf(ctx, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAvatarService creates a new instance of AvatarService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAvatarService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AvatarService {
+	mock := &AvatarService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ClientInfoService is an autogenerated mock type for the ClientInfoService type
+type ClientInfoService struct {
+	mock.Mock
+}
+
+// GetClientInfo provides a mock function with given fields: ctx
+func (_m *ClientInfoService) GetClientInfo(ctx context.Context) (*domain.ClientInfoResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClientInfo")
+	}
+
+	var r0 *domain.ClientInfoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ClientInfoResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ClientInfoResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ClientInfoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewClientInfoService creates a new instance of ClientInfoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClientInfoService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClientInfoService {
+	mock := &ClientInfoService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
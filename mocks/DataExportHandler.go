@@ -0,0 +1,63 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	echo "github.com/labstack/echo/v4"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataExportHandler is an autogenerated mock type for the DataExportHandler type
+type DataExportHandler struct {
+	mock.Mock
+}
+
+// DownloadDataExport provides a mock function with given fields: ctx
+func (_m *DataExportHandler) DownloadDataExport(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadDataExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestDataExport provides a mock function with given fields: ctx
+func (_m *DataExportHandler) RequestDataExport(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestDataExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDataExportHandler creates a new instance of DataExportHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataExportHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataExportHandler {
+	mock := &DataExportHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
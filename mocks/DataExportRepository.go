@@ -0,0 +1,231 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// DataExportRepository is an autogenerated mock type for the DataExportRepository type
+type DataExportRepository struct {
+	mock.Mock
+}
+
+// CreateExport provides a mock function with given fields: ctx, export
+func (_m *DataExportRepository) CreateExport(ctx context.Context, export domain.DataExport) (*domain.DataExport, error) {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExport")
+	}
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DataExport) (*domain.DataExport, error)); ok {
+		return rf(ctx, export)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DataExport) *domain.DataExport); ok {
+		r0 = rf(ctx, export)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.DataExport) error); ok {
+		r1 = rf(ctx, export)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredExports provides a mock function with given fields: ctx, before, limit
+func (_m *DataExportRepository) GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*domain.DataExport, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredExports")
+	}
+
+	var r0 []*domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*domain.DataExport, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.DataExport); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExportByID provides a mock function with given fields: ctx, ID
+func (_m *DataExportRepository) GetExportByID(ctx context.Context, ID uuid.UUID) (*domain.DataExport, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportByID")
+	}
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.DataExport, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.DataExport); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExportByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *DataExportRepository) GetExportByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportByTokenHash")
+	}
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.DataExport, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.DataExport); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExportsByUserID provides a mock function with given fields: ctx, userID
+func (_m *DataExportRepository) GetExportsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.DataExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportsByUserID")
+	}
+
+	var r0 []*domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.DataExport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.DataExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingExport provides a mock function with given fields: ctx, userID
+func (_m *DataExportRepository) GetPendingExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingExport")
+	}
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.DataExport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.DataExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateExport provides a mock function with given fields: ctx, export
+func (_m *DataExportRepository) UpdateExport(ctx context.Context, export domain.DataExport) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DataExport) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDataExportRepository creates a new instance of DataExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataExportRepository {
+	mock := &DataExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
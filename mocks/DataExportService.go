@@ -0,0 +1,137 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// DataExportService is an autogenerated mock type for the DataExportService type
+type DataExportService struct {
+	mock.Mock
+}
+
+// DownloadDataExport provides a mock function with given fields: ctx, token
+func (_m *DataExportService) DownloadDataExport(ctx context.Context, token string) (*domain.DataExportFile, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadDataExport")
+	}
+
+	var r0 *domain.DataExportFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.DataExportFile, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.DataExportFile); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExportFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessDataExport provides a mock function with given fields: ctx, exportID
+func (_m *DataExportService) ProcessDataExport(ctx context.Context, exportID uuid.UUID) error {
+	ret := _m.Called(ctx, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessDataExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeExpiredExports provides a mock function with given fields: ctx
+func (_m *DataExportService) PurgeExpiredExports(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredExports")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestDataExport provides a mock function with given fields: ctx
+func (_m *DataExportService) RequestDataExport(ctx context.Context) (*domain.DataExportResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestDataExport")
+	}
+
+	var r0 *domain.DataExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.DataExportResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.DataExportResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDataExportService creates a new instance of DataExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataExportService {
+	mock := &DataExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExternalIdentityRepository is an autogenerated mock type for the ExternalIdentityRepository type
+type ExternalIdentityRepository struct {
+	mock.Mock
+}
+
+// CreateIdentity provides a mock function with given fields: ctx, identity
+func (_m *ExternalIdentityRepository) CreateIdentity(ctx context.Context, identity domain.ExternalIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExternalIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetIdentity provides a mock function with given fields: ctx, provider, subject
+func (_m *ExternalIdentityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*domain.ExternalIdentity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdentity")
+	}
+
+	var r0 *domain.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ExternalIdentity, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ExternalIdentity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ExternalIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewExternalIdentityRepository creates a new instance of ExternalIdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExternalIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExternalIdentityRepository {
+	mock := &ExternalIdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// KnownDeviceRepository is an autogenerated mock type for the KnownDeviceRepository type
+type KnownDeviceRepository struct {
+	mock.Mock
+}
+
+// CreateDevice provides a mock function with given fields: ctx, device
+func (_m *KnownDeviceRepository) CreateDevice(ctx context.Context, device domain.KnownDevice) error {
+	ret := _m.Called(ctx, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.KnownDevice) error); ok {
+		r0 = rf(ctx, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDevice provides a mock function with given fields: ctx, userID, fingerprint
+func (_m *KnownDeviceRepository) DeleteDevice(ctx context.Context, userID uuid.UUID, fingerprint string) error {
+	ret := _m.Called(ctx, userID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDevice provides a mock function with given fields: ctx, userID, fingerprint
+func (_m *KnownDeviceRepository) GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*domain.KnownDevice, error) {
+	ret := _m.Called(ctx, userID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
+	var r0 *domain.KnownDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*domain.KnownDevice, error)); ok {
+		return rf(ctx, userID, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *domain.KnownDevice); ok {
+		r0 = rf(ctx, userID, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.KnownDevice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasDevices provides a mock function with given fields: ctx, userID
+func (_m *KnownDeviceRepository) HasDevices(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasDevices")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastSeen provides a mock function with given fields: ctx, ID
+func (_m *KnownDeviceRepository) UpdateLastSeen(ctx context.Context, ID uuid.UUID) error {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastSeen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewKnownDeviceRepository creates a new instance of KnownDeviceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKnownDeviceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KnownDeviceRepository {
+	mock := &KnownDeviceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -82,6 +82,36 @@ func (_m *LikeRepository) GetLikedPostIDs(ctx context.Context, userID uuid.UUID)
 	return r0, r1
 }
 
+// GetLikesByUserID provides a mock function with given fields: ctx, userID
+func (_m *LikeRepository) GetLikesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Like, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLikesByUserID")
+	}
+
+	var r0 []*domain.Like
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.Like, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.Like); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Like)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserLikedPost provides a mock function with given fields: ctx, ID, userID
 func (_m *LikeRepository) UserLikedPost(ctx context.Context, ID uuid.UUID, userID uuid.UUID) (bool, error) {
 	ret := _m.Called(ctx, ID, userID)
@@ -76,6 +76,108 @@ func (_m *MemoryCacheRepository) GetPosts(ctx context.Context, userID uuid.UUID,
 	return r0, r1
 }
 
+// GetProfileCounts provides a mock function with given fields: ctx, userID
+func (_m *MemoryCacheRepository) GetProfileCounts(ctx context.Context, userID uuid.UUID) (*domain.ProfileCounts, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfileCounts")
+	}
+
+	var r0 *domain.ProfileCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ProfileCounts, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ProfileCounts); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProfileCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementProfileCount provides a mock function with given fields: ctx, userID, count, delta
+func (_m *MemoryCacheRepository) IncrementProfileCount(ctx context.Context, userID uuid.UUID, count domain.ProfileCount, delta int64) error {
+	ret := _m.Called(ctx, userID, count, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementProfileCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProfileCount, int64) error); ok {
+		r0 = rf(ctx, userID, count, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateFeeds provides a mock function with given fields: ctx
+func (_m *MemoryCacheRepository) InvalidateFeeds(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateFeeds")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateProfileCounts provides a mock function with given fields: ctx
+func (_m *MemoryCacheRepository) InvalidateProfileCounts(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateProfileCounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateUserFeed provides a mock function with given fields: ctx, userID
+func (_m *MemoryCacheRepository) InvalidateUserFeed(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateUserFeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemovePostLike provides a mock function with given fields: ctx, postID, userID
 func (_m *MemoryCacheRepository) RemovePostLike(ctx context.Context, postID uuid.UUID, userID uuid.UUID) error {
 	ret := _m.Called(ctx, postID, userID)
@@ -148,6 +250,24 @@ func (_m *MemoryCacheRepository) SetPostLike(ctx context.Context, postID uuid.UU
 	return r0
 }
 
+// SetProfileCounts provides a mock function with given fields: ctx, userID, counts
+func (_m *MemoryCacheRepository) SetProfileCounts(ctx context.Context, userID uuid.UUID, counts domain.ProfileCounts) error {
+	ret := _m.Called(ctx, userID, counts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProfileCounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ProfileCounts) error); ok {
+		r0 = rf(ctx, userID, counts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMemoryCacheRepository creates a new instance of MemoryCacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMemoryCacheRepository(t interface {
@@ -0,0 +1,67 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ModerationRepository is an autogenerated mock type for the ModerationRepository type
+type ModerationRepository struct {
+	mock.Mock
+}
+
+// DeletePost provides a mock function with given fields: ctx, action, postID
+func (_m *ModerationRepository) DeletePost(ctx context.Context, action domain.ModerationAction, postID uuid.UUID) error {
+	ret := _m.Called(ctx, action, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ModerationAction, uuid.UUID) error); ok {
+		r0 = rf(ctx, action, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, action, user
+func (_m *ModerationRepository) UpdateUser(ctx context.Context, action domain.ModerationAction, user domain.User) error {
+	ret := _m.Called(ctx, action, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ModerationAction, domain.User) error); ok {
+		r0 = rf(ctx, action, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewModerationRepository creates a new instance of ModerationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModerationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ModerationRepository {
+	mock := &ModerationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
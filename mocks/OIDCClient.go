@@ -0,0 +1,135 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCClient is an autogenerated mock type for the OIDCClient type
+type OIDCClient struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, provider, state, nonce, codeChallenge
+func (_m *OIDCClient) AuthCodeURL(ctx context.Context, provider string, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, provider, state, nonce, codeChallenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, error)); ok {
+		return rf(ctx, provider, state, nonce, codeChallenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, provider, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, provider, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, provider, code, codeVerifier
+func (_m *OIDCClient) Exchange(ctx context.Context, provider string, code string, codeVerifier string) (*domain.OIDCTokenResponse, error) {
+	ret := _m.Called(ctx, provider, code, codeVerifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 *domain.OIDCTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.OIDCTokenResponse, error)); ok {
+		return rf(ctx, provider, code, codeVerifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.OIDCTokenResponse); ok {
+		r0 = rf(ctx, provider, code, codeVerifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, provider, code, codeVerifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasProvider provides a mock function with given fields: provider
+func (_m *OIDCClient) HasProvider(provider string) bool {
+	ret := _m.Called(provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasProvider")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(provider)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// VerifyIDToken provides a mock function with given fields: ctx, provider, rawIDToken, nonce
+func (_m *OIDCClient) VerifyIDToken(ctx context.Context, provider string, rawIDToken string, nonce string) (*domain.OIDCClaims, error) {
+	ret := _m.Called(ctx, provider, rawIDToken, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyIDToken")
+	}
+
+	var r0 *domain.OIDCClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.OIDCClaims, error)); ok {
+		return rf(ctx, provider, rawIDToken, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.OIDCClaims); ok {
+		r0 = rf(ctx, provider, rawIDToken, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, provider, rawIDToken, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOIDCClient creates a new instance of OIDCClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCClient {
+	mock := &OIDCClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCService is an autogenerated mock type for the OIDCService type
+type OIDCService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, provider, payload
+func (_m *OIDCService) Authenticate(ctx context.Context, provider string, payload domain.OIDCCallbackPayload) (*domain.OIDCClaims, error) {
+	ret := _m.Called(ctx, provider, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *domain.OIDCClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.OIDCCallbackPayload) (*domain.OIDCClaims, error)); ok {
+		return rf(ctx, provider, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.OIDCCallbackPayload) *domain.OIDCClaims); ok {
+		r0 = rf(ctx, provider, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.OIDCCallbackPayload) error); ok {
+		r1 = rf(ctx, provider, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorizationURL provides a mock function with given fields: ctx, provider
+func (_m *OIDCService) GetAuthorizationURL(ctx context.Context, provider string) (string, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorizationURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOIDCService creates a new instance of OIDCService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCService {
+	mock := &OIDCService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
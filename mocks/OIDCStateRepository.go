@@ -0,0 +1,77 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCStateRepository is an autogenerated mock type for the OIDCStateRepository type
+type OIDCStateRepository struct {
+	mock.Mock
+}
+
+// ConsumeState provides a mock function with given fields: ctx, stateHash
+func (_m *OIDCStateRepository) ConsumeState(ctx context.Context, stateHash string) (*domain.OIDCAuthState, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeState")
+	}
+
+	var r0 *domain.OIDCAuthState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.OIDCAuthState, error)); ok {
+		return rf(ctx, stateHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.OIDCAuthState); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCAuthState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetState provides a mock function with given fields: ctx, stateHash, state
+func (_m *OIDCStateRepository) SetState(ctx context.Context, stateHash string, state domain.OIDCAuthState) error {
+	ret := _m.Called(ctx, stateHash, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.OIDCAuthState) error); ok {
+		r0 = rf(ctx, stateHash, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOIDCStateRepository creates a new instance of OIDCStateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCStateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCStateRepository {
+	mock := &OIDCStateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
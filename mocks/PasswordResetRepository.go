@@ -0,0 +1,78 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PasswordResetRepository is an autogenerated mock type for the PasswordResetRepository type
+type PasswordResetRepository struct {
+	mock.Mock
+}
+
+// ConsumeToken provides a mock function with given fields: ctx, tokenHash
+func (_m *PasswordResetRepository) ConsumeToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeToken")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetToken provides a mock function with given fields: ctx, tokenHash, userID
+func (_m *PasswordResetRepository) SetToken(ctx context.Context, tokenHash string, userID uuid.UUID) error {
+	ret := _m.Called(ctx, tokenHash, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, tokenHash, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasswordResetRepository creates a new instance of PasswordResetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetRepository {
+	mock := &PasswordResetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
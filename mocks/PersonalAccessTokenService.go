@@ -0,0 +1,139 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PersonalAccessTokenService is an autogenerated mock type for the PersonalAccessTokenService type
+type PersonalAccessTokenService struct {
+	mock.Mock
+}
+
+// CreateToken provides a mock function with given fields: ctx, payload
+func (_m *PersonalAccessTokenService) CreateToken(ctx context.Context, payload domain.PersonalAccessTokenPayload) (*domain.PersonalAccessTokenCreatedResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *domain.PersonalAccessTokenCreatedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PersonalAccessTokenPayload) (*domain.PersonalAccessTokenCreatedResponse, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PersonalAccessTokenPayload) *domain.PersonalAccessTokenCreatedResponse); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PersonalAccessTokenCreatedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PersonalAccessTokenPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionByToken provides a mock function with given fields: ctx, token
+func (_m *PersonalAccessTokenService) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionByToken")
+	}
+
+	var r0 *domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Session, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Session); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokens provides a mock function with given fields: ctx
+func (_m *PersonalAccessTokenService) GetTokens(ctx context.Context) ([]*domain.PersonalAccessTokenResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokens")
+	}
+
+	var r0 []*domain.PersonalAccessTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.PersonalAccessTokenResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.PersonalAccessTokenResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PersonalAccessTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: ctx, tokenID
+func (_m *PersonalAccessTokenService) RevokeToken(ctx context.Context, tokenID uuid.UUID) error {
+	ret := _m.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPersonalAccessTokenService creates a new instance of PersonalAccessTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenService {
+	mock := &PersonalAccessTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -142,6 +142,36 @@ func (_m *PostRepository) GetPostById(ctx context.Context, ID uuid.UUID, preload
 	return r0, r1
 }
 
+// GetPostByIdIncludingHidden provides a mock function with given fields: ctx, ID
+func (_m *PostRepository) GetPostByIdIncludingHidden(ctx context.Context, ID uuid.UUID) (*domain.Post, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostByIdIncludingHidden")
+	}
+
+	var r0 *domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Post, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Post); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnlikePost provides a mock function with given fields: ctx, ID, userID
 func (_m *PostRepository) UnlikePost(ctx context.Context, ID uuid.UUID, userID uuid.UUID) error {
 	ret := _m.Called(ctx, ID, userID)
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ProfileRepository is an autogenerated mock type for the ProfileRepository type
+type ProfileRepository struct {
+	mock.Mock
+}
+
+// GetProfileCounts provides a mock function with given fields: ctx, userID
+func (_m *ProfileRepository) GetProfileCounts(ctx context.Context, userID uuid.UUID) (*domain.ProfileCounts, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfileCounts")
+	}
+
+	var r0 *domain.ProfileCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.ProfileCounts, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.ProfileCounts); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProfileCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: ctx, viewerID, query, page, limit
+func (_m *ProfileRepository) SearchUsers(ctx context.Context, viewerID uuid.UUID, query string, page int, limit int) (*domain.Pagination[*domain.UserSearchResult], error) {
+	ret := _m.Called(ctx, viewerID, query, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 *domain.Pagination[*domain.UserSearchResult]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int, int) (*domain.Pagination[*domain.UserSearchResult], error)); ok {
+		return rf(ctx, viewerID, query, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int, int) *domain.Pagination[*domain.UserSearchResult]); ok {
+		r0 = rf(ctx, viewerID, query, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Pagination[*domain.UserSearchResult])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, int, int) error); ok {
+		r1 = rf(ctx, viewerID, query, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProfileRepository creates a new instance of ProfileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProfileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProfileRepository {
+	mock := &ProfileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProfileService is an autogenerated mock type for the ProfileService type
+type ProfileService struct {
+	mock.Mock
+}
+
+// GetProfile provides a mock function with given fields: ctx, username
+func (_m *ProfileService) GetProfile(ctx context.Context, username string) (*domain.ProfileResponse, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *domain.ProfileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ProfileResponse, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ProfileResponse); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ProfileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: ctx, query, page, limit
+func (_m *ProfileService) SearchUsers(ctx context.Context, query string, page int, limit int) (*domain.Pagination[*domain.UserSearchResponse], error) {
+	ret := _m.Called(ctx, query, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 *domain.Pagination[*domain.UserSearchResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*domain.Pagination[*domain.UserSearchResponse], error)); ok {
+		return rf(ctx, query, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *domain.Pagination[*domain.UserSearchResponse]); ok {
+		r0 = rf(ctx, query, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Pagination[*domain.UserSearchResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, query, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProfileService creates a new instance of ProfileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProfileService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProfileService {
+	mock := &ProfileService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
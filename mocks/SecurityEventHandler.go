@@ -0,0 +1,45 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	echo "github.com/labstack/echo/v4"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SecurityEventHandler is an autogenerated mock type for the SecurityEventHandler type
+type SecurityEventHandler struct {
+	mock.Mock
+}
+
+// GetSecurityEvents provides a mock function with given fields: ctx
+func (_m *SecurityEventHandler) GetSecurityEvents(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecurityEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSecurityEventHandler creates a new instance of SecurityEventHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSecurityEventHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SecurityEventHandler {
+	mock := &SecurityEventHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
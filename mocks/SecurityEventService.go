@@ -0,0 +1,94 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// SecurityEventService is an autogenerated mock type for the SecurityEventService type
+type SecurityEventService struct {
+	mock.Mock
+}
+
+// GetSecurityEvents provides a mock function with given fields: ctx, page, limit
+func (_m *SecurityEventService) GetSecurityEvents(ctx context.Context, page int, limit int) (*domain.Pagination[*domain.SecurityEventResponse], error) {
+	ret := _m.Called(ctx, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecurityEvents")
+	}
+
+	var r0 *domain.Pagination[*domain.SecurityEventResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.Pagination[*domain.SecurityEventResponse], error)); ok {
+		return rf(ctx, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.Pagination[*domain.SecurityEventResponse]); ok {
+		r0 = rf(ctx, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Pagination[*domain.SecurityEventResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpiredEvents provides a mock function with given fields: ctx
+func (_m *SecurityEventService) PurgeExpiredEvents(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Record provides a mock function with given fields: ctx, userID, eventType, details
+func (_m *SecurityEventService) Record(ctx context.Context, userID uuid.UUID, eventType domain.SecurityEventType, details string) {
+	_m.Called(ctx, userID, eventType, details)
+}
+
+// NewSecurityEventService creates a new instance of SecurityEventService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSecurityEventService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SecurityEventService {
+	mock := &SecurityEventService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -34,17 +34,17 @@ func (_m *SessionRepository) CreateSession(ctx context.Context, session domain.S
 	return r0
 }
 
-// DeleteSession provides a mock function with given fields: ctx, userId
-func (_m *SessionRepository) DeleteSession(ctx context.Context, userId uuid.UUID) error {
-	ret := _m.Called(ctx, userId)
+// DeleteSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionRepository) DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSession")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,24 +52,102 @@ func (_m *SessionRepository) DeleteSession(ctx context.Context, userId uuid.UUID
 	return r0
 }
 
-// GetSessionByUserID provides a mock function with given fields: ctx, userID
-func (_m *SessionRepository) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*domain.Session, error) {
+// DeleteSessionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepository) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
 	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSessionByUserID")
+		panic("no return value specified for DeleteSessionsByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRefreshToken provides a mock function with given fields: ctx, tokenHash
+func (_m *SessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshToken")
+	}
+
+	var r0 *domain.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.RefreshToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionRepository) GetSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*domain.Session, error) {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSession")
 	}
 
 	var r0 *domain.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Session, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.Session, error)); ok {
+		return rf(ctx, userID, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.Session); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepository) GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionsByUserID")
+	}
+
+	var r0 []*domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.Session, error)); ok {
 		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Session); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.Session); ok {
 		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Session)
+			r0 = ret.Get(0).([]*domain.Session)
 		}
 	}
 
@@ -82,6 +160,42 @@ func (_m *SessionRepository) GetSessionByUserID(ctx context.Context, userID uuid
 	return r0, r1
 }
 
+// RenewSession provides a mock function with given fields: ctx, session
+func (_m *SessionRepository) RenewSession(ctx context.Context, session domain.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSession provides a mock function with given fields: ctx, session
+func (_m *SessionRepository) UpdateSession(ctx context.Context, session domain.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSessionRepository creates a new instance of SessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionRepository(t interface {
@@ -8,6 +8,8 @@ import (
 	domain "github.com/G-Villarinho/social-network/domain"
 	mock "github.com/stretchr/testify/mock"
 
+	secure "github.com/G-Villarinho/social-network/secure"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -17,22 +19,24 @@ type SessionService struct {
 }
 
 // CreateSession provides a mock function with given fields: ctx, user
-func (_m *SessionService) CreateSession(ctx context.Context, user domain.User) (string, error) {
+func (_m *SessionService) CreateSession(ctx context.Context, user domain.User) (*domain.AuthTokens, error) {
 	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
 	}
 
-	var r0 string
+	var r0 *domain.AuthTokens
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (*domain.AuthTokens, error)); ok {
 		return rf(ctx, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) *domain.AuthTokens); ok {
 		r0 = rf(ctx, user)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, domain.User) error); ok {
@@ -44,12 +48,12 @@ func (_m *SessionService) CreateSession(ctx context.Context, user domain.User) (
 	return r0, r1
 }
 
-// DeleteSession provides a mock function with given fields: ctx, userID
-func (_m *SessionService) DeleteSession(ctx context.Context, userID uuid.UUID) error {
+// DeleteAllSessions provides a mock function with given fields: ctx, userID
+func (_m *SessionService) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
 	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSession")
+		panic("no return value specified for DeleteAllSessions")
 	}
 
 	var r0 error
@@ -62,6 +66,54 @@ func (_m *SessionService) DeleteSession(ctx context.Context, userID uuid.UUID) e
 	return r0
 }
 
+// DeleteSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionService) DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJWKS provides a mock function with no fields
+func (_m *SessionService) GetJWKS() (*secure.JSONWebKeySet, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJWKS")
+	}
+
+	var r0 *secure.JSONWebKeySet
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*secure.JSONWebKeySet, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *secure.JSONWebKeySet); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*secure.JSONWebKeySet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSessionByToken provides a mock function with given fields: ctx, token
 func (_m *SessionService) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
 	ret := _m.Called(ctx, token)
@@ -92,6 +144,102 @@ func (_m *SessionService) GetSessionByToken(ctx context.Context, token string) (
 	return r0, r1
 }
 
+// GetSessions provides a mock function with given fields: ctx
+func (_m *SessionService) GetSessions(ctx context.Context) ([]*domain.SessionResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []*domain.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.SessionResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.SessionResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.SessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshSession provides a mock function with given fields: ctx, refreshToken
+func (_m *SessionService) RefreshSession(ctx context.Context, refreshToken string) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSession")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.AuthTokens); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeOtherSessions provides a mock function with given fields: ctx
+func (_m *SessionService) RevokeOtherSessions(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, sessionID
+func (_m *SessionService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSessionService creates a new instance of SessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionService(t interface {
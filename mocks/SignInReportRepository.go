@@ -0,0 +1,77 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SignInReportRepository is an autogenerated mock type for the SignInReportRepository type
+type SignInReportRepository struct {
+	mock.Mock
+}
+
+// ConsumeToken provides a mock function with given fields: ctx, tokenHash
+func (_m *SignInReportRepository) ConsumeToken(ctx context.Context, tokenHash string) (*domain.SignInReport, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeToken")
+	}
+
+	var r0 *domain.SignInReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.SignInReport, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.SignInReport); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SignInReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetToken provides a mock function with given fields: ctx, tokenHash, report
+func (_m *SignInReportRepository) SetToken(ctx context.Context, tokenHash string, report domain.SignInReport) error {
+	ret := _m.Called(ctx, tokenHash, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SignInReport) error); ok {
+		r0 = rf(ctx, tokenHash, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSignInReportRepository creates a new instance of SignInReportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSignInReportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SignInReportRepository {
+	mock := &SignInReportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
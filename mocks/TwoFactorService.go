@@ -0,0 +1,127 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// TwoFactorService is an autogenerated mock type for the TwoFactorService type
+type TwoFactorService struct {
+	mock.Mock
+}
+
+// BeginEnrollment provides a mock function with given fields: ctx
+func (_m *TwoFactorService) BeginEnrollment(ctx context.Context) (*domain.TwoFactorEnrollmentResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginEnrollment")
+	}
+
+	var r0 *domain.TwoFactorEnrollmentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.TwoFactorEnrollmentResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.TwoFactorEnrollmentResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorEnrollmentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmEnrollment provides a mock function with given fields: ctx, payload
+func (_m *TwoFactorService) ConfirmEnrollment(ctx context.Context, payload domain.ConfirmTwoFactorPayload) (*domain.RecoveryCodesResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEnrollment")
+	}
+
+	var r0 *domain.RecoveryCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ConfirmTwoFactorPayload) (*domain.RecoveryCodesResponse, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ConfirmTwoFactorPayload) *domain.RecoveryCodesResponse); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RecoveryCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ConfirmTwoFactorPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTwoFactor provides a mock function with given fields: ctx, payload
+func (_m *TwoFactorService) DisableTwoFactor(ctx context.Context, payload domain.DisableTwoFactorPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTwoFactor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.DisableTwoFactorPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyCode provides a mock function with given fields: ctx, userID, code, recoveryCode
+func (_m *TwoFactorService) VerifyCode(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error {
+	ret := _m.Called(ctx, userID, code, recoveryCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, userID, code, recoveryCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorService {
+	mock := &TwoFactorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -12,6 +12,42 @@ type UserHandler struct {
 	mock.Mock
 }
 
+// BlockUser provides a mock function with given fields: ctx
+func (_m *UserHandler) BlockUser(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx
+func (_m *UserHandler) ChangePassword(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckPasswordStrong provides a mock function with given fields: ctx
 func (_m *UserHandler) CheckPasswordStrong(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -48,6 +84,24 @@ func (_m *UserHandler) CheckUsername(ctx echo.Context) error {
 	return r0
 }
 
+// ConfirmEmail provides a mock function with given fields: ctx
+func (_m *UserHandler) ConfirmEmail(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUser provides a mock function with given fields: ctx
 func (_m *UserHandler) CreateUser(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -84,6 +138,42 @@ func (_m *UserHandler) DeleteUser(ctx echo.Context) error {
 	return r0
 }
 
+// ForgotPassword provides a mock function with given fields: ctx
+func (_m *UserHandler) ForgotPassword(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOIDCAuthorizationURL provides a mock function with given fields: ctx
+func (_m *UserHandler) GetOIDCAuthorizationURL(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOIDCAuthorizationURL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetUser provides a mock function with given fields: ctx
 func (_m *UserHandler) GetUser(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -102,6 +192,60 @@ func (_m *UserHandler) GetUser(ctx echo.Context) error {
 	return r0
 }
 
+// ReportSignIn provides a mock function with given fields: ctx
+func (_m *UserHandler) ReportSignIn(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportSignIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendEmailConfirmation provides a mock function with given fields: ctx
+func (_m *UserHandler) ResendEmailConfirmation(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendEmailConfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx
+func (_m *UserHandler) ResetPassword(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignIn provides a mock function with given fields: ctx
 func (_m *UserHandler) SignIn(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -120,6 +264,24 @@ func (_m *UserHandler) SignIn(ctx echo.Context) error {
 	return r0
 }
 
+// SignInWithOIDC provides a mock function with given fields: ctx
+func (_m *UserHandler) SignInWithOIDC(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignInWithOIDC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignOut provides a mock function with given fields: ctx
 func (_m *UserHandler) SignOut(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -138,6 +300,24 @@ func (_m *UserHandler) SignOut(ctx echo.Context) error {
 	return r0
 }
 
+// UnblockUser provides a mock function with given fields: ctx
+func (_m *UserHandler) UnblockUser(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: ctx
 func (_m *UserHandler) UpdateUser(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -156,6 +336,60 @@ func (_m *UserHandler) UpdateUser(ctx echo.Context) error {
 	return r0
 }
 
+// UpdateUserRole provides a mock function with given fields: ctx
+func (_m *UserHandler) UpdateUserRole(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifySignIn provides a mock function with given fields: ctx
+func (_m *UserHandler) VerifySignIn(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyTwoFactorSignIn provides a mock function with given fields: ctx
+func (_m *UserHandler) VerifyTwoFactorSignIn(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTwoFactorSignIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserHandler creates a new instance of UserHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserHandler(t interface {
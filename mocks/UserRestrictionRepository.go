@@ -0,0 +1,135 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// UserRestrictionRepository is an autogenerated mock type for the UserRestrictionRepository type
+type UserRestrictionRepository struct {
+	mock.Mock
+}
+
+// CreateRestriction provides a mock function with given fields: ctx, restriction
+func (_m *UserRestrictionRepository) CreateRestriction(ctx context.Context, restriction domain.UserRestriction) error {
+	ret := _m.Called(ctx, restriction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRestriction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserRestriction) error); ok {
+		r0 = rf(ctx, restriction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRestriction provides a mock function with given fields: ctx, userID, targetID, restrictionType
+func (_m *UserRestrictionRepository) DeleteRestriction(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, restrictionType domain.RestrictionType) (bool, error) {
+	ret := _m.Called(ctx, userID, targetID, restrictionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRestriction")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.RestrictionType) (bool, error)); ok {
+		return rf(ctx, userID, targetID, restrictionType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.RestrictionType) bool); ok {
+		r0 = rf(ctx, userID, targetID, restrictionType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, domain.RestrictionType) error); ok {
+		r1 = rf(ctx, userID, targetID, restrictionType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRestrictionTypes provides a mock function with given fields: ctx, userID, targetID
+func (_m *UserRestrictionRepository) GetRestrictionTypes(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) ([]domain.RestrictionType, error) {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRestrictionTypes")
+	}
+
+	var r0 []domain.RestrictionType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]domain.RestrictionType, error)); ok {
+		return rf(ctx, userID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []domain.RestrictionType); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RestrictionType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasBlock provides a mock function with given fields: ctx, userID, targetID
+func (_m *UserRestrictionRepository) HasBlock(ctx context.Context, userID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasBlock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserRestrictionRepository creates a new instance of UserRestrictionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserRestrictionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserRestrictionRepository {
+	mock := &UserRestrictionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
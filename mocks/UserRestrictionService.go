@@ -0,0 +1,67 @@
+// Code generated by mockery v2.47.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/G-Villarinho/social-network/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// UserRestrictionService is an autogenerated mock type for the UserRestrictionService type
+type UserRestrictionService struct {
+	mock.Mock
+}
+
+// Restrict provides a mock function with given fields: ctx, targetID, restrictionType
+func (_m *UserRestrictionService) Restrict(ctx context.Context, targetID uuid.UUID, restrictionType domain.RestrictionType) error {
+	ret := _m.Called(ctx, targetID, restrictionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restrict")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.RestrictionType) error); ok {
+		r0 = rf(ctx, targetID, restrictionType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unrestrict provides a mock function with given fields: ctx, targetID, restrictionType
+func (_m *UserRestrictionService) Unrestrict(ctx context.Context, targetID uuid.UUID, restrictionType domain.RestrictionType) error {
+	ret := _m.Called(ctx, targetID, restrictionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unrestrict")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.RestrictionType) error); ok {
+		r0 = rf(ctx, targetID, restrictionType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUserRestrictionService creates a new instance of UserRestrictionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserRestrictionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserRestrictionService {
+	mock := &UserRestrictionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
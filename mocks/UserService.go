@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/G-Villarinho/social-network/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -14,6 +16,42 @@ type UserService struct {
 	mock.Mock
 }
 
+// BlockUser provides a mock function with given fields: ctx, userID, payload
+func (_m *UserService) BlockUser(ctx context.Context, userID uuid.UUID, payload domain.ModerationReasonPayload) error {
+	ret := _m.Called(ctx, userID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ModerationReasonPayload) error); ok {
+		r0 = rf(ctx, userID, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx, payload
+func (_m *UserService) ChangePassword(ctx context.Context, payload domain.ChangePasswordPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChangePasswordPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckUsername provides a mock function with given fields: ctx, payload
 func (_m *UserService) CheckUsername(ctx context.Context, payload domain.CheckUsernamePayload) (*domain.UsernameSuggestionResponse, error) {
 	ret := _m.Called(ctx, payload)
@@ -44,32 +82,40 @@ func (_m *UserService) CheckUsername(ctx context.Context, payload domain.CheckUs
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: ctx, payload
-func (_m *UserService) CreateUser(ctx context.Context, payload domain.UserPayload) (string, error) {
+// ConfirmEmail provides a mock function with given fields: ctx, payload
+func (_m *UserService) ConfirmEmail(ctx context.Context, payload domain.ConfirmEmailPayload) error {
 	ret := _m.Called(ctx, payload)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for ConfirmEmail")
 	}
 
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.UserPayload) (string, error)); ok {
-		return rf(ctx, payload)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.UserPayload) string); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ConfirmEmailPayload) error); ok {
 		r0 = rf(ctx, payload)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.UserPayload) error); ok {
-		r1 = rf(ctx, payload)
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: ctx, payload
+func (_m *UserService) CreateUser(ctx context.Context, payload domain.UserPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserPayload) error); ok {
+		r0 = rf(ctx, payload)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // DeleteUser provides a mock function with given fields: ctx
@@ -90,6 +136,54 @@ func (_m *UserService) DeleteUser(ctx context.Context) error {
 	return r0
 }
 
+// ForgotPassword provides a mock function with given fields: ctx, payload
+func (_m *UserService) ForgotPassword(ctx context.Context, payload domain.ForgotPasswordPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ForgotPasswordPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOIDCAuthorizationURL provides a mock function with given fields: ctx, provider
+func (_m *UserService) GetOIDCAuthorizationURL(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOIDCAuthorizationURL")
+	}
+
+	var r0 *domain.OIDCAuthorizationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.OIDCAuthorizationResponse, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.OIDCAuthorizationResponse); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCAuthorizationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUser provides a mock function with given fields: ctx
 func (_m *UserService) GetUser(ctx context.Context) (*domain.UserResponse, error) {
 	ret := _m.Called(ctx)
@@ -120,23 +214,79 @@ func (_m *UserService) GetUser(ctx context.Context) (*domain.UserResponse, error
 	return r0, r1
 }
 
+// ReportSignIn provides a mock function with given fields: ctx, payload
+func (_m *UserService) ReportSignIn(ctx context.Context, payload domain.ReportSignInPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportSignIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReportSignInPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendEmailConfirmation provides a mock function with given fields: ctx, payload
+func (_m *UserService) ResendEmailConfirmation(ctx context.Context, payload domain.ResendEmailConfirmationPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendEmailConfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ResendEmailConfirmationPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, payload
+func (_m *UserService) ResetPassword(ctx context.Context, payload domain.ResetPasswordPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ResetPasswordPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignIn provides a mock function with given fields: ctx, payload
-func (_m *UserService) SignIn(ctx context.Context, payload domain.SignInPayload) (string, error) {
+func (_m *UserService) SignIn(ctx context.Context, payload domain.SignInPayload) (*domain.SignInResponse, error) {
 	ret := _m.Called(ctx, payload)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SignIn")
 	}
 
-	var r0 string
+	var r0 *domain.SignInResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.SignInPayload) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SignInPayload) (*domain.SignInResponse, error)); ok {
 		return rf(ctx, payload)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.SignInPayload) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SignInPayload) *domain.SignInResponse); ok {
 		r0 = rf(ctx, payload)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SignInResponse)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, domain.SignInPayload) error); ok {
@@ -148,6 +298,36 @@ func (_m *UserService) SignIn(ctx context.Context, payload domain.SignInPayload)
 	return r0, r1
 }
 
+// SignInWithOIDC provides a mock function with given fields: ctx, provider, payload
+func (_m *UserService) SignInWithOIDC(ctx context.Context, provider string, payload domain.OIDCCallbackPayload) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, provider, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignInWithOIDC")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.OIDCCallbackPayload) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, provider, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.OIDCCallbackPayload) *domain.AuthTokens); ok {
+		r0 = rf(ctx, provider, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.OIDCCallbackPayload) error); ok {
+		r1 = rf(ctx, provider, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignOut provides a mock function with given fields: ctx
 func (_m *UserService) SignOut(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -166,6 +346,24 @@ func (_m *UserService) SignOut(ctx context.Context) error {
 	return r0
 }
 
+// UnblockUser provides a mock function with given fields: ctx, userID, payload
+func (_m *UserService) UnblockUser(ctx context.Context, userID uuid.UUID, payload domain.ModerationReasonPayload) error {
+	ret := _m.Called(ctx, userID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ModerationReasonPayload) error); ok {
+		r0 = rf(ctx, userID, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: ctx, payload
 func (_m *UserService) UpdateUser(ctx context.Context, payload domain.UserUpdatePayload) error {
 	ret := _m.Called(ctx, payload)
@@ -184,6 +382,84 @@ func (_m *UserService) UpdateUser(ctx context.Context, payload domain.UserUpdate
 	return r0
 }
 
+// UpdateUserRole provides a mock function with given fields: ctx, userID, payload
+func (_m *UserService) UpdateUserRole(ctx context.Context, userID uuid.UUID, payload domain.UpdateUserRolePayload) error {
+	ret := _m.Called(ctx, userID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.UpdateUserRolePayload) error); ok {
+		r0 = rf(ctx, userID, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifySignIn provides a mock function with given fields: ctx, payload
+func (_m *UserService) VerifySignIn(ctx context.Context, payload domain.VerifySignInPayload) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignIn")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.VerifySignInPayload) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.VerifySignInPayload) *domain.AuthTokens); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.VerifySignInPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactorSignIn provides a mock function with given fields: ctx, payload
+func (_m *UserService) VerifyTwoFactorSignIn(ctx context.Context, payload domain.VerifyTwoFactorSignInPayload) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTwoFactorSignIn")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.VerifyTwoFactorSignInPayload) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.VerifyTwoFactorSignInPayload) *domain.AuthTokens); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.VerifyTwoFactorSignInPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type accountPurgeRepository struct {
+	di          *internal.Di
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+func NewAccountPurgeRepository(di *internal.Di) (domain.AccountPurgeRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountPurgeRepository{
+		di:          di,
+		db:          db,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (a *accountPurgeRepository) GetAccountsDueForPurge(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+
+	if err := a.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("status = ? AND scheduledPurgeAt <= ?", domain.Inactive, before).
+		Limit(limit).
+		Pluck("id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// DeleteLikes removes a batch of the user's likes and takes them off the like
+// counters of the posts they were given to.
+func (a *accountPurgeRepository) DeleteLikes(ctx context.Context, userID uuid.UUID, limit int) (int64, error) {
+	var deleted int64
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var likes []domain.Like
+		if err := tx.Select("id", "postID").
+			Where("userID = ?", userID).
+			Limit(limit).
+			Find(&likes).Error; err != nil {
+			return err
+		}
+
+		if len(likes) == 0 {
+			return nil
+		}
+
+		likeIDs := make([]uuid.UUID, len(likes))
+		for i, like := range likes {
+			likeIDs[i] = like.ID
+
+			if err := tx.Model(&domain.Post{}).
+				Where("id = ? AND likes > 0", like.PostID).
+				Update("likes", gorm.Expr("likes - ?", 1)).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Where("id IN ?", likeIDs).Delete(&domain.Like{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		deleted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// DeletePosts removes a batch of the user's posts, the likes they received go
+// with them through the foreign key.
+func (a *accountPurgeRepository) DeletePosts(ctx context.Context, userID uuid.UUID, limit int) (int64, error) {
+	result := a.db.WithContext(ctx).
+		Where("authorId = ?", userID).
+		Limit(limit).
+		Delete(&domain.Post{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (a *accountPurgeRepository) DeleteFollows(ctx context.Context, userID uuid.UUID, limit int) (int64, error) {
+	result := a.db.WithContext(ctx).
+		Where("userId = ? OR followerId = ?", userID, userID).
+		Limit(limit).
+		Delete(&domain.Follower{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteCachedKeys drops every Redis key kept for the user outside of sessions.
+func (a *accountPurgeRepository) DeleteCachedKeys(ctx context.Context, userID uuid.UUID) error {
+	keys := []string{
+		getAccountStatusKey(userID),
+		getEmailConfirmationKey(userID),
+		getEmailConfirmationResendKey(userID),
+		getPasswordResetUserKey(userID),
+		getAccountFailuresKey(userID),
+		getAccountLockKey(userID),
+		getProfileCountsCacheKey(userID),
+	}
+
+	passwordResetTokenHash, err := a.redisClient.Get(ctx, getPasswordResetUserKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	if passwordResetTokenHash != "" {
+		keys = append(keys, getPasswordResetKey(passwordResetTokenHash))
+	}
+
+	if err := a.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return err
+	}
+
+	for _, pattern := range []string{
+		fmt.Sprintf("user:%s:feed:*", userID.String()),
+		fmt.Sprintf("like:*:%s", userID.String()),
+	} {
+		if err := a.deleteKeysMatching(ctx, pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *accountPurgeRepository) deleteKeysMatching(ctx context.Context, pattern string) error {
+	iter := a.redisClient.Scan(ctx, 0, pattern, 100).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 100 {
+			if err := a.redisClient.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		if err := a.redisClient.Del(ctx, keys...).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type accountStatusRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewAccountStatusRepository(di *internal.Di) (domain.AccountStatusRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountStatusRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (a *accountStatusRepository) SetStatus(ctx context.Context, userID uuid.UUID, status domain.UserStatus) error {
+	if status == domain.Active {
+		if err := a.redisClient.Del(ctx, getAccountStatusKey(userID)).Err(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := a.redisClient.Set(ctx, getAccountStatusKey(userID), string(status), 0).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *accountStatusRepository) GetStatus(ctx context.Context, userID uuid.UUID) (domain.UserStatus, error) {
+	status, err := a.redisClient.Get(ctx, getAccountStatusKey(userID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return domain.Active, nil
+		}
+		return "", err
+	}
+
+	return domain.UserStatus(status), nil
+}
+
+func getAccountStatusKey(userID uuid.UUID) string {
+	return fmt.Sprintf("account_status:%s", userID.String())
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type dataExportRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewDataExportRepository(di *internal.Di) (domain.DataExportRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataExportRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (d *dataExportRepository) CreateExport(ctx context.Context, export domain.DataExport) (*domain.DataExport, error) {
+	if err := d.db.WithContext(ctx).
+		Create(&export).Error; err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (d *dataExportRepository) GetExportByID(ctx context.Context, ID uuid.UUID) (*domain.DataExport, error) {
+	return d.first(d.db.WithContext(ctx).Where("id = ?", ID))
+}
+
+func (d *dataExportRepository) GetPendingExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	return d.first(d.db.WithContext(ctx).Where("userId = ? AND status = ?", userID, domain.DataExportPending))
+}
+
+func (d *dataExportRepository) GetExportByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	return d.first(d.db.WithContext(ctx).Where("tokenHash = ?", tokenHash))
+}
+
+func (d *dataExportRepository) GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*domain.DataExport, error) {
+	var exports []*domain.DataExport
+
+	if err := d.db.WithContext(ctx).
+		Where("status = ? AND expiresAt <= ?", domain.DataExportReady, before).
+		Limit(limit).
+		Find(&exports).Error; err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (d *dataExportRepository) GetExportsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.DataExport, error) {
+	var exports []*domain.DataExport
+
+	if err := d.db.WithContext(ctx).
+		Where("userId = ?", userID).
+		Find(&exports).Error; err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (d *dataExportRepository) UpdateExport(ctx context.Context, export domain.DataExport) error {
+	if err := d.db.WithContext(ctx).
+		Save(&export).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *dataExportRepository) first(query *gorm.DB) (*domain.DataExport, error) {
+	var export domain.DataExport
+
+	if err := query.First(&export).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &export, nil
+}
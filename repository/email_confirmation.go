@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type emailConfirmationRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewEmailConfirmationRepository(di *internal.Di) (domain.EmailConfirmationRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailConfirmationRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (e *emailConfirmationRepository) SetTokenID(ctx context.Context, userID uuid.UUID, tokenID string) error {
+	if err := e.redisClient.Set(ctx, getEmailConfirmationKey(userID), tokenID, time.Duration(config.Env.Cache.EmailConfirmationExp)*time.Hour).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *emailConfirmationRepository) GetTokenID(ctx context.Context, userID uuid.UUID) (string, error) {
+	tokenID, err := e.redisClient.Get(ctx, getEmailConfirmationKey(userID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return tokenID, nil
+}
+
+func (e *emailConfirmationRepository) DeleteTokenID(ctx context.Context, userID uuid.UUID) error {
+	if err := e.redisClient.Del(ctx, getEmailConfirmationKey(userID)).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *emailConfirmationRepository) IncrementResendAttempts(ctx context.Context, userID uuid.UUID) (int64, error) {
+	key := getEmailConfirmationResendKey(userID)
+
+	attempts, err := e.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if attempts == 1 {
+		if err := e.redisClient.Expire(ctx, key, domain.EmailConfirmationResendWindow*time.Minute).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return attempts, nil
+}
+
+func getEmailConfirmationKey(userID uuid.UUID) string {
+	return fmt.Sprintf("email_confirmation:%s", userID.String())
+}
+
+func getEmailConfirmationResendKey(userID uuid.UUID) string {
+	return fmt.Sprintf("email_confirmation:%s:resends", userID.String())
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"gorm.io/gorm"
+)
+
+type externalIdentityRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepository(di *internal.Di) (domain.ExternalIdentityRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalIdentityRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (e *externalIdentityRepository) CreateIdentity(ctx context.Context, identity domain.ExternalIdentity) error {
+	if err := e.db.WithContext(ctx).
+		Create(&identity).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *externalIdentityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*domain.ExternalIdentity, error) {
+	var identity domain.ExternalIdentity
+
+	if err := e.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &identity, nil
+}
@@ -66,7 +66,7 @@ func (f *followerRepository) GetFollower(ctx context.Context, userID uuid.UUID,
 func (f *followerRepository) GetFollowers(ctx context.Context, userID uuid.UUID) ([]*domain.Follower, error) {
 	var followers []*domain.Follower
 
-	if err := f.db.WithContext(ctx).Preload("Follower").Where("userId = ?", userID).Find(&followers).Error; err != nil {
+	if err := f.db.WithContext(ctx).Preload("Follower").Where("userId = ? AND followerId NOT IN (?)", userID, f.deactivatedUsers()).Find(&followers).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -80,7 +80,7 @@ func (f *followerRepository) GetFollowers(ctx context.Context, userID uuid.UUID)
 func (f *followerRepository) GetFollowings(ctx context.Context, userID uuid.UUID) ([]*domain.Follower, error) {
 	var followings []*domain.Follower
 
-	if err := f.db.WithContext(ctx).Preload("User").Where("followerId = ?", userID).Find(&followings).Error; err != nil {
+	if err := f.db.WithContext(ctx).Preload("User").Where("followerId = ? AND userId NOT IN (?)", userID, f.deactivatedUsers()).Find(&followings).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -90,3 +90,9 @@ func (f *followerRepository) GetFollowings(ctx context.Context, userID uuid.UUID
 
 	return followings, nil
 }
+
+// deactivatedUsers selects accounts whose profile is hidden: blocked accounts
+// and accounts waiting to be purged.
+func (f *followerRepository) deactivatedUsers() *gorm.DB {
+	return f.db.Table("User").Select("id").Where("status IN ?", []domain.UserStatus{domain.Block, domain.Inactive})
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFollowers_ShouldLeaveOutBlockedAndDeactivatedAccounts(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	repository := &followerRepository{db: db}
+	userID := uuid.MustParse("7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e")
+
+	_, err := repository.GetFollowers(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Contains(t, *statements, "SELECT * FROM `Follower` WHERE userId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND followerId NOT IN (SELECT id FROM `User` WHERE status IN ('block','inactive'))")
+}
+
+func TestGetFollowings_ShouldLeaveOutBlockedAndDeactivatedAccounts(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	repository := &followerRepository{db: db}
+	userID := uuid.MustParse("7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e")
+
+	_, err := repository.GetFollowings(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Contains(t, *statements, "SELECT * FROM `Follower` WHERE followerId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND userId NOT IN (SELECT id FROM `User` WHERE status IN ('block','inactive'))")
+}
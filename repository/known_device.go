@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type knownDeviceRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewKnownDeviceRepository(di *internal.Di) (domain.KnownDeviceRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &knownDeviceRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (k *knownDeviceRepository) CreateDevice(ctx context.Context, device domain.KnownDevice) error {
+	if err := k.db.WithContext(ctx).
+		Create(&device).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (k *knownDeviceRepository) GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*domain.KnownDevice, error) {
+	var device domain.KnownDevice
+
+	if err := k.db.WithContext(ctx).
+		Where("userId = ? AND fingerprint = ?", userID, fingerprint).
+		First(&device).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func (k *knownDeviceRepository) HasDevices(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := k.db.WithContext(ctx).
+		Model(&domain.KnownDevice{}).
+		Where("userId = ?", userID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (k *knownDeviceRepository) UpdateLastSeen(ctx context.Context, ID uuid.UUID) error {
+	if err := k.db.WithContext(ctx).
+		Model(&domain.KnownDevice{}).
+		Where("id = ?", ID).
+		Update("lastSeenAt", time.Now().UTC()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (k *knownDeviceRepository) DeleteDevice(ctx context.Context, userID uuid.UUID, fingerprint string) error {
+	if err := k.db.WithContext(ctx).
+		Where("userId = ? AND fingerprint = ?", userID, fingerprint).
+		Delete(&domain.KnownDevice{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
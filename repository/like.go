@@ -104,6 +104,18 @@ func (l *likeRepository) GetLikedPostIDs(ctx context.Context, userID uuid.UUID)
 	return likedPostIDs, nil
 }
 
+func (l *likeRepository) GetLikesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Like, error) {
+	var likes []*domain.Like
+	if err := l.db.WithContext(ctx).
+		Where("userID = ?", userID).
+		Order("createdAt desc").
+		Find(&likes).Error; err != nil {
+		return nil, err
+	}
+
+	return likes, nil
+}
+
 func (l *likeRepository) DeleteLike(ctx context.Context, like domain.Like) error {
 	tx := l.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
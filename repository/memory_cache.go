@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/G-Villarinho/social-network/config"
@@ -79,6 +80,17 @@ func (m *memoryCacheRepository) GetPosts(ctx context.Context, userID uuid.UUID,
 	return posts, nil
 }
 
+// InvalidateFeeds drops every cached feed page, for changes that affect what
+// all users are allowed to see.
+func (m *memoryCacheRepository) InvalidateFeeds(ctx context.Context) error {
+	return m.deleteKeys(ctx, "user:*:feed:*")
+}
+
+// InvalidateUserFeed drops the cached feed pages of a single user.
+func (m *memoryCacheRepository) InvalidateUserFeed(ctx context.Context, userID uuid.UUID) error {
+	return m.deleteKeys(ctx, fmt.Sprintf("user:%s:feed:*", userID))
+}
+
 func (m *memoryCacheRepository) GetCachedLikes(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (*domain.LikeCache, error) {
 	likeCache := new(domain.LikeCache)
 
@@ -113,6 +125,104 @@ func (m *memoryCacheRepository) SetLikesByPostIDs(ctx context.Context, userID uu
 	return nil
 }
 
+func (m *memoryCacheRepository) GetProfileCounts(ctx context.Context, userID uuid.UUID) (*domain.ProfileCounts, error) {
+	values, err := m.redisClient.HMGet(ctx, getProfileCountsCacheKey(userID),
+		string(domain.ProfileCountFollowers),
+		string(domain.ProfileCountFollowings),
+		string(domain.ProfileCountPosts),
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int64, len(values))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			return nil, nil
+		}
+
+		count, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[i] = count
+	}
+
+	return &domain.ProfileCounts{
+		Followers:  counts[0],
+		Followings: counts[1],
+		Posts:      counts[2],
+	}, nil
+}
+
+func (m *memoryCacheRepository) SetProfileCounts(ctx context.Context, userID uuid.UUID, counts domain.ProfileCounts) error {
+	key := getProfileCountsCacheKey(userID)
+
+	_, err := m.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			string(domain.ProfileCountFollowers), counts.Followers,
+			string(domain.ProfileCountFollowings), counts.Followings,
+			string(domain.ProfileCountPosts), counts.Posts,
+		)
+		pipe.Expire(ctx, key, time.Duration(config.Env.Cache.CacheExp)*time.Minute)
+		return nil
+	})
+
+	return err
+}
+
+// incrementIfCachedScript only touches counts that are already cached, so a
+// change never creates a partial hash that would be read as the full counts.
+var incrementIfCachedScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HINCRBY", KEYS[1], ARGV[1], ARGV[2])
+end
+return 0
+`)
+
+func (m *memoryCacheRepository) IncrementProfileCount(ctx context.Context, userID uuid.UUID, count domain.ProfileCount, delta int64) error {
+	if err := incrementIfCachedScript.Run(ctx, m.redisClient, []string{getProfileCountsCacheKey(userID)}, string(count), delta).Err(); err != nil && err != redis.Nil {
+		return err
+	}
+
+	return nil
+}
+
+// InvalidateProfileCounts drops every cached profile count, for account status
+// changes that hide or reveal a user in other people's follower counts.
+func (m *memoryCacheRepository) InvalidateProfileCounts(ctx context.Context) error {
+	return m.deleteKeys(ctx, "user:*:counts")
+}
+
+func (m *memoryCacheRepository) deleteKeys(ctx context.Context, pattern string) error {
+	iter := m.redisClient.Scan(ctx, 0, pattern, 100).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 100 {
+			if err := m.redisClient.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		if err := m.redisClient.Del(ctx, keys...).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getLikeCacheKey(postID uuid.UUID, userID uuid.UUID) string {
 	return fmt.Sprintf("like:%s:%s", postID.String(), userID.String())
 }
@@ -120,3 +230,7 @@ func getLikeCacheKey(postID uuid.UUID, userID uuid.UUID) string {
 func getPostCacheKey(userID uuid.UUID, page, limit int) string {
 	return fmt.Sprintf("user:%s:feed:page:%d:limit:%d", userID, page, limit)
 }
+
+func getProfileCountsCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user:%s:counts", userID)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type moderationRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewModerationRepository(di *internal.Di) (domain.ModerationRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderationRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (m *moderationRepository) UpdateUser(ctx context.Context, action domain.ModerationAction, user domain.User) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&action).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(&user).Error
+	})
+}
+
+func (m *moderationRepository) DeletePost(ctx context.Context, action domain.ModerationAction, postID uuid.UUID) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&action).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", postID).Delete(&domain.Post{}).Error
+	})
+}
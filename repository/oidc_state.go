@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type oidcStateRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewOIDCStateRepository(di *internal.Di) (domain.OIDCStateRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidcStateRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (o *oidcStateRepository) SetState(ctx context.Context, stateHash string, state domain.OIDCAuthState) error {
+	JSON, err := jsoniter.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := o.redisClient.Set(ctx, getOIDCStateKey(stateHash), JSON, time.Duration(config.Env.OIDC.StateExp)*time.Minute).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *oidcStateRepository) ConsumeState(ctx context.Context, stateHash string) (*domain.OIDCAuthState, error) {
+	JSON, err := o.redisClient.GetDel(ctx, getOIDCStateKey(stateHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var state domain.OIDCAuthState
+	if err := jsoniter.UnmarshalFromString(JSON, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func getOIDCStateKey(stateHash string) string {
+	return fmt.Sprintf("oidc_state:%s", stateHash)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type otpRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewOTPRepository(di *internal.Di) (domain.OTPRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &otpRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (o *otpRepository) SetHash(ctx context.Context, hash string, userID uuid.UUID) error {
+	if err := o.redisClient.Set(ctx, getOTPHashKey(hash), userID.String(), time.Duration(config.Env.Cache.Hash2FADuration)*time.Minute).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpRepository) GetUserIDByHash(ctx context.Context, hash string) (uuid.UUID, error) {
+	userID, err := o.redisClient.Get(ctx, getOTPHashKey(hash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(userID)
+}
+
+func (o *otpRepository) DeleteHash(ctx context.Context, hash string) error {
+	if err := o.redisClient.Del(ctx, getOTPHashKey(hash), getOTPFailuresKey(hash)).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpRepository) SetCode(ctx context.Context, hash string, code string) error {
+	if err := o.redisClient.Set(ctx, getOTPCodeKey(hash), code, time.Duration(config.Env.Cache.Code2FADuration)*time.Minute).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpRepository) GetCode(ctx context.Context, hash string) (string, error) {
+	code, err := o.redisClient.Get(ctx, getOTPCodeKey(hash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return code, nil
+}
+
+func (o *otpRepository) DeleteCode(ctx context.Context, hash string) error {
+	if err := o.redisClient.Del(ctx, getOTPCodeKey(hash)).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IncrementCodeFailures counts the wrong codes sent for a sign-in request. The
+// counter lives as long as the request itself.
+func (o *otpRepository) IncrementCodeFailures(ctx context.Context, hash string) (int64, error) {
+	key := getOTPFailuresKey(hash)
+
+	failures, err := o.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if failures == 1 {
+		if err := o.redisClient.Expire(ctx, key, time.Duration(config.Env.Cache.Hash2FADuration)*time.Minute).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return failures, nil
+}
+
+func getOTPHashKey(hash string) string {
+	return fmt.Sprintf("2fa:hash:%s", hash)
+}
+
+func getOTPCodeKey(hash string) string {
+	return fmt.Sprintf("2fa:code:%s", hash)
+}
+
+func getOTPFailuresKey(hash string) string {
+	return fmt.Sprintf("2fa:failures:%s", hash)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type passwordResetRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewPasswordResetRepository(di *internal.Di) (domain.PasswordResetRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &passwordResetRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (p *passwordResetRepository) SetToken(ctx context.Context, tokenHash string, userID uuid.UUID) error {
+	expiration := time.Duration(config.Env.Cache.PasswordResetExp) * time.Minute
+	userKey := getPasswordResetUserKey(userID)
+
+	previousTokenHash, err := p.redisClient.Get(ctx, userKey).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := p.redisClient.TxPipeline()
+	if previousTokenHash != "" {
+		pipe.Del(ctx, getPasswordResetKey(previousTokenHash))
+	}
+	pipe.Set(ctx, getPasswordResetKey(tokenHash), userID.String(), expiration)
+	pipe.Set(ctx, userKey, tokenHash, expiration)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *passwordResetRepository) ConsumeToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, err := p.redisClient.GetDel(ctx, getPasswordResetKey(tokenHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, err
+	}
+
+	ID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := p.redisClient.Del(ctx, getPasswordResetUserKey(ID)).Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	return ID, nil
+}
+
+func getPasswordResetKey(tokenHash string) string {
+	return fmt.Sprintf("password_reset:%s", tokenHash)
+}
+
+func getPasswordResetUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("password_reset:user:%s", userID.String())
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type personalAccessTokenRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(di *internal.Di) (domain.PersonalAccessTokenRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &personalAccessTokenRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (p *personalAccessTokenRepository) CreateToken(ctx context.Context, token domain.PersonalAccessToken) error {
+	if err := p.db.WithContext(ctx).
+		Create(&token).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *personalAccessTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+
+	if err := p.db.WithContext(ctx).
+		Preload("User").
+		Where("tokenHash = ?", tokenHash).
+		First(&token).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (p *personalAccessTokenRepository) GetTokensByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	var tokens []*domain.PersonalAccessToken
+
+	if err := p.db.WithContext(ctx).
+		Where("userId = ?", userID).
+		Order("createdAt desc").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (p *personalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error {
+	if err := p.db.WithContext(ctx).
+		Model(&domain.PersonalAccessToken{}).
+		Where("id = ?", tokenID).
+		Update("lastUsedAt", lastUsedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *personalAccessTokenRepository) DeleteToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) (bool, error) {
+	result := p.db.WithContext(ctx).
+		Where("id = ? AND userId = ?", tokenID, userID).
+		Delete(&domain.PersonalAccessToken{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -52,7 +52,8 @@ func (p *postRepository) GetPaginatedPosts(ctx context.Context, userID uuid.UUID
 		Sort:  "createdAt desc",
 	}
 
-	subQuery := p.db.Table("Follower").Select("userId").Where("followerId = ?", userID)
+	mutedAuthors := p.db.Table("UserRestriction").Select("targetId").Where("userId = ? AND type = ?", userID, domain.RestrictionMute)
+	subQuery := p.db.Table("Follower").Select("userId").Where("followerId = ? AND userId NOT IN (?) AND userId NOT IN (?)", userID, p.hiddenAuthors(), mutedAuthors)
 
 	paginatedPosts, err := paginate(pagination,
 		p.db.WithContext(ctx).
@@ -75,6 +76,25 @@ func (p *postRepository) GetPostById(ctx context.Context, ID uuid.UUID, preload
 	}
 
 	if err := query.
+		Where("id = ? AND authorId NOT IN (?)", ID, p.hiddenAuthors()).
+		First(&post).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// GetPostByIdIncludingHidden also finds posts of blocked and deactivated
+// authors, which readers no longer see but moderators still have to remove.
+func (p *postRepository) GetPostByIdIncludingHidden(ctx context.Context, ID uuid.UUID) (*domain.Post, error) {
+	var post domain.Post
+
+	if err := p.db.WithContext(ctx).
 		Where("id = ?", ID).
 		First(&post).Error; err != nil {
 
@@ -114,7 +134,7 @@ func (p *postRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*
 
 	if err := p.db.WithContext(ctx).
 		Preload("Author").
-		Where("authorId = ?", userID).
+		Where("authorId = ? AND authorId NOT IN (?)", userID, p.hiddenAuthors()).
 		Find(&posts).Error; err != nil {
 
 		if err == gorm.ErrRecordNotFound {
@@ -150,3 +170,9 @@ func (p *postRepository) UnlikePost(ctx context.Context, ID uuid.UUID, userID uu
 
 	return tx.Commit().Error
 }
+
+// hiddenAuthors selects the users whose posts must not be shown: blocked
+// accounts and accounts deactivated or waiting to be purged.
+func (p *postRepository) hiddenAuthors() *gorm.DB {
+	return p.db.Table("User").Select("id").Where("status IN ?", []domain.UserStatus{domain.Block, domain.Inactive})
+}
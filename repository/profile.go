@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type profileRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewProfileRepository(di *internal.Di) (domain.ProfileRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profileRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+// GetProfileCounts counts followers and followings the same way the follower
+// lists do, leaving out blocked and deactivated accounts.
+func (p *profileRepository) GetProfileCounts(ctx context.Context, userID uuid.UUID) (*domain.ProfileCounts, error) {
+	var counts domain.ProfileCounts
+
+	if err := p.db.WithContext(ctx).
+		Model(&domain.Follower{}).
+		Where("userId = ? AND followerId NOT IN (?)", userID, p.deactivatedUsers()).
+		Count(&counts.Followers).Error; err != nil {
+		return nil, err
+	}
+
+	if err := p.db.WithContext(ctx).
+		Model(&domain.Follower{}).
+		Where("followerId = ? AND userId NOT IN (?)", userID, p.deactivatedUsers()).
+		Count(&counts.Followings).Error; err != nil {
+		return nil, err
+	}
+
+	if err := p.db.WithContext(ctx).
+		Model(&domain.Post{}).
+		Where("authorId = ?", userID).
+		Count(&counts.Posts).Error; err != nil {
+		return nil, err
+	}
+
+	return &counts, nil
+}
+
+// SearchUsers matches the query against the FULLTEXT index on username, first
+// and last name. The index uses the ngram parser, so a query matches names
+// sharing parts of it, which covers prefixes and small typos alike. The
+// username prefix match keeps one character queries, shorter than an ngram,
+// working. Both matches run as separate branches of a UNION so that each one
+// uses its own index. Results are ranked by exact username or full name
+// match, then by whether the viewer follows the user, then by follower count,
+// counted the same way as GetProfileCounts.
+func (p *profileRepository) SearchUsers(ctx context.Context, viewerID uuid.UUID, query string, page, limit int) (*domain.Pagination[*domain.UserSearchResult], error) {
+	pagination := &domain.Pagination[*domain.UserSearchResult]{
+		Limit: limit,
+		Page:  page,
+	}
+
+	candidates := p.db.Raw("(?) UNION ALL (?)",
+		p.db.Table("User").
+			Select("id, MATCH (username, firstName, lastName) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance", query).
+			Where("MATCH (username, firstName, lastName) AGAINST (? IN NATURAL LANGUAGE MODE)", query),
+		p.db.Table("User").
+			Select("id, 0 AS relevance").
+			Where("username LIKE ?", escapeLike(query)+"%"),
+	)
+
+	matches := p.db.Table("(?) AS candidates", candidates).
+		Select("id, MAX(relevance) AS relevance").
+		Group("id")
+
+	followerCounts := p.db.Table("Follower").
+		Select("userId, COUNT(*) AS followers").
+		Where("userId IN (?)", p.db.Table("(?) AS candidates", candidates).Select("id")).
+		Where("followerId NOT IN (?)", p.deactivatedUsers()).
+		Group("userId")
+
+	db := p.db.WithContext(ctx).
+		Table("(?) AS matches", matches).
+		Joins("JOIN User ON User.id = matches.id").
+		Where("User.status = ?", domain.Active).
+		Where("User.id NOT IN (?)", p.db.Table("UserRestriction").Select("targetId").Where("userId = ? AND type = ?", viewerID, domain.RestrictionBlock)).
+		Where("User.id NOT IN (?)", p.db.Table("UserRestriction").Select("userId").Where("targetId = ? AND type = ?", viewerID, domain.RestrictionBlock)).
+		Session(&gorm.Session{})
+
+	if err := db.Count(&pagination.TotalRows).Error; err != nil {
+		return nil, fmt.Errorf("error to count users matching the search: %w", err)
+	}
+
+	pagination.TotalPages = int(math.Ceil(float64(pagination.TotalRows) / float64(pagination.GetLimit())))
+
+	if err := db.
+		Select(
+			"User.*, matches.relevance, "+
+				"(User.username = ? OR CONCAT(User.firstName, ' ', User.lastName) = ?) AS exactMatch, "+
+				"EXISTS (SELECT 1 FROM Follower WHERE Follower.userId = User.id AND Follower.followerId = ?) AS youFollow, "+
+				"COALESCE(followerCounts.followers, 0) AS followers",
+			query, query, viewerID,
+		).
+		Joins("LEFT JOIN (?) AS followerCounts ON followerCounts.userId = User.id", followerCounts).
+		Order("exactMatch DESC, youFollow DESC, followers DESC, matches.relevance DESC, User.username").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Find(&pagination.Rows).Error; err != nil {
+		return nil, fmt.Errorf("error to search users: %w", err)
+	}
+
+	return pagination, nil
+}
+
+// deactivatedUsers selects the accounts left out of follower counts and
+// search, matching the follower lists.
+func (p *profileRepository) deactivatedUsers() *gorm.DB {
+	return p.db.Table("User").Select("id").Where("status IN ?", []domain.UserStatus{domain.Block, domain.Inactive})
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes the wildcards in value match literally in a LIKE pattern.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProfileCounts_ShouldLeaveOutBlockedAndDeactivatedAccounts(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	repository := &profileRepository{db: db}
+	userID := uuid.MustParse("7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e")
+
+	_, err := repository.GetProfileCounts(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Contains(t, *statements, "SELECT count(*) FROM `Follower` WHERE userId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND followerId NOT IN (SELECT id FROM `User` WHERE status IN ('block','inactive'))")
+	assert.Contains(t, *statements, "SELECT count(*) FROM `Follower` WHERE followerId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND userId NOT IN (SELECT id FROM `User` WHERE status IN ('block','inactive'))")
+}
+
+func TestSearchUsers_ShouldBuildRankedUnionOfMatches(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	repository := &profileRepository{db: db}
+	viewerID := uuid.MustParse("7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e")
+
+	_, err := repository.SearchUsers(context.Background(), viewerID, "50%_off", 2, 10)
+
+	require.NoError(t, err)
+
+	candidates := "(SELECT id, MATCH (username, firstName, lastName) AGAINST ('50%_off' IN NATURAL LANGUAGE MODE) AS relevance FROM `User` WHERE MATCH (username, firstName, lastName) AGAINST ('50%_off' IN NATURAL LANGUAGE MODE)) " +
+		"UNION ALL (SELECT id, 0 AS relevance FROM `User` WHERE username LIKE '50\\%\\_off%')"
+	visibleUsers := "WHERE User.status = 'active' " +
+		"AND User.id NOT IN (SELECT targetId FROM `UserRestriction` WHERE userId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND type = 'block') " +
+		"AND User.id NOT IN (SELECT userId FROM `UserRestriction` WHERE targetId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e' AND type = 'block')"
+
+	count := findStatement(t, *statements, "SELECT count(*)")
+	assert.Contains(t, count, candidates)
+	assert.True(t, strings.HasSuffix(count, visibleUsers), count)
+
+	search := findStatement(t, *statements, "SELECT User.*")
+	assert.Contains(t, search, candidates)
+	assert.Contains(t, search, "(User.username = '50%_off' OR CONCAT(User.firstName, ' ', User.lastName) = '50%_off') AS exactMatch")
+	assert.Contains(t, search, "EXISTS (SELECT 1 FROM Follower WHERE Follower.userId = User.id AND Follower.followerId = '7f1c1b4e-64a7-4c36-9d0e-1f2a3b4c5d6e') AS youFollow")
+	assert.Contains(t, search, "followerId NOT IN (SELECT id FROM `User` WHERE status IN ('block','inactive'))")
+	assert.True(t, strings.HasSuffix(search, visibleUsers+" ORDER BY exactMatch DESC, youFollow DESC, followers DESC, matches.relevance DESC, User.username LIMIT 10 OFFSET 10"), search)
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "john", expected: "john"},
+		{value: "50%", expected: `50\%`},
+		{value: "john_doe", expected: `john\_doe`},
+		{value: `back\slash`, expected: `back\\slash`},
+		{value: `%_\`, expected: `\%\_\\`},
+		{value: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeLike(tt.value))
+		})
+	}
+}
+
+// findStatement returns the first captured statement starting with prefix.
+func findStatement(t *testing.T, statements []string, prefix string) string {
+	for _, statement := range statements {
+		if strings.HasPrefix(statement, prefix) {
+			return statement
+		}
+	}
+
+	t.Fatalf("no statement starting with %q in %v", prefix, statements)
+	return ""
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type securityEventRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewSecurityEventRepository(di *internal.Di) (domain.SecurityEventRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &securityEventRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (s *securityEventRepository) CreateEvent(ctx context.Context, event domain.SecurityEvent) error {
+	if err := s.db.WithContext(ctx).
+		Create(&event).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *securityEventRepository) GetEvents(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.Pagination[*domain.SecurityEvent], error) {
+	pagination := &domain.Pagination[*domain.SecurityEvent]{
+		Limit: limit,
+		Page:  page,
+		Sort:  "createdAt desc",
+	}
+
+	events, err := paginate(pagination, s.db.WithContext(ctx).Where("userId = ?", userID))
+	if err != nil {
+		return nil, fmt.Errorf("error to get paginated security events in repository: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *securityEventRepository) DeleteEventsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("createdAt < ?", before).
+		Limit(limit).
+		Delete(&domain.SecurityEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
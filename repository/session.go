@@ -45,15 +45,84 @@ func (s *sessionRepository) CreateSession(ctx context.Context, session domain.Se
 		return err
 	}
 
-	if err := s.redisClient.Set(ctx, s.getSessionKey(session.UserID.String()), sessionJSON, time.Duration(config.Env.Cache.SessionExp)*time.Hour).Err(); err != nil {
+	refreshTokenJSON, err := s.getRefreshTokenJSON(session)
+	if err != nil {
+		return err
+	}
+
+	expiration := time.Duration(config.Env.Cache.SessionExp) * time.Hour
+	indexKey := s.getUserSessionsKey(session.UserID)
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, s.getSessionKey(session.UserID.String(), session.ID.String()), sessionJSON, expiration)
+	pipe.Set(ctx, s.getRefreshTokenKey(session.RefreshTokenHash), refreshTokenJSON, expiration)
+	pipe.SAdd(ctx, indexKey, session.ID.String())
+	pipe.Expire(ctx, indexKey, expiration)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepository) RenewSession(ctx context.Context, session domain.Session) error {
+	sessionJSON, err := jsoniter.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	refreshTokenJSON, err := s.getRefreshTokenJSON(session)
+	if err != nil {
+		return err
+	}
+
+	expiration := time.Duration(config.Env.Cache.SessionExp) * time.Hour
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.SetXX(ctx, s.getSessionKey(session.UserID.String(), session.ID.String()), sessionJSON, expiration)
+	pipe.Set(ctx, s.getRefreshTokenKey(session.RefreshTokenHash), refreshTokenJSON, expiration)
+	pipe.Expire(ctx, s.getUserSessionsKey(session.UserID), expiration)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	refreshTokenJSON, err := s.redisClient.Get(ctx, s.getRefreshTokenKey(tokenHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var refreshToken domain.RefreshToken
+	if err := jsoniter.UnmarshalFromString(refreshTokenJSON, &refreshToken); err != nil {
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+func (s *sessionRepository) UpdateSession(ctx context.Context, session domain.Session) error {
+	sessionJSON, err := jsoniter.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := s.redisClient.SetXX(ctx, s.getSessionKey(session.UserID.String(), session.ID.String()), sessionJSON, redis.KeepTTL).Err(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *sessionRepository) GetSessionByUserID(ctx context.Context, userID uuid.UUID) (*domain.Session, error) {
-	sessionJSON, err := s.redisClient.Get(ctx, s.getSessionKey(userID.String())).Result()
+func (s *sessionRepository) GetSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*domain.Session, error) {
+	sessionJSON, err := s.redisClient.Get(ctx, s.getSessionKey(userID.String(), sessionID.String())).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, nil
@@ -69,14 +138,99 @@ func (s *sessionRepository) GetSessionByUserID(ctx context.Context, userID uuid.
 	return &session, nil
 }
 
-func (s *sessionRepository) DeleteSession(ctx context.Context, userID uuid.UUID) error {
-	if err := s.redisClient.Del(ctx, s.getSessionKey(userID.String())).Err(); err != nil {
+func (s *sessionRepository) GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	sessionIDs, err := s.redisClient.SMembers(ctx, s.getUserSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		keys[i] = s.getSessionKey(userID.String(), sessionID)
+	}
+
+	values, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*domain.Session
+	var expiredSessionIDs []any
+	for i, value := range values {
+		sessionJSON, ok := value.(string)
+		if !ok {
+			expiredSessionIDs = append(expiredSessionIDs, sessionIDs[i])
+			continue
+		}
+
+		var session domain.Session
+		if err := jsoniter.UnmarshalFromString(sessionJSON, &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expiredSessionIDs) > 0 {
+		if err := s.redisClient.SRem(ctx, s.getUserSessionsKey(userID), expiredSessionIDs...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionRepository) DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, s.getSessionKey(userID.String(), sessionID.String()))
+	pipe.SRem(ctx, s.getUserSessionsKey(userID), sessionID.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepository) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
+	indexKey := s.getUserSessionsKey(userID)
+
+	sessionIDs, err := s.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, s.getSessionKey(userID.String(), sessionID))
+	}
+	keys = append(keys, indexKey)
+
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *sessionRepository) getSessionKey(userID string) string {
-	return fmt.Sprintf("session_%s", userID)
+func (s *sessionRepository) getSessionKey(userID string, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sessionID)
+}
+
+func (s *sessionRepository) getRefreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("refresh_token:%s", tokenHash)
+}
+
+func (s *sessionRepository) getRefreshTokenJSON(session domain.Session) ([]byte, error) {
+	return jsoniter.Marshal(domain.RefreshToken{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+	})
+}
+
+func (s *sessionRepository) getUserSessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sessions:%s", userID.String())
 }
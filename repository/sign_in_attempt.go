@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type signInAttemptRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewSignInAttemptRepository(di *internal.Di) (domain.SignInAttemptRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signInAttemptRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+func (s *signInAttemptRepository) GetAccountFailures(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.getCounter(ctx, getAccountFailuresKey(userID))
+}
+
+func (s *signInAttemptRepository) IncrementAccountFailures(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.incrementCounter(ctx, getAccountFailuresKey(userID))
+}
+
+func (s *signInAttemptRepository) ResetAccountFailures(ctx context.Context, userID uuid.UUID) error {
+	if err := s.redisClient.Del(ctx, getAccountFailuresKey(userID)).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *signInAttemptRepository) GetIPFailures(ctx context.Context, ip string) (int64, error) {
+	return s.getCounter(ctx, getIPFailuresKey(ip))
+}
+
+func (s *signInAttemptRepository) IncrementIPFailures(ctx context.Context, ip string) (int64, error) {
+	return s.incrementCounter(ctx, getIPFailuresKey(ip))
+}
+
+func (s *signInAttemptRepository) LockAccount(ctx context.Context, userID uuid.UUID, duration time.Duration) error {
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, getAccountLockKey(userID), time.Now().UTC().Format(time.RFC3339), duration)
+	pipe.Del(ctx, getAccountFailuresKey(userID))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *signInAttemptRepository) IsAccountLocked(ctx context.Context, userID uuid.UUID) (bool, error) {
+	exists, err := s.redisClient.Exists(ctx, getAccountLockKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return exists > 0, nil
+}
+
+func (s *signInAttemptRepository) getCounter(ctx context.Context, key string) (int64, error) {
+	failures, err := s.redisClient.Get(ctx, key).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+func (s *signInAttemptRepository) incrementCounter(ctx context.Context, key string) (int64, error) {
+	failures, err := s.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if failures == 1 {
+		if err := s.redisClient.Expire(ctx, key, domain.SignInFailureWindow*time.Minute).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return failures, nil
+}
+
+func getAccountFailuresKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sign_in:failures:account:%s", userID.String())
+}
+
+func getIPFailuresKey(ip string) string {
+	return fmt.Sprintf("sign_in:failures:ip:%s", ip)
+}
+
+func getAccountLockKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sign_in:lock:%s", userID.String())
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/go-redis/redis/v8"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type signInReportRepository struct {
+	di          *internal.Di
+	redisClient *redis.Client
+}
+
+func NewSignInReportRepository(di *internal.Di) (domain.SignInReportRepository, error) {
+	redisClient, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signInReportRepository{
+		di:          di,
+		redisClient: redisClient,
+	}, nil
+}
+
+// SetToken keeps the report for as long as the reported session can live.
+func (s *signInReportRepository) SetToken(ctx context.Context, tokenHash string, report domain.SignInReport) error {
+	JSON, err := jsoniter.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	if err := s.redisClient.Set(ctx, getSignInReportKey(tokenHash), JSON, time.Duration(config.Env.Cache.SessionExp)*time.Hour).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *signInReportRepository) ConsumeToken(ctx context.Context, tokenHash string) (*domain.SignInReport, error) {
+	JSON, err := s.redisClient.GetDel(ctx, getSignInReportKey(tokenHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var report domain.SignInReport
+	if err := jsoniter.UnmarshalFromString(JSON, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func getSignInReportKey(tokenHash string) string {
+	return fmt.Sprintf("sign_in_report:%s", tokenHash)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type twoFactorRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(di *internal.Di) (domain.TwoFactorRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactorRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (t *twoFactorRepository) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+
+	if err := t.db.WithContext(ctx).
+		Where("userId = ?", userID).
+		First(&twoFactor).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+func (t *twoFactorRepository) SaveTwoFactor(ctx context.Context, twoFactor domain.TwoFactor) error {
+	if err := t.db.WithContext(ctx).
+		Omit("User").
+		Save(&twoFactor).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *twoFactorRepository) DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	if err := t.db.WithContext(ctx).
+		Where("userId = ?", userID).
+		Delete(&domain.TwoFactor{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UseStep records the TOTP time step a code was accepted for. It only succeeds
+// for a step later than the last one used, so a code cannot be replayed.
+func (t *twoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result := t.db.WithContext(ctx).
+		Model(&domain.TwoFactor{}).
+		Where("userId = ? AND lastUsedStep < ?", userID, step).
+		Update("lastUsedStep", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (t *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("userId = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.RecoveryCode, len(codeHashes))
+		for i, codeHash := range codeHashes {
+			codes[i] = domain.RecoveryCode{UserID: userID, CodeHash: codeHash}
+		}
+
+		return tx.Omit("User").Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marks an unused code as used and reports whether one
+// matched.
+func (t *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	result := t.db.WithContext(ctx).
+		Model(&domain.RecoveryCode{}).
+		Where("userId = ? AND codeHash = ? AND usedAt IS NULL", userID, codeHash).
+		Limit(1).
+		Update("usedAt", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (t *twoFactorRepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	if err := t.db.WithContext(ctx).
+		Where("userId = ?", userID).
+		Delete(&domain.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
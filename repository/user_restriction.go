@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type userRestrictionRepository struct {
+	di *internal.Di
+	db *gorm.DB
+}
+
+func NewUserRestrictionRepository(di *internal.Di) (domain.UserRestrictionRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userRestrictionRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (u *userRestrictionRepository) CreateRestriction(ctx context.Context, restriction domain.UserRestriction) error {
+	if err := u.db.WithContext(ctx).
+		Omit("User", "Target").
+		Create(&restriction).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *userRestrictionRepository) DeleteRestriction(ctx context.Context, userID, targetID uuid.UUID, restrictionType domain.RestrictionType) (bool, error) {
+	result := u.db.WithContext(ctx).
+		Where("userId = ? AND targetId = ? AND type = ?", userID, targetID, restrictionType).
+		Delete(&domain.UserRestriction{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (u *userRestrictionRepository) GetRestrictionTypes(ctx context.Context, userID, targetID uuid.UUID) ([]domain.RestrictionType, error) {
+	var restrictionTypes []domain.RestrictionType
+
+	if err := u.db.WithContext(ctx).
+		Model(&domain.UserRestriction{}).
+		Where("userId = ? AND targetId = ?", userID, targetID).
+		Pluck("type", &restrictionTypes).Error; err != nil {
+		return nil, err
+	}
+
+	return restrictionTypes, nil
+}
+
+// HasBlock reports whether either user has blocked the other.
+func (u *userRestrictionRepository) HasBlock(ctx context.Context, userID, targetID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := u.db.WithContext(ctx).
+		Model(&domain.UserRestriction{}).
+		Where("type = ? AND ((userId = ? AND targetId = ?) OR (userId = ? AND targetId = ?))",
+			domain.RestrictionBlock, userID, targetID, targetID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,79 @@
+package secure
+
+//go:generate go run gen_breached_passwords.go
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const breachedHashPrefixLength = 5
+
+//go:embed data/breached_passwords.gz
+var breachedPasswordsFile []byte
+
+var (
+	breachedRangesOnce sync.Once
+	breachedRanges     map[string][]string
+)
+
+// IsBreachedPassword reports whether the password is in the embedded list of
+// common and breached passwords. The list holds SHA-1 digests grouped by their
+// first five hex characters, the same k-anonymity ranges served by Have I Been
+// Pwned, so a remote range lookup can replace it without changing callers.
+func IsBreachedPassword(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes := breachedPasswordRange(digest[:breachedHashPrefixLength])
+	_, found := slices.BinarySearch(suffixes, digest[breachedHashPrefixLength:])
+
+	return found
+}
+
+func breachedPasswordRange(prefix string) []string {
+	breachedRangesOnce.Do(func() {
+		breachedRanges = loadBreachedRanges()
+	})
+
+	return breachedRanges[prefix]
+}
+
+// loadBreachedRanges panics on a corrupt file: it is embedded at build time,
+// so a failure here is a packaging bug rather than a runtime condition.
+func loadBreachedRanges() map[string][]string {
+	reader, err := gzip.NewReader(bytes.NewReader(breachedPasswordsFile))
+	if err != nil {
+		panic("secure: read breached passwords: " + err.Error())
+	}
+	defer reader.Close()
+
+	ranges := make(map[string][]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		digest := strings.TrimSpace(scanner.Text())
+		if len(digest) != sha1.Size*2 {
+			continue
+		}
+
+		prefix := digest[:breachedHashPrefixLength]
+		ranges[prefix] = append(ranges[prefix], digest[breachedHashPrefixLength:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		panic("secure: read breached passwords: " + err.Error())
+	}
+
+	for _, suffixes := range ranges {
+		slices.Sort(suffixes)
+	}
+
+	return ranges
+}
@@ -0,0 +1,95 @@
+//go:build ignore
+
+// This program builds data/breached_passwords.gz from data/common_passwords.txt.
+// Each entry is expanded with the capitalisation and suffixes people add to
+// satisfy composition rules, then stored as sorted upper-case SHA-1 hex digests,
+// the same shape as a Have I Been Pwned range dump.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var (
+	digitSuffixes   = []string{"1", "123", "1234"}
+	specialSuffixes = []string{"!", "@"}
+)
+
+func main() {
+	source, err := os.Open("data/common_passwords.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer source.Close()
+
+	passwords := map[string]struct{}{}
+	scanner := bufio.NewScanner(source)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+
+		for _, variant := range variants(word) {
+			passwords[variant] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	digests := make([]string, 0, len(passwords))
+	for password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		digests = append(digests, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	slices.Sort(digests)
+
+	output, err := os.Create("data/breached_passwords.gz")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer output.Close()
+
+	writer, err := gzip.NewWriterLevel(output, gzip.BestCompression)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, digest := range digests {
+		if _, err := writer.Write([]byte(digest + "\n")); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func variants(word string) []string {
+	capitalized := capitalize(word)
+	result := []string{word, capitalized, word + "1", word + "123"}
+
+	for _, digits := range digitSuffixes {
+		for _, special := range specialSuffixes {
+			result = append(result, capitalized+digits+special)
+		}
+	}
+
+	return result
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
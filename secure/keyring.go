@@ -0,0 +1,159 @@
+package secure
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const keyIDHeader = "kid"
+
+var (
+	ErrUnknownKeyID            = errors.New("unknown key id")
+	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+)
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeyRing holds the EC key used to sign new tokens and every public key,
+// active or retired, that is still accepted when verifying them.
+type KeyRing struct {
+	activeKeyID      string
+	signingKey       *ecdsa.PrivateKey
+	verificationKeys map[string]*ecdsa.PublicKey
+}
+
+func NewKeyRing(activeKeyID string, signingKey *ecdsa.PrivateKey, verificationKeys map[string]*ecdsa.PublicKey) (*KeyRing, error) {
+	if signingKey == nil {
+		return nil, fmt.Errorf("signing key %q not found", activeKeyID)
+	}
+
+	if signingKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("signing key %q must use the P-256 curve", activeKeyID)
+	}
+
+	keys := make(map[string]*ecdsa.PublicKey, len(verificationKeys)+1)
+	for kid, key := range verificationKeys {
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("verification key %q must use the P-256 curve", kid)
+		}
+		keys[kid] = key
+	}
+	keys[activeKeyID] = &signingKey.PublicKey
+
+	return &KeyRing{
+		activeKeyID:      activeKeyID,
+		signingKey:       signingKey,
+		verificationKeys: keys,
+	}, nil
+}
+
+func (k *KeyRing) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+func (k *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header[keyIDHeader] = k.activeKeyID
+
+	return token.SignedString(k.signingKey)
+}
+
+func (k *KeyRing) Keyfunc(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+		return nil, ErrUnexpectedSigningMethod
+	}
+
+	kid, _ := token.Header[keyIDHeader].(string)
+	key, ok := k.verificationKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key, nil
+}
+
+func (k *KeyRing) JWKS() (*JSONWebKeySet, error) {
+	kids := make([]string, 0, len(k.verificationKeys))
+	for kid := range k.verificationKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	keySet := &JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(kids))}
+	for _, kid := range kids {
+		jwk, err := toJSONWebKey(kid, k.verificationKeys[kid])
+		if err != nil {
+			return nil, err
+		}
+		keySet.Keys = append(keySet.Keys, *jwk)
+	}
+
+	return keySet, nil
+}
+
+// KeyID returns the RFC 7638 thumbprint of the key, used when a key is
+// loaded without an explicit identifier.
+func KeyID(key *ecdsa.PublicKey) (string, error) {
+	x, y, err := coordinates(key)
+	if err != nil {
+		return "", err
+	}
+
+	thumbprint := fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, x, y)
+	sum := sha256.Sum256([]byte(thumbprint))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func toJSONWebKey(kid string, key *ecdsa.PublicKey) (*JSONWebKey, error) {
+	x, y, err := coordinates(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWebKey{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   x,
+		Y:   y,
+		Kid: kid,
+		Use: "sig",
+		Alg: jwt.SigningMethodES256.Alg(),
+	}, nil
+}
+
+func coordinates(key *ecdsa.PublicKey) (string, string, error) {
+	ecdhKey, err := key.ECDH()
+	if err != nil {
+		return "", "", fmt.Errorf("convert public key: %w", err)
+	}
+
+	// uncompressed point: 0x04 || X || Y
+	point := ecdhKey.Bytes()
+	size := (len(point) - 1) / 2
+
+	return base64.RawURLEncoding.EncodeToString(point[1 : 1+size]),
+		base64.RawURLEncoding.EncodeToString(point[1+size:]),
+		nil
+}
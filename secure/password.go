@@ -1,11 +1,185 @@
 package secure
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordMismatch      = errors.New("password does not match")
+	ErrUnsupportedHashFormat = errors.New("unsupported password hash format")
+)
+
+// maxArgon2idMemory caps, in KiB, the memory a stored hash may ask for.
+const maxArgon2idMemory = 1024 * 1024
+
+// DefaultArgon2idParams follows the OWASP baseline for Argon2id.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordHasher hashes new passwords and verifies stored ones. NeedsRehash
+// reports whether a stored hash should be replaced by Hash on the next
+// successful verification.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(encodedHash, password string) error
+	NeedsRehash(encodedHash string) bool
+}
+
+type Argon2idParams struct {
+	Memory      uint32 // in KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var passwordHasher PasswordHasher = NewArgon2idHasher(DefaultArgon2idParams)
+
+// SetPasswordHasher replaces the hasher used by HashPassword, CheckPassword
+// and PasswordNeedsRehash. It is meant to be called once at startup.
+func SetPasswordHasher(hasher PasswordHasher) {
+	passwordHasher = hasher
+}
 
 func HashPassword(password string) ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hash, err := passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(hash), nil
 }
 
 func CheckPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return passwordHasher.Verify(hashedPassword, password)
+}
+
+func PasswordNeedsRehash(hashedPassword string) bool {
+	return passwordHasher.NeedsRehash(hashedPassword)
+}
+
+// argon2idHasher writes PHC strings
+// ($argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>) and
+// still verifies the bcrypt hashes stored before it was introduced.
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) PasswordHasher {
+	return &argon2idHasher{params: params}
+}
+
+func (a *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, a.params.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		a.params.Memory,
+		a.params.Iterations,
+		a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *argon2idHasher) Verify(encodedHash, password string) error {
+	if isBcryptHash(encodedHash) {
+		if err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return ErrPasswordMismatch
+			}
+			return err
+		}
+		return nil
+	}
+
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return ErrPasswordMismatch
+	}
+
+	return nil
+}
+
+func (a *argon2idHasher) NeedsRehash(encodedHash string) bool {
+	params, salt, _, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != a.params.Memory ||
+		params.Iterations != a.params.Iterations ||
+		params.Parallelism != a.params.Parallelism ||
+		params.KeyLength != a.params.KeyLength ||
+		uint32(len(salt)) != a.params.SaltLength
+}
+
+func isBcryptHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+func decodeArgon2idHash(encodedHash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	// argon2 panics on zero parameters, and a tampered hash must not be able to
+	// make a single verification allocate an arbitrary amount of memory
+	if params.Iterations == 0 || params.Parallelism == 0 ||
+		params.Memory < 8*uint32(params.Parallelism) || params.Memory > maxArgon2idMemory {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnsupportedHashFormat
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
 }
@@ -0,0 +1,142 @@
+package secure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams keeps the tests fast; only the encoding matters here.
+var testArgon2idParams = Argon2idParams{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestArgon2idHasher_Hash_ShouldEncodePHCString(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	encoded, err := hasher.Hash("Password@123")
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	params, salt, key, err := decodeArgon2idHash(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, testArgon2idParams, params)
+	assert.Len(t, salt, 16)
+	assert.Len(t, key, 32)
+}
+
+func TestArgon2idHasher_Hash_ShouldUseRandomSalt(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	first, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+	second, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestArgon2idHasher_Verify(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	encoded, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+
+	assert.NoError(t, hasher.Verify(encoded, "Password@123"))
+	assert.ErrorIs(t, hasher.Verify(encoded, "Password@124"), ErrPasswordMismatch)
+}
+
+func TestArgon2idHasher_Verify_WhenHashWasWrittenWithOtherParams_ShouldUseStoredParams(t *testing.T) {
+	previous := NewArgon2idHasher(Argon2idParams{Memory: 32, Iterations: 2, Parallelism: 2, SaltLength: 8, KeyLength: 16})
+	encoded, err := previous.Hash("Password@123")
+	require.NoError(t, err)
+
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	assert.NoError(t, hasher.Verify(encoded, "Password@123"))
+}
+
+func TestArgon2idHasher_Verify_WhenHashIsLegacyBcrypt_ShouldFallBackToBcrypt(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Password@123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	assert.NoError(t, hasher.Verify(string(legacy), "Password@123"))
+	assert.ErrorIs(t, hasher.Verify(string(legacy), "Password@124"), ErrPasswordMismatch)
+	assert.True(t, hasher.NeedsRehash(string(legacy)))
+}
+
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	encoded, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+
+	changes := map[string]func(params *Argon2idParams){
+		"memory":      func(params *Argon2idParams) { params.Memory *= 2 },
+		"iterations":  func(params *Argon2idParams) { params.Iterations++ },
+		"parallelism": func(params *Argon2idParams) { params.Parallelism++ },
+		"salt length": func(params *Argon2idParams) { params.SaltLength = 32 },
+		"key length":  func(params *Argon2idParams) { params.KeyLength = 64 },
+	}
+
+	assert.False(t, hasher.NeedsRehash(encoded))
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			params := testArgon2idParams
+			change(&params)
+
+			assert.True(t, NewArgon2idHasher(params).NeedsRehash(encoded))
+		})
+	}
+}
+
+func TestArgon2idHasher_Verify_WhenHashIsMalformed_ShouldReturnErrorUnsupportedHashFormat(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	encoded, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+	parts := strings.Split(encoded, "$")
+
+	withParams := func(params string) string {
+		return strings.Join([]string{"", parts[1], parts[2], params, parts[4], parts[5]}, "$")
+	}
+
+	tests := map[string]string{
+		"empty":              "",
+		"plain text":         "Password@123",
+		"wrong algorithm":    strings.Replace(encoded, "$argon2id$", "$argon2i$", 1),
+		"wrong version":      strings.Replace(encoded, "$v=19$", "$v=16$", 1),
+		"missing segment":    strings.Join(parts[:5], "$"),
+		"garbled params":     withParams("m=64;t=1;p=1"),
+		"zero iterations":    withParams("m=64,t=0,p=1"),
+		"zero parallelism":   withParams("m=64,t=1,p=0"),
+		"too little memory":  withParams("m=4,t=1,p=1"),
+		"excessive memory":   withParams("m=4294967295,t=1,p=1"),
+		"salt not base64":    strings.Join([]string{"", parts[1], parts[2], parts[3], "***", parts[5]}, "$"),
+		"empty key":          strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], ""}, "$"),
+		"unsupported bcrypt": "$2x$10$abcdefghijklmnopqrstuv",
+	}
+
+	for name, hash := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, hasher.Verify(hash, "Password@123"), ErrUnsupportedHashFormat)
+			assert.True(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestArgon2idHasher_Verify_WhenParamsAreTampered_ShouldReturnErrorPasswordMismatch(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	encoded, err := hasher.Hash("Password@123")
+	require.NoError(t, err)
+
+	weakened := strings.Replace(encoded, "$m=64,t=1,p=1$", "$m=8,t=1,p=1$", 1)
+
+	assert.ErrorIs(t, hasher.Verify(weakened, "Password@123"), ErrPasswordMismatch)
+}
@@ -0,0 +1,21 @@
+package secure
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+const codeVerifierSize = 32
+
+// GenerateCodeVerifier returns a PKCE code verifier (RFC 7636) of 43
+// characters from the unreserved URL alphabet.
+func GenerateCodeVerifier() (string, error) {
+	return GenerateToken(codeVerifierSize)
+}
+
+// CodeChallengeS256 derives the S256 code challenge sent with the
+// authorization request.
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
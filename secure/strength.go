@@ -0,0 +1,544 @@
+package secure
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// maxEstimatedPasswordLength bounds the matching work; anything longer is
+	// scored on its prefix, which is already far beyond the top score.
+	maxEstimatedPasswordLength = 100
+	minYear                    = 1900
+	maxYear                    = 2039
+)
+
+//go:embed data/common_passwords.txt
+var commonPasswordsFile string
+
+var (
+	passwordRanksOnce sync.Once
+	passwordRanks     map[string]int
+)
+
+var keyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+	"1qaz2wsx3edc4rfv5tgb6yhn7ujm8ik9ol0p",
+}
+
+var leetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '3': 'e', '6': 'g', '1': 'i', '!': 'i',
+	'0': 'o', '5': 's', '$': 's', '7': 't', '2': 'z',
+}
+
+type patternKind int
+
+const (
+	patternBruteforce patternKind = iota
+	patternDictionary
+	patternSequence
+	patternRepeat
+	patternKeyboard
+	patternYear
+)
+
+type passwordMatch struct {
+	kind        patternKind
+	start       int
+	end         int
+	guessesLog  float64
+	rank        int
+	capitalized bool
+	upper       bool
+	leet        bool
+}
+
+// PasswordStrength is a zxcvbn-style estimate: the password is split into the
+// cheapest combination of dictionary words, sequences, repeats, keyboard rows,
+// years and brute-forced characters, and Score buckets the resulting number of
+// guesses from 0 (under 10^3) to 4 (10^10 or more).
+type PasswordStrength struct {
+	Score       int
+	GuessesLog  float64
+	Breached    bool
+	Warning     string
+	Suggestions []string
+}
+
+func EstimatePasswordStrength(password string) PasswordStrength {
+	runes := []rune(password)
+	if len(runes) > maxEstimatedPasswordLength {
+		runes = runes[:maxEstimatedPasswordLength]
+	}
+
+	if len(runes) == 0 {
+		return PasswordStrength{
+			Warning:     "Enter a password.",
+			Suggestions: []string{"Use a few words, avoid common phrases."},
+		}
+	}
+
+	matches := findPasswordMatches(runes)
+	guessesLog, sequence := cheapestMatchSequence(len(runes), matches)
+
+	strength := PasswordStrength{
+		Score:      scoreFromGuesses(guessesLog),
+		GuessesLog: guessesLog,
+		Breached:   IsBreachedPassword(password),
+	}
+
+	if strength.Breached {
+		strength.Score = 0
+	}
+
+	strength.Warning, strength.Suggestions = passwordFeedback(strength, sequence)
+
+	return strength
+}
+
+func scoreFromGuesses(guessesLog float64) int {
+	switch {
+	case guessesLog < 3:
+		return 0
+	case guessesLog < 6:
+		return 1
+	case guessesLog < 8:
+		return 2
+	case guessesLog < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func findPasswordMatches(runes []rune) []passwordMatch {
+	lower := []rune(strings.ToLower(string(runes)))
+
+	var matches []passwordMatch
+	matches = append(matches, dictionaryMatches(runes, lower)...)
+	matches = append(matches, sequenceMatches(lower)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, keyboardMatches(lower)...)
+	matches = append(matches, yearMatches(runes)...)
+
+	return matches
+}
+
+func dictionaryMatches(runes, lower []rune) []passwordMatch {
+	ranks := commonPasswordRanks()
+
+	unleeted := make([]rune, len(lower))
+	for i, r := range lower {
+		if substitute, ok := leetSubstitutions[r]; ok {
+			unleeted[i] = substitute
+		} else {
+			unleeted[i] = r
+		}
+	}
+
+	var matches []passwordMatch
+	for i := range lower {
+		for j := i + 3; j <= len(lower); j++ {
+			token := string(lower[i:j])
+			leet := false
+
+			rank, ok := ranks[token]
+			if !ok {
+				rank, ok = ranks[string(unleeted[i:j])]
+				leet = ok
+			}
+
+			if !ok {
+				continue
+			}
+
+			match := passwordMatch{kind: patternDictionary, start: i, end: j, rank: rank, leet: leet}
+			match.capitalized, match.upper = capitalization(runes[i:j])
+
+			guesses := math.Log10(float64(rank))
+			if match.capitalized || match.upper {
+				guesses += math.Log10(2)
+			} else if hasMixedCase(runes[i:j]) {
+				guesses += float64(countUpper(runes[i:j])) * math.Log10(2)
+			}
+			if leet {
+				guesses += math.Log10(2)
+			}
+
+			match.guessesLog = guesses
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+func sequenceMatches(lower []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for i := 0; i < len(lower)-2; {
+		delta := lower[i+1] - lower[i]
+		if (delta != 1 && delta != -1) || !sameCharClass(lower[i], lower[i+1]) {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j+1 < len(lower) && lower[j+1]-lower[j] == delta && sameCharClass(lower[j], lower[j+1]) {
+			j++
+		}
+
+		if j-i+1 >= 3 {
+			base := 26.0
+			if unicode.IsDigit(lower[i]) {
+				base = 10
+			}
+			if strings.ContainsRune("a1z90", lower[i]) {
+				base = 4
+			}
+
+			guesses := math.Log10(base * float64(j-i+1))
+			if delta < 0 {
+				guesses += math.Log10(2)
+			}
+
+			matches = append(matches, passwordMatch{kind: patternSequence, start: i, end: j + 1, guessesLog: guesses})
+		}
+
+		i = j
+	}
+
+	return matches
+}
+
+// repeatMatches finds runs made of a base repeated back to back, like "aaa" or
+// "abcabc". As in zxcvbn the base is the shortest one that produces the
+// longest run, and the run costs the guesses of the base times the number of
+// repeats, so repeating a weak chunk never makes a strong password.
+func repeatMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for i := 0; i < len(runes); {
+		span, baseLength := longestRepeat(runes[i:])
+		if span < 3 {
+			i++
+			continue
+		}
+
+		baseGuesses, _ := cheapestMatchSequence(baseLength, findPasswordMatches(runes[i:i+baseLength]))
+		guesses := baseGuesses + math.Log10(float64(span/baseLength))
+		matches = append(matches, passwordMatch{kind: patternRepeat, start: i, end: i + span, guessesLog: guesses})
+
+		i += span
+	}
+
+	return matches
+}
+
+// longestRepeat returns the length of the longest prefix of runes made of at
+// least two copies of a base, and the length of the shortest such base.
+func longestRepeat(runes []rune) (int, int) {
+	bestSpan, bestBase := 0, 0
+
+	for base := 1; base*2 <= len(runes); base++ {
+		repeats := 1
+		for end := (repeats + 1) * base; end <= len(runes) && slices.Equal(runes[end-base:end], runes[:base]); end += base {
+			repeats++
+		}
+
+		if span := repeats * base; repeats >= 2 && span > bestSpan {
+			bestSpan, bestBase = span, base
+		}
+	}
+
+	return bestSpan, bestBase
+}
+
+func keyboardMatches(lower []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for i := range lower {
+		for j := i + 4; j <= len(lower); j++ {
+			token := string(lower[i:j])
+			if !onKeyboardRow(token) {
+				break
+			}
+
+			guesses := math.Log10(50 * float64(j-i))
+			matches = append(matches, passwordMatch{kind: patternKeyboard, start: i, end: j, guessesLog: guesses})
+		}
+	}
+
+	return matches
+}
+
+func yearMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for i := 0; i+4 <= len(runes); i++ {
+		year := 0
+		digits := true
+		for _, r := range runes[i : i+4] {
+			if !unicode.IsDigit(r) {
+				digits = false
+				break
+			}
+			year = year*10 + int(r-'0')
+		}
+
+		if digits && year >= minYear && year <= maxYear {
+			matches = append(matches, passwordMatch{kind: patternYear, start: i, end: i + 4, guessesLog: math.Log10(maxYear - minYear)})
+		}
+	}
+
+	return matches
+}
+
+// cheapestMatchSequence covers the password with matches and brute-forced
+// runs so that the number of guesses is minimal. Like zxcvbn it multiplies the
+// guesses of each part and by the factorial of the number of parts, since an
+// attacker also has to try the parts in every order.
+func cheapestMatchSequence(length int, matches []passwordMatch) (float64, []passwordMatch) {
+	byEnd := make([][]passwordMatch, length+1)
+	for _, match := range matches {
+		byEnd[match.end] = append(byEnd[match.end], match)
+	}
+
+	type step struct {
+		guessesLog float64
+		match      passwordMatch
+		ok         bool
+	}
+
+	// best[k][l] is the cheapest way to cover the first k runes with l parts.
+	best := make([][]step, length+1)
+	for k := range best {
+		best[k] = make([]step, length+1)
+	}
+	best[0][0] = step{ok: true}
+
+	for k := 1; k <= length; k++ {
+		candidates := byEnd[k]
+		for start := 0; start < k; start++ {
+			candidates = append(candidates, passwordMatch{
+				kind:       patternBruteforce,
+				start:      start,
+				end:        k,
+				guessesLog: float64(k - start),
+			})
+		}
+
+		for _, candidate := range candidates {
+			for parts := 0; parts < k; parts++ {
+				previous := best[candidate.start][parts]
+				if !previous.ok {
+					continue
+				}
+
+				guesses := previous.guessesLog + candidate.guessesLog
+				current := best[k][parts+1]
+				if !current.ok || guesses < current.guessesLog {
+					best[k][parts+1] = step{guessesLog: guesses, match: candidate, ok: true}
+				}
+			}
+		}
+	}
+
+	bestParts := 0
+	bestGuesses := math.Inf(1)
+	for parts := 1; parts <= length; parts++ {
+		if !best[length][parts].ok {
+			continue
+		}
+
+		guesses := best[length][parts].guessesLog + logFactorial(parts)
+		if guesses < bestGuesses {
+			bestGuesses = guesses
+			bestParts = parts
+		}
+	}
+
+	sequence := make([]passwordMatch, bestParts)
+	for k, parts := length, bestParts; parts > 0; parts-- {
+		match := best[k][parts].match
+		sequence[parts-1] = match
+		k = match.start
+	}
+
+	return bestGuesses, sequence
+}
+
+func passwordFeedback(strength PasswordStrength, sequence []passwordMatch) (string, []string) {
+	if strength.Breached {
+		return "This password appears in lists of common or breached passwords.", []string{
+			"Choose a password you have not used anywhere else.",
+			"Use a few words, avoid common phrases.",
+		}
+	}
+
+	if strength.Score >= 3 {
+		return "", []string{}
+	}
+
+	var warning string
+	suggestions := []string{"Add another word or two. Uncommon words are better."}
+
+	for _, match := range sequence {
+		switch match.kind {
+		case patternDictionary:
+			if warning == "" {
+				if match.rank <= 10 {
+					warning = "This is a top-10 common password."
+				} else {
+					warning = "This is similar to a commonly used password."
+				}
+			}
+			if match.capitalized {
+				suggestions = append(suggestions, "Capitalization doesn't help very much.")
+			}
+			if match.upper {
+				suggestions = append(suggestions, "All-uppercase is almost as easy to guess as all-lowercase.")
+			}
+			if match.leet {
+				suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much.")
+			}
+		case patternSequence:
+			if warning == "" {
+				warning = "Sequences like abc or 6543 are easy to guess."
+			}
+			suggestions = append(suggestions, "Avoid sequences.")
+		case patternRepeat:
+			if warning == "" {
+				warning = "Repeats like \"aaa\" are easy to guess."
+			}
+			suggestions = append(suggestions, "Avoid repeated words and characters.")
+		case patternKeyboard:
+			if warning == "" {
+				warning = "Straight rows of keys are easy to guess."
+			}
+			suggestions = append(suggestions, "Use a longer keyboard pattern with more turns.")
+		case patternYear:
+			if warning == "" {
+				warning = "Recent years are easy to guess."
+			}
+			suggestions = append(suggestions, "Avoid recent years and years that are associated with you.")
+		}
+	}
+
+	if warning == "" {
+		warning = "This password is too short to be hard to guess."
+	}
+
+	return warning, uniqueStrings(suggestions)
+}
+
+func commonPasswordRanks() map[string]int {
+	passwordRanksOnce.Do(func() {
+		passwordRanks = make(map[string]int)
+
+		scanner := bufio.NewScanner(strings.NewReader(commonPasswordsFile))
+		for rank := 1; scanner.Scan(); {
+			word := strings.TrimSpace(scanner.Text())
+			if word == "" {
+				continue
+			}
+
+			if _, exists := passwordRanks[word]; !exists {
+				passwordRanks[word] = rank
+				rank++
+			}
+		}
+	})
+
+	return passwordRanks
+}
+
+func onKeyboardRow(token string) bool {
+	for _, row := range keyboardRows {
+		if strings.Contains(row, token) || strings.Contains(reverse(row), token) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func capitalization(runes []rune) (bool, bool) {
+	upper := countUpper(runes)
+	letters := 0
+	for _, r := range runes {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	if upper == 0 {
+		return false, false
+	}
+
+	if upper == letters {
+		return false, true
+	}
+
+	return upper == 1 && unicode.IsUpper(runes[0]), false
+}
+
+func hasMixedCase(runes []rune) bool {
+	upper := countUpper(runes)
+	return upper > 0 && upper < len(runes)
+}
+
+func countUpper(runes []rune) int {
+	count := 0
+	for _, r := range runes {
+		if unicode.IsUpper(r) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func sameCharClass(a, b rune) bool {
+	return (unicode.IsDigit(a) && unicode.IsDigit(b)) || (unicode.IsLetter(a) && unicode.IsLetter(b))
+}
+
+func logFactorial(n int) float64 {
+	result := 0.0
+	for i := 2; i <= n; i++ {
+		result += math.Log10(float64(i))
+	}
+
+	return result
+}
+
+func reverse(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+
+	return string(runes)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+package secure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bestSequence returns the pattern kinds of the cheapest way to guess password.
+func bestSequence(password string) []patternKind {
+	runes := []rune(password)
+	_, sequence := cheapestMatchSequence(len(runes), findPasswordMatches(runes))
+
+	kinds := make([]patternKind, len(sequence))
+	for i, match := range sequence {
+		kinds[i] = match.kind
+	}
+
+	return kinds
+}
+
+func TestEstimatePasswordStrength_WhenPasswordIsEmpty_ShouldScoreZero(t *testing.T) {
+	strength := EstimatePasswordStrength("")
+
+	assert.Equal(t, 0, strength.Score)
+	assert.NotEmpty(t, strength.Warning)
+}
+
+func TestEstimatePasswordStrength_WhenPasswordIsRandom_ShouldScoreFour(t *testing.T) {
+	for _, password := range []string{"xK9#mQ2$vL7@", "zqj8$Lw#9pVx!rT4", "correct horse battery staple"} {
+		strength := EstimatePasswordStrength(password)
+
+		assert.Equal(t, 4, strength.Score, password)
+		assert.False(t, strength.Breached, password)
+		assert.Empty(t, strength.Warning, password)
+	}
+}
+
+func TestEstimatePasswordStrength_Sequences(t *testing.T) {
+	for _, password := range []string{"abcdefgh", "hgfedcba", "mnopqrstu", "98765"} {
+		t.Run(password, func(t *testing.T) {
+			assert.Equal(t, []patternKind{patternSequence}, bestSequence(password))
+			assert.LessOrEqual(t, EstimatePasswordStrength(password).Score, 1)
+		})
+	}
+}
+
+func TestEstimatePasswordStrength_KeyboardPatterns(t *testing.T) {
+	for _, password := range []string{"wertyuio", "poiuytre", "sdfghjk", "2wsx3edc4rfv"} {
+		t.Run(password, func(t *testing.T) {
+			assert.Equal(t, []patternKind{patternKeyboard}, bestSequence(password))
+			assert.Equal(t, 0, EstimatePasswordStrength(password).Score)
+		})
+	}
+}
+
+func TestEstimatePasswordStrength_Repeats(t *testing.T) {
+	tests := map[string]int{
+		"aaaaaaaaaaaa":             1,
+		"abcabcabcabc":             3,
+		"Xy7!Xy7!Xy7!":             4,
+		strings.Repeat("aB1!", 30): 4,
+	}
+
+	for password, baseLength := range tests {
+		t.Run(password, func(t *testing.T) {
+			span, base := longestRepeat([]rune(password))
+
+			assert.Equal(t, baseLength, base)
+			assert.Equal(t, len([]rune(password)), span)
+			assert.Equal(t, []patternKind{patternRepeat}, bestSequence(password))
+			assert.Less(t, EstimatePasswordStrength(password).Score, 2)
+		})
+	}
+}
+
+func TestEstimatePasswordStrength_WhenRepeatedBaseIsStrong_ShouldScoreBaseTimesRepeats(t *testing.T) {
+	base := EstimatePasswordStrength("xK9#mQ2$vL7@")
+	repeated := EstimatePasswordStrength("xK9#mQ2$vL7@xK9#mQ2$vL7@xK9#mQ2$vL7@")
+
+	assert.InDelta(t, base.GuessesLog+0.477, repeated.GuessesLog, 0.01)
+}
+
+func TestRepeatMatches_WhenRunIsTooShort_ShouldNotMatch(t *testing.T) {
+	assert.Empty(t, repeatMatches([]rune("aa")))
+	assert.Empty(t, repeatMatches([]rune("abcd")))
+	assert.Empty(t, repeatMatches([]rune("aAa")), "repeats are case sensitive")
+}
+
+func TestEstimatePasswordStrength_DictionaryWithLeetSubstitutions(t *testing.T) {
+	tests := []struct {
+		password   string
+		suggestion string
+	}{
+		{"P4ssw0rd", "Predictable substitutions like '@' instead of 'a' don't help very much."},
+		{"dr4g0n", "Predictable substitutions like '@' instead of 'a' don't help very much."},
+		{"Dr4g0n", "Capitalization doesn't help very much."},
+		{"PASSWORD", "All-uppercase is almost as easy to guess as all-lowercase."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.password, func(t *testing.T) {
+			assert.Equal(t, []patternKind{patternDictionary}, bestSequence(test.password))
+
+			strength := EstimatePasswordStrength(test.password)
+
+			assert.Equal(t, 0, strength.Score)
+			assert.Contains(t, strength.Suggestions, test.suggestion)
+		})
+	}
+}
+
+func TestEstimatePasswordStrength_WhenPasswordIsBreached_ShouldScoreZero(t *testing.T) {
+	strength := EstimatePasswordStrength("password123")
+
+	assert.True(t, strength.Breached)
+	assert.Equal(t, 0, strength.Score)
+	assert.Equal(t, "This password appears in lists of common or breached passwords.", strength.Warning)
+}
+
+func TestEstimatePasswordStrength_WhenPasswordIsTooLong_ShouldScorePrefix(t *testing.T) {
+	strength := EstimatePasswordStrength(strings.Repeat("xK9#mQ2$vL7@", 20))
+
+	assert.Equal(t, 4, strength.Score)
+}
+
+func TestIsBreachedPassword(t *testing.T) {
+	tests := map[string]bool{
+		"123456":           true,
+		"password":         true,
+		"qwerty":           true,
+		"xK9#mQ2$vL7@":     false,
+		"zqj8$Lw#9pVx!rT4": false,
+		"":                 false,
+	}
+
+	for password, breached := range tests {
+		t.Run(password, func(t *testing.T) {
+			assert.Equal(t, breached, IsBreachedPassword(password))
+		})
+	}
+}
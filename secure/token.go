@@ -0,0 +1,67 @@
+package secure
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+func GenerateToken(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func GenerateOTP(digits int) (string, error) {
+	var builder strings.Builder
+
+	for i := 0; i < digits; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("generate otp digit: %w", err)
+		}
+		builder.WriteString(n.String())
+	}
+
+	return builder.String(), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCode returns a single-use code formatted as "xxxxx-xxxxx".
+// Ambiguous characters such as 0, o, 1 and l are left out.
+func GenerateRecoveryCode() (string, error) {
+	var builder strings.Builder
+
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			builder.WriteByte('-')
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("generate recovery code character: %w", err)
+		}
+		builder.WriteByte(recoveryCodeAlphabet[n.Int64()])
+	}
+
+	return builder.String(), nil
+}
+
+// NormalizeRecoveryCode makes a typed recovery code comparable to the one that
+// was issued, ignoring case, spaces and dashes.
+func NormalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
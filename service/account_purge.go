@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+)
+
+type accountPurgeService struct {
+	di                     *internal.Di
+	accountPurgeRepository domain.AccountPurgeRepository
+	sessionRepository      domain.SessionRepository
+	userRepository         domain.UserRepository
+	dataExportRepository   domain.DataExportRepository
+	storage                client.Storage
+}
+
+func NewAccountPurgeService(di *internal.Di) (domain.AccountPurgeService, error) {
+	accountPurgeRepository, err := internal.Invoke[domain.AccountPurgeRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionRepository, err := internal.Invoke[domain.SessionRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	dataExportRepository, err := internal.Invoke[domain.DataExportRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := internal.Invoke[client.Storage](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountPurgeService{
+		di:                     di,
+		accountPurgeRepository: accountPurgeRepository,
+		sessionRepository:      sessionRepository,
+		userRepository:         userRepository,
+		dataExportRepository:   dataExportRepository,
+		storage:                storage,
+	}, nil
+}
+
+// PurgeDeletedAccounts removes every account whose deletion grace period is
+// over and returns how many were purged.
+func (a *accountPurgeService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	purged := 0
+
+	for {
+		userIDs, err := a.accountPurgeRepository.GetAccountsDueForPurge(ctx, time.Now().UTC(), domain.AccountPurgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("get accounts due for purge: %w", err)
+		}
+
+		for _, userID := range userIDs {
+			if err := a.purgeAccount(ctx, userID); err != nil {
+				return purged, fmt.Errorf("purge account %s: %w", userID, err)
+			}
+			purged++
+		}
+
+		if len(userIDs) < domain.AccountPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purgeAccount deletes the user's content before the user row, so that the
+// cascades left to the database only touch a handful of rows. Files in the
+// storage go first: once the rows referencing them cascade away nothing
+// points to them anymore.
+func (a *accountPurgeService) purgeAccount(ctx context.Context, userID uuid.UUID) error {
+	user, err := a.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if err := a.deleteDataExports(ctx, userID); err != nil {
+		return fmt.Errorf("delete data exports: %w", err)
+	}
+
+	if user != nil && user.AvatarKey != "" {
+		deleteAvatarVariants(ctx, a.storage, user.AvatarKey)
+	}
+
+	if err := deleteInBatches(ctx, userID, a.accountPurgeRepository.DeleteLikes); err != nil {
+		return fmt.Errorf("delete likes: %w", err)
+	}
+
+	if err := deleteInBatches(ctx, userID, a.accountPurgeRepository.DeletePosts); err != nil {
+		return fmt.Errorf("delete posts: %w", err)
+	}
+
+	if err := deleteInBatches(ctx, userID, a.accountPurgeRepository.DeleteFollows); err != nil {
+		return fmt.Errorf("delete follows: %w", err)
+	}
+
+	if err := a.sessionRepository.DeleteSessionsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+
+	if err := a.accountPurgeRepository.DeleteCachedKeys(ctx, userID); err != nil {
+		return fmt.Errorf("delete cached keys: %w", err)
+	}
+
+	if err := a.userRepository.DeleteUser(ctx, userID); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+
+	slog.Info("account purged", slog.String("userId", userID.String()))
+
+	return nil
+}
+
+func (a *accountPurgeService) deleteDataExports(ctx context.Context, userID uuid.UUID) error {
+	exports, err := a.dataExportRepository.GetExportsByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get data exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if export.FileKey == "" {
+			continue
+		}
+
+		if err := a.storage.Delete(ctx, export.FileKey); err != nil {
+			return fmt.Errorf("delete data export file %s: %w", export.FileKey, err)
+		}
+	}
+
+	return nil
+}
+
+func deleteInBatches(ctx context.Context, userID uuid.UUID, deleteBatch func(ctx context.Context, userID uuid.UUID, limit int) (int64, error)) error {
+	for {
+		deleted, err := deleteBatch(ctx, userID, domain.AccountPurgeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if deleted < domain.AccountPurgeBatchSize {
+			return nil
+		}
+	}
+}
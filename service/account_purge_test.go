@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeDeletedAccounts_WhenAccountsAreDue_ShouldDeleteContentBeforeUser(t *testing.T) {
+	ctx := context.Background()
+	accountPurgeRepoMock := new(mocks.AccountPurgeRepository)
+	sessionRepoMock := new(mocks.SessionRepository)
+	userRepoMock := new(mocks.UserRepository)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	storageMock := new(mocks.Storage)
+
+	accountPurgeService := &accountPurgeService{
+		accountPurgeRepository: accountPurgeRepoMock,
+		sessionRepository:      sessionRepoMock,
+		userRepository:         userRepoMock,
+		dataExportRepository:   dataExportRepoMock,
+		storage:                storageMock,
+	}
+
+	userID := uuid.New()
+	var order []string
+	track := func(step string) func(mock.Arguments) {
+		return func(mock.Arguments) { order = append(order, step) }
+	}
+
+	exportKey := "exports/" + userID.String() + "/export.zip"
+
+	accountPurgeRepoMock.On("GetAccountsDueForPurge", ctx, mock.AnythingOfType("time.Time"), domain.AccountPurgeBatchSize).Return([]uuid.UUID{userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	dataExportRepoMock.On("GetExportsByUserID", ctx, userID).Return([]*domain.DataExport{
+		{UserID: userID, Status: domain.DataExportReady, FileKey: exportKey},
+		{UserID: userID, Status: domain.DataExportExpired},
+	}, nil)
+	storageMock.On("Delete", ctx, exportKey).Return(nil).Once().Run(track("exports"))
+	accountPurgeRepoMock.On("DeleteLikes", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(domain.AccountPurgeBatchSize), nil).Once().Run(track("likes"))
+	accountPurgeRepoMock.On("DeleteLikes", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(3), nil).Once().Run(track("likes"))
+	accountPurgeRepoMock.On("DeletePosts", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(1), nil).Run(track("posts"))
+	accountPurgeRepoMock.On("DeleteFollows", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), nil).Run(track("follows"))
+	sessionRepoMock.On("DeleteSessionsByUserID", ctx, userID).Return(nil).Run(track("sessions"))
+	accountPurgeRepoMock.On("DeleteCachedKeys", ctx, userID).Return(nil).Run(track("keys"))
+	userRepoMock.On("DeleteUser", ctx, userID).Return(nil).Run(track("user"))
+
+	purged, err := accountPurgeService.PurgeDeletedAccounts(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, []string{"exports", "likes", "likes", "posts", "follows", "sessions", "keys", "user"}, order)
+	accountPurgeRepoMock.AssertExpectations(t)
+	storageMock.AssertExpectations(t)
+}
+
+func TestPurgeDeletedAccounts_WhenDeletingContentFails_ShouldKeepUser(t *testing.T) {
+	ctx := context.Background()
+	accountPurgeRepoMock := new(mocks.AccountPurgeRepository)
+	sessionRepoMock := new(mocks.SessionRepository)
+	userRepoMock := new(mocks.UserRepository)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	storageMock := new(mocks.Storage)
+
+	accountPurgeService := &accountPurgeService{
+		accountPurgeRepository: accountPurgeRepoMock,
+		sessionRepository:      sessionRepoMock,
+		userRepository:         userRepoMock,
+		dataExportRepository:   dataExportRepoMock,
+		storage:                storageMock,
+	}
+
+	userID := uuid.New()
+	accountPurgeRepoMock.On("GetAccountsDueForPurge", ctx, mock.AnythingOfType("time.Time"), domain.AccountPurgeBatchSize).Return([]uuid.UUID{userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	dataExportRepoMock.On("GetExportsByUserID", ctx, userID).Return(nil, nil)
+	accountPurgeRepoMock.On("DeleteLikes", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), nil)
+	accountPurgeRepoMock.On("DeletePosts", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), errors.New("database error"))
+
+	purged, err := accountPurgeService.PurgeDeletedAccounts(ctx)
+
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+	userRepoMock.AssertNotCalled(t, "DeleteUser", ctx, userID)
+}
+
+func TestPurgeDeletedAccounts_WhenDeletingExportFileFails_ShouldKeepUser(t *testing.T) {
+	ctx := context.Background()
+	accountPurgeRepoMock := new(mocks.AccountPurgeRepository)
+	userRepoMock := new(mocks.UserRepository)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	storageMock := new(mocks.Storage)
+
+	accountPurgeService := &accountPurgeService{
+		accountPurgeRepository: accountPurgeRepoMock,
+		userRepository:         userRepoMock,
+		dataExportRepository:   dataExportRepoMock,
+		storage:                storageMock,
+	}
+
+	userID := uuid.New()
+	accountPurgeRepoMock.On("GetAccountsDueForPurge", ctx, mock.AnythingOfType("time.Time"), domain.AccountPurgeBatchSize).Return([]uuid.UUID{userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	dataExportRepoMock.On("GetExportsByUserID", ctx, userID).Return([]*domain.DataExport{{UserID: userID, FileKey: "exports/export.zip"}}, nil)
+	storageMock.On("Delete", ctx, "exports/export.zip").Return(errors.New("storage error"))
+
+	purged, err := accountPurgeService.PurgeDeletedAccounts(ctx)
+
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+	accountPurgeRepoMock.AssertNotCalled(t, "DeleteLikes", ctx, userID, domain.AccountPurgeBatchSize)
+	userRepoMock.AssertNotCalled(t, "DeleteUser", ctx, userID)
+}
+
+func TestPurgeDeletedAccounts_WhenUserHasAvatar_ShouldDeleteEveryVariant(t *testing.T) {
+	ctx := context.Background()
+	accountPurgeRepoMock := new(mocks.AccountPurgeRepository)
+	sessionRepoMock := new(mocks.SessionRepository)
+	userRepoMock := new(mocks.UserRepository)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	storageMock := new(mocks.Storage)
+
+	accountPurgeService := &accountPurgeService{
+		accountPurgeRepository: accountPurgeRepoMock,
+		sessionRepository:      sessionRepoMock,
+		userRepository:         userRepoMock,
+		dataExportRepository:   dataExportRepoMock,
+		storage:                storageMock,
+	}
+
+	userID := uuid.New()
+	avatarKey := "avatars/" + userID.String() + "/" + uuid.NewString() + "/medium.jpg"
+
+	accountPurgeRepoMock.On("GetAccountsDueForPurge", ctx, mock.AnythingOfType("time.Time"), domain.AccountPurgeBatchSize).Return([]uuid.UUID{userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID, AvatarKey: avatarKey}, nil)
+	dataExportRepoMock.On("GetExportsByUserID", ctx, userID).Return(nil, nil)
+	for _, size := range domain.AvatarSizes {
+		storageMock.On("Delete", ctx, domain.AvatarVariantKey(avatarKey, size.Name)).Return(nil).Once()
+	}
+	accountPurgeRepoMock.On("DeleteLikes", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), nil)
+	accountPurgeRepoMock.On("DeletePosts", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), nil)
+	accountPurgeRepoMock.On("DeleteFollows", ctx, userID, domain.AccountPurgeBatchSize).Return(int64(0), nil)
+	sessionRepoMock.On("DeleteSessionsByUserID", ctx, userID).Return(nil)
+	accountPurgeRepoMock.On("DeleteCachedKeys", ctx, userID).Return(nil)
+	userRepoMock.On("DeleteUser", ctx, userID).Return(nil)
+
+	purged, err := accountPurgeService.PurgeDeletedAccounts(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	storageMock.AssertExpectations(t)
+	storageMock.AssertNumberOfCalls(t, "Delete", len(domain.AvatarSizes))
+}
+
+func TestPurgeDeletedAccounts_WhenNothingIsDue_ShouldUseCurrentTimeAsCutoff(t *testing.T) {
+	ctx := context.Background()
+	accountPurgeRepoMock := new(mocks.AccountPurgeRepository)
+
+	accountPurgeService := &accountPurgeService{
+		accountPurgeRepository: accountPurgeRepoMock,
+	}
+
+	accountPurgeRepoMock.On("GetAccountsDueForPurge", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before).Abs() < time.Minute
+	}), domain.AccountPurgeBatchSize).Return([]uuid.UUID{}, nil)
+
+	purged, err := accountPurgeService.PurgeDeletedAccounts(ctx)
+
+	assert.NoError(t, err)
+	assert.Zero(t, purged)
+	accountPurgeRepoMock.AssertExpectations(t)
+}
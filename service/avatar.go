@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/utils"
+	"github.com/google/uuid"
+)
+
+type avatarService struct {
+	di             *internal.Di
+	userRepository domain.UserRepository
+	contextService domain.ContextService
+	storage        client.Storage
+}
+
+func NewAvatarService(di *internal.Di) (domain.AvatarService, error) {
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := internal.Invoke[client.Storage](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &avatarService{
+		di:             di,
+		userRepository: userRepository,
+		contextService: contextService,
+		storage:        storage,
+	}, nil
+}
+
+// UploadAvatar replaces the signed in user's avatar. The image is decoded and
+// encoded again, which drops EXIF and any other metadata, and stored in every
+// size of domain.AvatarSizes under a new directory so that cached URLs of the
+// previous avatar never serve the new one.
+func (a *avatarService) UploadAvatar(ctx context.Context, content io.Reader) (*domain.AvatarResponse, error) {
+	user, err := a.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, domain.MaxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read avatar: %w", err)
+	}
+
+	if len(data) > domain.MaxImageSize {
+		return nil, domain.ErrInvalidImage
+	}
+
+	img, format, err := utils.DecodeImage(data, domain.AvatarMaxPixels)
+	if err != nil {
+		if errors.Is(err, utils.ErrUnsupportedImage) || errors.Is(err, utils.ErrImageTooLarge) {
+			return nil, domain.ErrInvalidImage
+		}
+		return nil, fmt.Errorf("decode avatar: %w", err)
+	}
+
+	directory := fmt.Sprintf("%s%s/%s", domain.AvatarKeyPrefix, user.ID, uuid.New())
+	key := path.Join(directory, domain.DefaultAvatarSize+imageExtension(format))
+
+	if err := a.putVariants(ctx, key, img, format); err != nil {
+		return nil, err
+	}
+
+	previousKey := user.AvatarKey
+	user.Avatar = domain.MediaURL(key)
+	user.AvatarKey = key
+	if err := a.userRepository.UpdateUser(ctx, *user); err != nil {
+		deleteAvatarVariants(ctx, a.storage, key)
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	if previousKey != "" {
+		deleteAvatarVariants(ctx, a.storage, previousKey)
+	}
+
+	return domain.NewAvatarResponse(key), nil
+}
+
+// DeleteAvatar removes the uploaded avatar and goes back to the placeholder.
+func (a *avatarService) DeleteAvatar(ctx context.Context) error {
+	user, err := a.sessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey == "" {
+		return domain.ErrAvatarNotFound
+	}
+
+	key := user.AvatarKey
+	user.Avatar = config.Env.AvatarPlaceholder
+	user.AvatarKey = ""
+	if err := a.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	deleteAvatarVariants(ctx, a.storage, key)
+
+	return nil
+}
+
+// GetAvatar reads a stored avatar variant. Only keys under
+// domain.AvatarKeyPrefix are served, the storage also holds private files.
+func (a *avatarService) GetAvatar(ctx context.Context, key string) (*domain.AvatarFile, error) {
+	if !strings.HasPrefix(key, domain.AvatarKeyPrefix) {
+		return nil, domain.ErrAvatarNotFound
+	}
+
+	contentType := imageContentType(path.Ext(key))
+	if contentType == "" {
+		return nil, domain.ErrAvatarNotFound
+	}
+
+	content, err := a.storage.Get(ctx, key)
+	if err != nil {
+		if err == client.ErrFileNotFound || err == client.ErrInvalidKey {
+			return nil, domain.ErrAvatarNotFound
+		}
+		return nil, fmt.Errorf("get avatar: %w", err)
+	}
+
+	return &domain.AvatarFile{Content: content, ContentType: contentType}, nil
+}
+
+// putVariants stores every size of the avatar, scaling each one down from the
+// previous, larger one. If a write fails the variants already stored are
+// removed.
+func (a *avatarService) putVariants(ctx context.Context, key string, img image.Image, format string) error {
+	var stored []string
+
+	for _, size := range domain.AvatarSizes {
+		img = utils.SquareThumbnail(img, size.Pixels)
+
+		var buffer bytes.Buffer
+		if err := utils.EncodeImage(&buffer, img, format); err != nil {
+			deleteAvatarFiles(ctx, a.storage, stored)
+			return fmt.Errorf("encode avatar: %w", err)
+		}
+
+		variantKey := domain.AvatarVariantKey(key, size.Name)
+		if err := a.storage.Put(ctx, variantKey, &buffer, imageContentType(path.Ext(key))); err != nil {
+			deleteAvatarFiles(ctx, a.storage, stored)
+			return fmt.Errorf("put avatar: %w", err)
+		}
+
+		stored = append(stored, variantKey)
+	}
+
+	return nil
+}
+
+// deleteAvatarVariants removes every size of the avatar stored at key.
+func deleteAvatarVariants(ctx context.Context, storage client.Storage, key string) {
+	keys := make([]string, len(domain.AvatarSizes))
+	for i, size := range domain.AvatarSizes {
+		keys[i] = domain.AvatarVariantKey(key, size.Name)
+	}
+
+	deleteAvatarFiles(ctx, storage, keys)
+}
+
+// deleteAvatarFiles is best effort: a file left behind is only wasted space.
+func deleteAvatarFiles(ctx context.Context, storage client.Storage, keys []string) {
+	for _, key := range keys {
+		if err := storage.Delete(ctx, key); err != nil {
+			slog.Warn("error to delete avatar file", slog.String("key", key), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (a *avatarService) sessionUser(ctx context.Context) (*domain.User, error) {
+	session, err := a.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.userRepository.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func imageExtension(format string) string {
+	if format == utils.ImageFormatPNG {
+		return ".png"
+	}
+
+	return ".jpg"
+}
+
+func imageContentType(extension string) string {
+	switch extension {
+	case ".png":
+		return "image/png"
+	case ".jpg":
+		return "image/jpeg"
+	default:
+		return ""
+	}
+}
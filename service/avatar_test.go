@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestJPEG draws a landscape image, red on the left and blue on the right,
+// tagged with the given EXIF orientation.
+func newTestJPEG(t *testing.T, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			if x < 20 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00")
+	binary.BigEndian.PutUint16(tiff[18:20], orientation)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	content := buffer.Bytes()
+	return append(append(append([]byte{}, content[:2]...), append(app1, segment...)...), content[2:]...)
+}
+
+func readStoredFile(t *testing.T, storage client.Storage, key string) []byte {
+	file, err := storage.Get(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return content
+}
+
+func TestUploadAvatar_WhenSuccessful_ShouldStoreUprightVariantsWithoutMetadata(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+	storage := newTestStorage(t)
+
+	avatarService := &avatarService{
+		userRepository: userRepoMock,
+		contextService: contextServiceMock,
+		storage:        storage,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	var key string
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	userRepoMock.On("UpdateUser", ctx, mock.MatchedBy(func(updated domain.User) bool {
+		key = updated.AvatarKey
+		return updated.AvatarKey != "" && updated.Avatar == domain.MediaURL(updated.AvatarKey)
+	})).Return(nil)
+
+	response, err := avatarService.UploadAvatar(ctx, bytes.NewReader(newTestJPEG(t, 6)))
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Variants, len(domain.AvatarSizes))
+	assert.Equal(t, response.Variants[domain.DefaultAvatarSize], response.URL)
+
+	for _, size := range domain.AvatarSizes {
+		content := readStoredFile(t, storage, domain.AvatarVariantKey(key, size.Name))
+		assert.NotContains(t, string(content), "Exif")
+
+		img, err := jpeg.Decode(bytes.NewReader(content))
+		assert.NoError(t, err)
+		assert.Equal(t, img.Bounds().Dx(), img.Bounds().Dy())
+
+		// rotated upright the red half is on top
+		top, _, _, _ := img.At(img.Bounds().Dx()/2, 1).RGBA()
+		bottom, _, _, _ := img.At(img.Bounds().Dx()/2, img.Bounds().Dy()-2).RGBA()
+		assert.Greater(t, top, bottom)
+	}
+
+	userRepoMock.AssertExpectations(t)
+}
+
+func TestUploadAvatar_WhenNotAnImage_ShouldReturnErrInvalidImage(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	avatarService := &avatarService{
+		userRepository: userRepoMock,
+		contextService: contextServiceMock,
+		storage:        newTestStorage(t),
+	}
+
+	user := &domain.User{ID: uuid.New()}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+
+	response, err := avatarService.UploadAvatar(ctx, bytes.NewReader([]byte("GIF89a not really")))
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrInvalidImage, err)
+	userRepoMock.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestUploadAvatar_WhenReplacingAvatar_ShouldDeletePreviousVariants(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+	storage := newTestStorage(t)
+
+	avatarService := &avatarService{
+		userRepository: userRepoMock,
+		contextService: contextServiceMock,
+		storage:        storage,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	previousKey := "avatars/" + user.ID.String() + "/previous/medium.jpg"
+	for _, size := range domain.AvatarSizes {
+		assert.NoError(t, storage.Put(ctx, domain.AvatarVariantKey(previousKey, size.Name), bytes.NewReader([]byte("old")), "image/jpeg"))
+	}
+	user.AvatarKey = previousKey
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	userRepoMock.On("UpdateUser", ctx, mock.AnythingOfType("domain.User")).Return(nil)
+
+	_, err := avatarService.UploadAvatar(ctx, bytes.NewReader(newTestJPEG(t, 1)))
+
+	assert.NoError(t, err)
+	for _, size := range domain.AvatarSizes {
+		_, err := storage.Get(ctx, domain.AvatarVariantKey(previousKey, size.Name))
+		assert.Equal(t, client.ErrFileNotFound, err)
+	}
+}
+
+func TestDeleteAvatar_WhenNoAvatarUploaded_ShouldReturnErrAvatarNotFound(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	avatarService := &avatarService{
+		userRepository: userRepoMock,
+		contextService: contextServiceMock,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+
+	err := avatarService.DeleteAvatar(ctx)
+
+	assert.Equal(t, domain.ErrAvatarNotFound, err)
+	userRepoMock.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestGetAvatar_WhenKeyOutsideAvatars_ShouldReturnErrAvatarNotFound(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	avatarService := &avatarService{
+		storage: storage,
+	}
+
+	assert.NoError(t, storage.Put(ctx, "exports/user/export.zip", bytes.NewReader([]byte("private")), "application/zip"))
+
+	file, err := avatarService.GetAvatar(ctx, "avatars/../exports/user/export.zip")
+
+	assert.Nil(t, file)
+	assert.Equal(t, domain.ErrAvatarNotFound, err)
+}
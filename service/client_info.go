@@ -14,6 +14,12 @@ import (
 	"github.com/mssola/user_agent"
 )
 
+// locationLookupTimeout bounds the ipstack request, which runs on the request
+// path of every sign-in.
+const locationLookupTimeout = 2 * time.Second
+
+var locationClient = &http.Client{Timeout: locationLookupTimeout}
+
 type result struct {
 	City    string `json:"city"`
 	Region  string `json:"region_name"`
@@ -37,7 +43,20 @@ func NewClientInfoService(di *internal.Di) (domain.ClientInfoService, error) {
 	}, nil
 }
 
+// GetClientInfo resolves the client of the request in ctx. Within a request
+// that went through middleware.ClientInfo the lookup runs only once and every
+// later call reuses its result.
 func (c *clientInfoService) GetClientInfo(ctx context.Context) (*domain.ClientInfoResponse, error) {
+	if cache, ok := ctx.Value(domain.ClientInfoKey).(*domain.ClientInfoCache); ok {
+		return cache.Resolve(func() (*domain.ClientInfoResponse, error) {
+			return c.resolveClientInfo(ctx)
+		})
+	}
+
+	return c.resolveClientInfo(ctx)
+}
+
+func (c *clientInfoService) resolveClientInfo(ctx context.Context) (*domain.ClientInfoResponse, error) {
 	userAgent, err := c.contextService.GetUserAgent(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get user-agent: %w", err)
@@ -48,40 +67,49 @@ func (c *clientInfoService) GetClientInfo(ctx context.Context) (*domain.ClientIn
 		return nil, fmt.Errorf("get client-ip: %w", err)
 	}
 
-	location, err := getLocation(clientIP)
-	if err != nil {
-		location = "Unknown"
-	}
+	ua := user_agent.New(userAgent)
+	browser, version := ua.Browser()
 
 	clientInfo := domain.ClientInfoResponse{
-		Device:    getDevice(userAgent),
-		Location:  location,
+		Device:    fmt.Sprintf("%s (%s %s)", browser, ua.OS(), version),
+		Location:  "Unknown",
 		LoginTime: time.Now().UTC().Format("January 2, 2006, 3:04 PM"),
 		IP:        clientIP,
+		Browser:   browser,
+		OS:        ua.OSInfo().Name,
 	}
 
-	return &clientInfo, nil
-}
+	location, err := getLocation(ctx, clientIP)
+	if err == nil {
+		clientInfo.Location = fmt.Sprintf("%s, %s, %s", location.City, location.Region, location.Country)
+		clientInfo.Country = location.Country
+	}
 
-func getDevice(userAgent string) string {
-	ua := user_agent.New(userAgent)
-	name, version := ua.Browser()
-	return fmt.Sprintf("%s (%s %s)", name, ua.OS(), version)
+	return &clientInfo, nil
 }
 
-func getLocation(ip string) (string, error) {
+func getLocation(ctx context.Context, ip string) (*result, error) {
 	url := fmt.Sprintf("%s/%s?access_key=%s", config.Env.IpStacker.IpStackBaseURL, ip, config.Env.IpStacker.IpStackAPIKey)
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("fetch location: %w", err)
+		return nil, fmt.Errorf("create location request: %w", err)
+	}
+
+	resp, err := locationClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch location: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch location: unexpected status %d", resp.StatusCode)
+	}
+
 	var result result
 	if err := jsoniter.NewDecoder(resp.Body).Decode(&result); err != nil {
 		slog.Warn("error decoding location response", slog.String("error", err.Error()))
-		return "", fmt.Errorf("decode location response: %w", err)
+		return nil, fmt.Errorf("decode location response: %w", err)
 	}
 
-	return fmt.Sprintf("%s, %s, %s", result.City, result.Region, result.Country), nil
+	return &result, nil
 }
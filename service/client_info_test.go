@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/config/model"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeIPStackServer(t *testing.T, status int) *atomic.Int32 {
+	var lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		w.WriteHeader(status)
+		writeJSON(w, map[string]string{"city": "Recife", "region_name": "Pernambuco", "country_name": "Brazil"})
+	}))
+	t.Cleanup(server.Close)
+
+	previous := config.Env.IpStacker
+	config.Env.IpStacker = model.IpStacker{IpStackBaseURL: server.URL, IpStackAPIKey: "key"}
+	t.Cleanup(func() { config.Env.IpStacker = previous })
+
+	return &lookups
+}
+
+func newTestClientInfoService(ctx context.Context) *clientInfoService {
+	contextServiceMock := new(mocks.ContextService)
+	contextServiceMock.On("GetUserAgent", ctx).Return("Mozilla/5.0 (X11; Linux x86_64) Firefox/120.0", nil)
+	contextServiceMock.On("GetClientIP", ctx).Return("203.0.113.7", nil)
+
+	return &clientInfoService{contextService: contextServiceMock}
+}
+
+func TestGetClientInfo_WhenCalledTwiceInOneRequest_ShouldLookUpLocationOnce(t *testing.T) {
+	lookups := newFakeIPStackServer(t, http.StatusOK)
+	ctx := context.WithValue(context.Background(), domain.ClientInfoKey, new(domain.ClientInfoCache))
+	clientInfoService := newTestClientInfoService(ctx)
+
+	first, err := clientInfoService.GetClientInfo(ctx)
+	assert.NoError(t, err)
+	second, err := clientInfoService.GetClientInfo(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), lookups.Load())
+	assert.Same(t, first, second)
+	assert.Equal(t, "Recife, Pernambuco, Brazil", first.Location)
+	assert.Equal(t, "203.0.113.7", first.IP)
+}
+
+func TestGetClientInfo_WhenContextHasNoCache_ShouldLookUpEveryTime(t *testing.T) {
+	lookups := newFakeIPStackServer(t, http.StatusOK)
+	ctx := context.Background()
+	clientInfoService := newTestClientInfoService(ctx)
+
+	_, _ = clientInfoService.GetClientInfo(ctx)
+	_, _ = clientInfoService.GetClientInfo(ctx)
+
+	assert.Equal(t, int32(2), lookups.Load())
+}
+
+func TestGetClientInfo_WhenLocationLookupFails_ShouldKeepLocationUnknown(t *testing.T) {
+	newFakeIPStackServer(t, http.StatusInternalServerError)
+	ctx := context.Background()
+	clientInfoService := newTestClientInfoService(ctx)
+
+	clientInfo, err := clientInfoService.GetClientInfo(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Unknown", clientInfo.Location)
+	assert.Empty(t, clientInfo.Country)
+}
@@ -0,0 +1,505 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type dataExportService struct {
+	di                      *internal.Di
+	dataExportRepository    domain.DataExportRepository
+	userRepository          domain.UserRepository
+	postRepository          domain.PostRepository
+	likeRepository          domain.LikeRepository
+	followerRepository      domain.FollowerRepository
+	securityEventRepository domain.SecurityEventRepository
+	contextService          domain.ContextService
+	queueService            domain.QueueService
+	storage                 client.Storage
+}
+
+func NewDataExportService(di *internal.Di) (domain.DataExportService, error) {
+	dataExportRepository, err := internal.Invoke[domain.DataExportRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	postRepository, err := internal.Invoke[domain.PostRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	likeRepository, err := internal.Invoke[domain.LikeRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	followerRepository, err := internal.Invoke[domain.FollowerRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	securityEventRepository, err := internal.Invoke[domain.SecurityEventRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	queueService, err := internal.Invoke[domain.QueueService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := internal.Invoke[client.Storage](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataExportService{
+		di:                      di,
+		dataExportRepository:    dataExportRepository,
+		userRepository:          userRepository,
+		postRepository:          postRepository,
+		likeRepository:          likeRepository,
+		followerRepository:      followerRepository,
+		securityEventRepository: securityEventRepository,
+		contextService:          contextService,
+		queueService:            queueService,
+		storage:                 storage,
+	}, nil
+}
+
+// RequestDataExport queues an export of the signed in user's data. Only one
+// export per user can be pending at a time.
+func (d *dataExportService) RequestDataExport(ctx context.Context) (*domain.DataExportResponse, error) {
+	session, err := d.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := d.dataExportRepository.GetPendingExport(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get pending data export: %w", err)
+	}
+
+	if pending != nil {
+		return nil, domain.ErrDataExportInProgress
+	}
+
+	export, err := d.dataExportRepository.CreateExport(ctx, domain.DataExport{
+		UserID: session.UserID,
+		Status: domain.DataExportPending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create data export: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(domain.DataExportTask{ExportID: export.ID})
+	if err != nil {
+		return nil, fmt.Errorf("marshal data export task: %w", err)
+	}
+
+	if err := d.queueService.Publish(domain.QueueDataExport, message); err != nil {
+		d.markFailed(ctx, export)
+		return nil, fmt.Errorf("publish data export task: %w", err)
+	}
+
+	return export.ToDataExportResponse(), nil
+}
+
+// ProcessDataExport builds the archive of a pending export, stores it and
+// emails its owner a download link. Exports that are no longer pending are
+// skipped, so a redelivered task does nothing.
+func (d *dataExportService) ProcessDataExport(ctx context.Context, exportID uuid.UUID) error {
+	export, err := d.dataExportRepository.GetExportByID(ctx, exportID)
+	if err != nil {
+		return fmt.Errorf("get data export: %w", err)
+	}
+
+	if export == nil || export.Status != domain.DataExportPending {
+		return nil
+	}
+
+	if err := d.fulfil(ctx, export); err != nil {
+		d.markFailed(ctx, export)
+		return err
+	}
+
+	return nil
+}
+
+func (d *dataExportService) DownloadDataExport(ctx context.Context, token string) (*domain.DataExportFile, error) {
+	export, err := d.dataExportRepository.GetExportByTokenHash(ctx, secure.HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("get data export by token: %w", err)
+	}
+
+	if export == nil || !export.IsDownloadable() {
+		return nil, domain.ErrDataExportNotFound
+	}
+
+	content, err := d.storage.Get(ctx, export.FileKey)
+	if err != nil {
+		if err == client.ErrFileNotFound {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("get data export file: %w", err)
+	}
+
+	return &domain.DataExportFile{
+		Name:    fmt.Sprintf("social-network-export-%s.zip", export.CreatedAt.Format("2006-01-02")),
+		Content: content,
+	}, nil
+}
+
+// PurgeExpiredExports deletes the files of exports whose link has expired and
+// returns how many were expired.
+func (d *dataExportService) PurgeExpiredExports(ctx context.Context) (int, error) {
+	purged := 0
+
+	for {
+		exports, err := d.dataExportRepository.GetExpiredExports(ctx, time.Now().UTC(), domain.DataExportPurgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("get expired data exports: %w", err)
+		}
+
+		for _, export := range exports {
+			if err := d.storage.Delete(ctx, export.FileKey); err != nil {
+				return purged, fmt.Errorf("delete data export file %s: %w", export.FileKey, err)
+			}
+
+			export.Status = domain.DataExportExpired
+			export.FileKey = ""
+			export.TokenHash = nil
+			if err := d.dataExportRepository.UpdateExport(ctx, *export); err != nil {
+				return purged, fmt.Errorf("update data export: %w", err)
+			}
+			purged++
+		}
+
+		if len(exports) < domain.DataExportPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (d *dataExportService) fulfil(ctx context.Context, export *domain.DataExport) error {
+	user, err := d.userRepository.GetUserByID(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	var archive bytes.Buffer
+	if err := d.writeArchive(ctx, &archive, user); err != nil {
+		return fmt.Errorf("build data export archive: %w", err)
+	}
+
+	fileKey := fmt.Sprintf("exports/%s/%s.zip", user.ID, export.ID)
+	if err := d.storage.Put(ctx, fileKey, &archive, "application/zip"); err != nil {
+		return fmt.Errorf("store data export archive: %w", err)
+	}
+
+	if err := d.sendDownloadLink(ctx, export, user, fileKey); err != nil {
+		d.discardArchive(ctx, export, fileKey)
+		return err
+	}
+
+	return nil
+}
+
+// sendDownloadLink marks the export as ready with a fresh download token and
+// emails the link to its owner.
+func (d *dataExportService) sendDownloadLink(ctx context.Context, export *domain.DataExport, user *domain.User, fileKey string) error {
+	token, err := secure.GenerateToken(32)
+	if err != nil {
+		return fmt.Errorf("generate data export token: %w", err)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Duration(dataExportLinkExp()) * time.Hour)
+	tokenHash := secure.HashToken(token)
+
+	export.Status = domain.DataExportReady
+	export.FileKey = fileKey
+	export.TokenHash = &tokenHash
+	export.ExpiresAt = &expiresAt
+	export.CompletedAt = &now
+	if err := d.dataExportRepository.UpdateExport(ctx, *export); err != nil {
+		return fmt.Errorf("update data export: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(getDataExportEmailTask(user, token))
+	if err != nil {
+		return fmt.Errorf("marshal data export email task: %w", err)
+	}
+
+	if err := d.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		return fmt.Errorf("publish data export email: %w", err)
+	}
+
+	return nil
+}
+
+// discardArchive deletes the archive of an export that is about to be marked
+// as failed, since PurgeExpiredExports only looks at ready ones. If the file
+// cannot be deleted its key is kept, so the account purge still finds it.
+func (d *dataExportService) discardArchive(ctx context.Context, export *domain.DataExport, fileKey string) {
+	if err := d.storage.Delete(ctx, fileKey); err != nil {
+		export.FileKey = fileKey
+		slog.Error("delete data export archive",
+			slog.String("exportId", export.ID.String()),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	export.FileKey = ""
+	export.TokenHash = nil
+	export.ExpiresAt = nil
+	export.CompletedAt = nil
+}
+
+// writeArchive writes a ZIP holding the profile as JSON and every other
+// dataset both as JSON and as CSV.
+func (d *dataExportService) writeArchive(ctx context.Context, archive *bytes.Buffer, user *domain.User) error {
+	posts, err := d.postRepository.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get posts: %w", err)
+	}
+
+	likes, err := d.likeRepository.GetLikesByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get likes: %w", err)
+	}
+
+	followers, err := d.followerRepository.GetFollowers(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get followers: %w", err)
+	}
+
+	followings, err := d.followerRepository.GetFollowings(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get followings: %w", err)
+	}
+
+	securityEvents, err := d.getSecurityEvents(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get security events: %w", err)
+	}
+
+	zipWriter := zip.NewWriter(archive)
+
+	if err := writeJSONFile(zipWriter, "profile.json", user.ToDataExportProfile()); err != nil {
+		return err
+	}
+
+	exportPosts := make([]domain.DataExportPost, len(posts))
+	for i, post := range posts {
+		exportPosts[i] = domain.DataExportPost{
+			ID:        post.ID,
+			Title:     post.Title,
+			Content:   post.Content,
+			Likes:     post.Likes,
+			CreatedAt: post.CreatedAt,
+			UpdatedAt: post.UpdatedAt,
+		}
+	}
+	if err := writeDataset(zipWriter, "posts", exportPosts,
+		[]string{"id", "title", "content", "likes", "createdAt", "updatedAt"},
+		func(post domain.DataExportPost) []string {
+			return []string{post.ID.String(), post.Title, post.Content, strconv.FormatUint(post.Likes, 10), formatExportTime(post.CreatedAt), formatExportTime(post.UpdatedAt)}
+		}); err != nil {
+		return err
+	}
+
+	exportLikes := make([]domain.DataExportLike, len(likes))
+	for i, like := range likes {
+		exportLikes[i] = domain.DataExportLike{PostID: like.PostID, CreatedAt: like.CreatedAt}
+	}
+	if err := writeDataset(zipWriter, "likes", exportLikes,
+		[]string{"postId", "createdAt"},
+		func(like domain.DataExportLike) []string {
+			return []string{like.PostID.String(), formatExportTime(like.CreatedAt)}
+		}); err != nil {
+		return err
+	}
+
+	exportFollowers := make([]domain.DataExportFollow, len(followers))
+	for i, follower := range followers {
+		exportFollowers[i] = newDataExportFollow(follower.Follower, follower.CreatedAt)
+	}
+	if err := writeFollows(zipWriter, "followers", exportFollowers); err != nil {
+		return err
+	}
+
+	exportFollowings := make([]domain.DataExportFollow, len(followings))
+	for i, following := range followings {
+		exportFollowings[i] = newDataExportFollow(following.User, following.CreatedAt)
+	}
+	if err := writeFollows(zipWriter, "followings", exportFollowings); err != nil {
+		return err
+	}
+
+	if err := writeDataset(zipWriter, "security_events", securityEvents,
+		[]string{"id", "type", "device", "location", "ip", "details", "createdAt"},
+		func(event *domain.SecurityEventResponse) []string {
+			return []string{event.ID.String(), string(event.Type), event.Device, event.Location, event.IP, event.Details, formatExportTime(event.CreatedAt)}
+		}); err != nil {
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+func (d *dataExportService) getSecurityEvents(ctx context.Context, userID uuid.UUID) ([]*domain.SecurityEventResponse, error) {
+	var events []*domain.SecurityEventResponse
+
+	for page := 1; ; page++ {
+		paginated, err := d.securityEventRepository.GetEvents(ctx, userID, page, domain.DataExportPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range paginated.Rows {
+			events = append(events, event.ToSecurityEventResponse())
+		}
+
+		if page >= paginated.TotalPages {
+			return events, nil
+		}
+	}
+}
+
+// markFailed records that an export will not complete so that the user can
+// request a new one. It is best-effort, the original error is what matters.
+func (d *dataExportService) markFailed(ctx context.Context, export *domain.DataExport) {
+	export.Status = domain.DataExportFailed
+	if err := d.dataExportRepository.UpdateExport(ctx, *export); err != nil {
+		slog.Error("mark data export as failed",
+			slog.String("exportId", export.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func newDataExportFollow(user domain.User, followedAt time.Time) domain.DataExportFollow {
+	return domain.DataExportFollow{
+		UserID:    user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: followedAt,
+	}
+}
+
+func writeFollows(zipWriter *zip.Writer, name string, follows []domain.DataExportFollow) error {
+	return writeDataset(zipWriter, name, follows,
+		[]string{"userId", "username", "firstName", "lastName", "createdAt"},
+		func(follow domain.DataExportFollow) []string {
+			return []string{follow.UserID.String(), follow.Username, follow.FirstName, follow.LastName, formatExportTime(follow.CreatedAt)}
+		})
+}
+
+// writeDataset adds <name>.json and <name>.csv to the archive.
+func writeDataset[T any](zipWriter *zip.Writer, name string, rows []T, header []string, record func(T) []string) error {
+	if err := writeJSONFile(zipWriter, name+".json", rows); err != nil {
+		return err
+	}
+
+	file, err := zipWriter.Create(name + ".csv")
+	if err != nil {
+		return fmt.Errorf("create %s.csv: %w", name, err)
+	}
+
+	csvWriter := csv.NewWriter(file)
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("write %s.csv: %w", name, err)
+	}
+
+	for _, row := range rows {
+		if err := csvWriter.Write(record(row)); err != nil {
+			return fmt.Errorf("write %s.csv: %w", name, err)
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("write %s.csv: %w", name, err)
+	}
+
+	return nil
+}
+
+func writeJSONFile(zipWriter *zip.Writer, name string, value any) error {
+	file, err := zipWriter.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+
+	encoder := jsoniter.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func dataExportLinkExp() int {
+	if config.Env.DataExport.LinkExp <= 0 {
+		return domain.DefaultDataExportLinkExp
+	}
+
+	return config.Env.DataExport.LinkExp
+}
+
+func getDataExportEmailTask(user *domain.User, token string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.DataExportAvailable,
+		Subject:  "Your Data Export Is Ready",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":     fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"link":     fmt.Sprintf("%s/data-export?token=%s", config.Env.FrontURL, token),
+			"duration": strconv.Itoa(dataExportLinkExp()),
+		},
+	}
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestStorage(t *testing.T) client.Storage {
+	config.Env.Storage.LocalDir = t.TempDir()
+	t.Cleanup(func() { config.Env.Storage.LocalDir = "" })
+
+	storage, err := client.NewLocalStorage(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return storage
+}
+
+func TestRequestDataExport_WhenExportIsPending_ShouldReturnErrDataExportInProgress(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	queueServiceMock := new(mocks.QueueService)
+
+	dataExportService := &dataExportService{
+		contextService:       contextServiceMock,
+		dataExportRepository: dataExportRepoMock,
+		queueService:         queueServiceMock,
+	}
+
+	userID := uuid.New()
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	dataExportRepoMock.On("GetPendingExport", ctx, userID).Return(&domain.DataExport{ID: uuid.New(), UserID: userID, Status: domain.DataExportPending}, nil)
+
+	response, err := dataExportService.RequestDataExport(ctx)
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrDataExportInProgress, err)
+	dataExportRepoMock.AssertNotCalled(t, "CreateExport", mock.Anything, mock.Anything)
+	queueServiceMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRequestDataExport_WhenPublishFails_ShouldMarkExportAsFailed(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	queueServiceMock := new(mocks.QueueService)
+
+	dataExportService := &dataExportService{
+		contextService:       contextServiceMock,
+		dataExportRepository: dataExportRepoMock,
+		queueService:         queueServiceMock,
+	}
+
+	userID := uuid.New()
+	export := &domain.DataExport{ID: uuid.New(), UserID: userID, Status: domain.DataExportPending}
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	dataExportRepoMock.On("GetPendingExport", ctx, userID).Return(nil, nil)
+	dataExportRepoMock.On("CreateExport", ctx, mock.MatchedBy(func(e domain.DataExport) bool {
+		return e.UserID == userID && e.Status == domain.DataExportPending
+	})).Return(export, nil)
+	queueServiceMock.On("Publish", domain.QueueDataExport, mock.Anything).Return(errors.New("broker down"))
+	dataExportRepoMock.On("UpdateExport", ctx, mock.MatchedBy(func(e domain.DataExport) bool {
+		return e.ID == export.ID && e.Status == domain.DataExportFailed
+	})).Return(nil)
+
+	response, err := dataExportService.RequestDataExport(ctx)
+
+	assert.Nil(t, response)
+	assert.Error(t, err)
+	dataExportRepoMock.AssertExpectations(t)
+}
+
+func TestRequestDataExport_WhenNoExportIsPending_ShouldQueueExport(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	queueServiceMock := new(mocks.QueueService)
+
+	dataExportService := &dataExportService{
+		contextService:       contextServiceMock,
+		dataExportRepository: dataExportRepoMock,
+		queueService:         queueServiceMock,
+	}
+
+	userID := uuid.New()
+	export := &domain.DataExport{ID: uuid.New(), UserID: userID, Status: domain.DataExportPending}
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	dataExportRepoMock.On("GetPendingExport", ctx, userID).Return(nil, nil)
+	dataExportRepoMock.On("CreateExport", ctx, mock.AnythingOfType("domain.DataExport")).Return(export, nil)
+	queueServiceMock.On("Publish", domain.QueueDataExport, mock.MatchedBy(func(message []byte) bool {
+		var task domain.DataExportTask
+		return jsoniter.Unmarshal(message, &task) == nil && task.ExportID == export.ID
+	})).Return(nil)
+
+	response, err := dataExportService.RequestDataExport(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, export.ID, response.ID)
+	assert.Equal(t, domain.DataExportPending, response.Status)
+	queueServiceMock.AssertExpectations(t)
+}
+
+func TestProcessDataExport_WhenExportIsPending_ShouldStoreArchiveAndEmailLink(t *testing.T) {
+	ctx := context.Background()
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	userRepoMock := new(mocks.UserRepository)
+	postRepoMock := new(mocks.PostRepository)
+	likeRepoMock := new(mocks.LikeRepository)
+	followerRepoMock := new(mocks.FollowerRepository)
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	queueServiceMock := new(mocks.QueueService)
+	storage := newTestStorage(t)
+
+	dataExportService := &dataExportService{
+		dataExportRepository:    dataExportRepoMock,
+		userRepository:          userRepoMock,
+		postRepository:          postRepoMock,
+		likeRepository:          likeRepoMock,
+		followerRepository:      followerRepoMock,
+		securityEventRepository: securityEventRepoMock,
+		queueService:            queueServiceMock,
+		storage:                 storage,
+	}
+
+	user := &domain.User{ID: uuid.New(), FirstName: "John", LastName: "Doe", Username: "johndoe", Email: "john@example.com"}
+	export := &domain.DataExport{ID: uuid.New(), UserID: user.ID, Status: domain.DataExportPending}
+	follower := &domain.Follower{UserID: user.ID, Follower: domain.User{ID: uuid.New(), Username: "janedoe"}, CreatedAt: time.Now()}
+
+	dataExportRepoMock.On("GetExportByID", ctx, export.ID).Return(export, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	postRepoMock.On("GetByUserID", ctx, user.ID).Return([]*domain.Post{{ID: uuid.New(), Title: "Hello", Content: "Hello, world", CreatedAt: time.Now()}}, nil)
+	likeRepoMock.On("GetLikesByUserID", ctx, user.ID).Return([]*domain.Like{{PostID: uuid.New(), CreatedAt: time.Now()}}, nil)
+	followerRepoMock.On("GetFollowers", ctx, user.ID).Return([]*domain.Follower{follower}, nil)
+	followerRepoMock.On("GetFollowings", ctx, user.ID).Return([]*domain.Follower{}, nil)
+	securityEventRepoMock.On("GetEvents", ctx, user.ID, 1, domain.DataExportPageSize).Return(&domain.Pagination[*domain.SecurityEvent]{
+		TotalPages: 1,
+		Rows:       []*domain.SecurityEvent{{ID: uuid.New(), UserID: user.ID, Type: domain.SecurityEventSignInSuccess}},
+	}, nil)
+
+	var updated domain.DataExport
+	dataExportRepoMock.On("UpdateExport", ctx, mock.AnythingOfType("domain.DataExport")).Return(nil).Run(func(args mock.Arguments) {
+		updated = args.Get(1).(domain.DataExport)
+	})
+
+	var emailTask domain.EmailPayloadTask
+	queueServiceMock.On("Publish", domain.QueueSendEmail, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		_ = jsoniter.Unmarshal(args.Get(1).([]byte), &emailTask)
+	})
+
+	err := dataExportService.ProcessDataExport(ctx, export.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DataExportReady, updated.Status)
+	assert.NotNil(t, updated.TokenHash)
+	assert.True(t, updated.IsDownloadable())
+	assert.Equal(t, domain.DataExportAvailable, emailTask.Template)
+
+	link := emailTask.Params["link"]
+	token := link[strings.Index(link, "token=")+len("token="):]
+	assert.Equal(t, secure.HashToken(token), *updated.TokenHash)
+
+	file, err := storage.Get(ctx, updated.FileKey)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"profile.json",
+		"posts.json", "posts.csv",
+		"likes.json", "likes.csv",
+		"followers.json", "followers.csv",
+		"followings.json", "followings.csv",
+		"security_events.json", "security_events.csv",
+	}, names)
+}
+
+func TestProcessDataExport_WhenBuildingFails_ShouldMarkExportAsFailed(t *testing.T) {
+	ctx := context.Background()
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	userRepoMock := new(mocks.UserRepository)
+	postRepoMock := new(mocks.PostRepository)
+	queueServiceMock := new(mocks.QueueService)
+
+	dataExportService := &dataExportService{
+		dataExportRepository: dataExportRepoMock,
+		userRepository:       userRepoMock,
+		postRepository:       postRepoMock,
+		queueService:         queueServiceMock,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	export := &domain.DataExport{ID: uuid.New(), UserID: user.ID, Status: domain.DataExportPending}
+
+	dataExportRepoMock.On("GetExportByID", ctx, export.ID).Return(export, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	postRepoMock.On("GetByUserID", ctx, user.ID).Return(nil, errors.New("database error"))
+	dataExportRepoMock.On("UpdateExport", ctx, mock.MatchedBy(func(e domain.DataExport) bool {
+		return e.Status == domain.DataExportFailed
+	})).Return(nil)
+
+	err := dataExportService.ProcessDataExport(ctx, export.ID)
+
+	assert.Error(t, err)
+	dataExportRepoMock.AssertExpectations(t)
+	queueServiceMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestProcessDataExport_WhenEmailPublishFails_ShouldDeleteArchiveAndMarkExportAsFailed(t *testing.T) {
+	ctx := context.Background()
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	userRepoMock := new(mocks.UserRepository)
+	postRepoMock := new(mocks.PostRepository)
+	likeRepoMock := new(mocks.LikeRepository)
+	followerRepoMock := new(mocks.FollowerRepository)
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	queueServiceMock := new(mocks.QueueService)
+	storage := newTestStorage(t)
+
+	dataExportService := &dataExportService{
+		dataExportRepository:    dataExportRepoMock,
+		userRepository:          userRepoMock,
+		postRepository:          postRepoMock,
+		likeRepository:          likeRepoMock,
+		followerRepository:      followerRepoMock,
+		securityEventRepository: securityEventRepoMock,
+		queueService:            queueServiceMock,
+		storage:                 storage,
+	}
+
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com"}
+	export := &domain.DataExport{ID: uuid.New(), UserID: user.ID, Status: domain.DataExportPending}
+
+	dataExportRepoMock.On("GetExportByID", ctx, export.ID).Return(export, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	postRepoMock.On("GetByUserID", ctx, user.ID).Return([]*domain.Post{}, nil)
+	likeRepoMock.On("GetLikesByUserID", ctx, user.ID).Return([]*domain.Like{}, nil)
+	followerRepoMock.On("GetFollowers", ctx, user.ID).Return([]*domain.Follower{}, nil)
+	followerRepoMock.On("GetFollowings", ctx, user.ID).Return([]*domain.Follower{}, nil)
+	securityEventRepoMock.On("GetEvents", ctx, user.ID, 1, domain.DataExportPageSize).Return(&domain.Pagination[*domain.SecurityEvent]{TotalPages: 1}, nil)
+
+	var updates []domain.DataExport
+	var fileKey string
+	dataExportRepoMock.On("UpdateExport", ctx, mock.AnythingOfType("domain.DataExport")).Return(nil).Run(func(args mock.Arguments) {
+		update := args.Get(1).(domain.DataExport)
+		if update.FileKey != "" {
+			fileKey = update.FileKey
+		}
+		updates = append(updates, update)
+	})
+	queueServiceMock.On("Publish", domain.QueueSendEmail, mock.Anything).Return(errors.New("broker down"))
+
+	err := dataExportService.ProcessDataExport(ctx, export.ID)
+
+	assert.ErrorContains(t, err, "broker down")
+	if assert.Len(t, updates, 2) {
+		failed := updates[1]
+		assert.Equal(t, domain.DataExportFailed, failed.Status)
+		assert.Empty(t, failed.FileKey)
+		assert.Nil(t, failed.TokenHash)
+		assert.False(t, failed.IsDownloadable())
+	}
+
+	_, err = storage.Get(ctx, fileKey)
+	assert.ErrorIs(t, err, client.ErrFileNotFound)
+}
+
+func TestDownloadDataExport_WhenLinkExpired_ShouldReturnErrDataExportNotFound(t *testing.T) {
+	ctx := context.Background()
+	dataExportRepoMock := new(mocks.DataExportRepository)
+
+	dataExportService := &dataExportService{
+		dataExportRepository: dataExportRepoMock,
+		storage:              newTestStorage(t),
+	}
+
+	expiresAt := time.Now().UTC().Add(-time.Minute)
+	dataExportRepoMock.On("GetExportByTokenHash", ctx, secure.HashToken("token")).Return(&domain.DataExport{
+		ID:        uuid.New(),
+		Status:    domain.DataExportReady,
+		FileKey:   "exports/file.zip",
+		ExpiresAt: &expiresAt,
+	}, nil)
+
+	file, err := dataExportService.DownloadDataExport(ctx, "token")
+
+	assert.Nil(t, file)
+	assert.Equal(t, domain.ErrDataExportNotFound, err)
+}
+
+func TestPurgeExpiredExports_WhenExportsExpired_ShouldDeleteFilesAndMarkExpired(t *testing.T) {
+	ctx := context.Background()
+	dataExportRepoMock := new(mocks.DataExportRepository)
+	storage := newTestStorage(t)
+
+	dataExportService := &dataExportService{
+		dataExportRepository: dataExportRepoMock,
+		storage:              storage,
+	}
+
+	tokenHash := secure.HashToken("token")
+	export := &domain.DataExport{ID: uuid.New(), Status: domain.DataExportReady, FileKey: "exports/user/export.zip", TokenHash: &tokenHash}
+	assert.NoError(t, storage.Put(ctx, export.FileKey, strings.NewReader("zip"), "application/zip"))
+
+	dataExportRepoMock.On("GetExpiredExports", ctx, mock.AnythingOfType("time.Time"), domain.DataExportPurgeBatchSize).Return([]*domain.DataExport{export}, nil)
+	dataExportRepoMock.On("UpdateExport", ctx, mock.MatchedBy(func(e domain.DataExport) bool {
+		return e.Status == domain.DataExportExpired && e.TokenHash == nil && e.FileKey == ""
+	})).Return(nil)
+
+	purged, err := dataExportService.PurgeExpiredExports(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = storage.Get(ctx, "exports/user/export.zip")
+	assert.Equal(t, client.ErrFileNotFound, err)
+	dataExportRepoMock.AssertExpectations(t)
+}
@@ -53,7 +53,7 @@ func (e *emailService) SendEmail(ctx context.Context, task domain.EmailPayloadTa
 }
 
 func renderTemplate(templateName domain.EmailTemplate, params map[string]string) (string, error) {
-	content, err := os.ReadFile(filepath.Join("./templates", string(templateName)))
+	content, err := os.ReadFile(filepath.Join("./templates", string(templateName)+".html"))
 	if err != nil {
 		return "", errors.New("read email template: " + err.Error())
 	}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type emailConfirmationService struct {
+	di                          *internal.Di
+	emailConfirmationRepository domain.EmailConfirmationRepository
+	queueService                domain.QueueService
+}
+
+func NewEmailConfirmationService(di *internal.Di) (domain.EmailConfirmationService, error) {
+	emailConfirmationRepository, err := internal.Invoke[domain.EmailConfirmationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	queueService, err := internal.Invoke[domain.QueueService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailConfirmationService{
+		di:                          di,
+		emailConfirmationRepository: emailConfirmationRepository,
+		queueService:                queueService,
+	}, nil
+}
+
+func (e *emailConfirmationService) SendConfirmation(ctx context.Context, user domain.User) error {
+	tokenID := uuid.NewString()
+
+	token, err := e.createToken(user.ID, tokenID)
+	if err != nil {
+		return fmt.Errorf("create email confirmation token: %w", err)
+	}
+
+	if err := e.emailConfirmationRepository.SetTokenID(ctx, user.ID, tokenID); err != nil {
+		return fmt.Errorf("set email confirmation token: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(getEmailConfirmationTask(&user, token))
+	if err != nil {
+		return fmt.Errorf("marshal email confirmation task: %w", err)
+	}
+
+	if err := e.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		return fmt.Errorf("publish email confirmation: %w", err)
+	}
+
+	return nil
+}
+
+func (e *emailConfirmationService) ResendConfirmation(ctx context.Context, user domain.User) error {
+	attempts, err := e.emailConfirmationRepository.IncrementResendAttempts(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("increment email confirmation resend attempts: %w", err)
+	}
+
+	if attempts > domain.MaxEmailConfirmationResends {
+		return domain.ErrEmailConfirmationResendLimit
+	}
+
+	return e.SendConfirmation(ctx, user)
+}
+
+func (e *emailConfirmationService) Confirm(ctx context.Context, token string) (uuid.UUID, error) {
+	userID, tokenID, err := e.extractToken(token)
+	if err != nil {
+		return uuid.Nil, domain.ErrEmailConfirmationTokenInvalid
+	}
+
+	storedTokenID, err := e.emailConfirmationRepository.GetTokenID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("get email confirmation token: %w", err)
+	}
+
+	if storedTokenID == "" || storedTokenID != tokenID {
+		return uuid.Nil, domain.ErrEmailConfirmationTokenInvalid
+	}
+
+	if err := e.emailConfirmationRepository.DeleteTokenID(ctx, userID); err != nil {
+		return uuid.Nil, fmt.Errorf("delete email confirmation token: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (e *emailConfirmationService) createToken(userID uuid.UUID, tokenID string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":     userID.String(),
+		"jti":     tokenID,
+		"purpose": domain.EmailConfirmationPurpose,
+		"iat":     now.Unix(),
+		"exp":     now.Add(time.Duration(config.Env.Cache.EmailConfirmationExp) * time.Hour).Unix(),
+	}
+
+	return config.Env.KeyRing.Sign(claims)
+}
+
+func (e *emailConfirmationService) extractToken(tokenString string) (uuid.UUID, string, error) {
+	token, err := jwt.Parse(tokenString, config.Env.KeyRing.Keyfunc)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("parse token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return uuid.Nil, "", domain.ErrTokenInvalid
+	}
+
+	if purpose, _ := claims["purpose"].(string); purpose != domain.EmailConfirmationPurpose {
+		return uuid.Nil, "", domain.ErrTokenInvalid
+	}
+
+	subject, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("parse subject: %w", err)
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return uuid.Nil, "", domain.ErrTokenInvalid
+	}
+
+	return userID, tokenID, nil
+}
+
+func getEmailConfirmationTask(user *domain.User, token string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.EmailConfirmation,
+		Subject:  "Confirm Your Email",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":     fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"link":     fmt.Sprintf("%s/confirm-email?token=%s", config.Env.FrontURL, token),
+			"duration": strconv.Itoa(config.Env.Cache.EmailConfirmationExp),
+		},
+	}
+}
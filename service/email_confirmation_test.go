@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTestKeys(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyRing, err := secure.NewKeyRing("test-key", privateKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Env.KeyRing = keyRing
+	config.Env.Cache.SessionExp = 1
+	config.Env.Cache.AccessTokenExp = 15
+	config.Env.Cache.EmailConfirmationExp = 1
+}
+
+func TestSendConfirmation_WhenSuccessful_ShouldStoreTokenAndPublishEmail(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	repoMock := new(mocks.EmailConfirmationRepository)
+	queueServiceMock := new(mocks.QueueService)
+
+	service := &emailConfirmationService{
+		emailConfirmationRepository: repoMock,
+		queueService:                queueServiceMock,
+	}
+
+	user := domain.User{ID: uuid.New(), Email: "gabriel@test.com"}
+
+	repoMock.On("SetTokenID", ctx, user.ID, mock.AnythingOfType("string")).Return(nil)
+	queueServiceMock.On("Publish", domain.QueueSendEmail, mock.Anything).Return(nil)
+
+	err := service.SendConfirmation(ctx, user)
+
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	queueServiceMock.AssertExpectations(t)
+}
+
+func TestConfirm_WhenTokenIsValid_ShouldReturnUserIDAndDeleteToken(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	repoMock := new(mocks.EmailConfirmationRepository)
+
+	service := &emailConfirmationService{
+		emailConfirmationRepository: repoMock,
+	}
+
+	userID := uuid.New()
+	tokenID := uuid.NewString()
+	token, err := service.createToken(userID, tokenID)
+	assert.NoError(t, err)
+
+	repoMock.On("GetTokenID", ctx, userID).Return(tokenID, nil)
+	repoMock.On("DeleteTokenID", ctx, userID).Return(nil)
+
+	confirmedUserID, err := service.Confirm(ctx, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, confirmedUserID)
+	repoMock.AssertExpectations(t)
+}
+
+func TestConfirm_WhenTokenWasReplaced_ShouldReturnErrorTokenInvalid(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	repoMock := new(mocks.EmailConfirmationRepository)
+
+	service := &emailConfirmationService{
+		emailConfirmationRepository: repoMock,
+	}
+
+	userID := uuid.New()
+	token, err := service.createToken(userID, uuid.NewString())
+	assert.NoError(t, err)
+
+	repoMock.On("GetTokenID", ctx, userID).Return(uuid.NewString(), nil)
+
+	confirmedUserID, err := service.Confirm(ctx, token)
+
+	assert.Equal(t, domain.ErrEmailConfirmationTokenInvalid, err)
+	assert.Equal(t, uuid.Nil, confirmedUserID)
+	repoMock.AssertNotCalled(t, "DeleteTokenID", ctx, userID)
+}
+
+func TestConfirm_WhenTokenIsMalformed_ShouldReturnErrorTokenInvalid(t *testing.T) {
+	setupTestKeys(t)
+	service := &emailConfirmationService{}
+
+	userID, err := service.Confirm(context.Background(), "malformed-token")
+
+	assert.Equal(t, domain.ErrEmailConfirmationTokenInvalid, err)
+	assert.Equal(t, uuid.Nil, userID)
+}
+
+func TestResendConfirmation_WhenLimitExceeded_ShouldReturnErrorResendLimit(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.EmailConfirmationRepository)
+
+	service := &emailConfirmationService{
+		emailConfirmationRepository: repoMock,
+	}
+
+	user := domain.User{ID: uuid.New()}
+	repoMock.On("IncrementResendAttempts", ctx, user.ID).Return(int64(domain.MaxEmailConfirmationResends+1), nil)
+
+	err := service.ResendConfirmation(ctx, user)
+
+	assert.Equal(t, domain.ErrEmailConfirmationResendLimit, err)
+	repoMock.AssertNotCalled(t, "SetTokenID", ctx, user.ID, mock.Anything)
+}
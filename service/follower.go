@@ -11,9 +11,11 @@ import (
 )
 
 type followerService struct {
-	di                 *internal.Di
-	followerRepository domain.FollowerRepository
-	userRepository     domain.UserRepository
+	di                        *internal.Di
+	followerRepository        domain.FollowerRepository
+	userRepository            domain.UserRepository
+	userRestrictionRepository domain.UserRestrictionRepository
+	memoryCacheRepository     domain.MemoryCacheRepository
 }
 
 func NewFollowerService(di *internal.Di) (domain.FollowerService, error) {
@@ -27,10 +29,22 @@ func NewFollowerService(di *internal.Di) (domain.FollowerService, error) {
 		return nil, err
 	}
 
+	userRestrictionRepository, err := internal.Invoke[domain.UserRestrictionRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryCacheRepository, err := internal.Invoke[domain.MemoryCacheRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &followerService{
-		di:                 di,
-		followerRepository: followerRepository,
-		userRepository:     userRepository,
+		di:                        di,
+		followerRepository:        followerRepository,
+		userRepository:            userRepository,
+		userRestrictionRepository: userRestrictionRepository,
+		memoryCacheRepository:     memoryCacheRepository,
 	}, nil
 }
 
@@ -49,10 +63,21 @@ func (f *followerService) FollowUser(ctx context.Context, userId uuid.UUID) erro
 		return fmt.Errorf("error to get follower by ID: %w", err)
 	}
 
-	if user == nil {
+	// blocked and deactivated accounts are hidden everywhere else, so they
+	// cannot be followed either
+	if user == nil || user.CheckStatus() != nil {
 		return domain.ErrFollowerNotFound
 	}
 
+	blocked, err := f.userRestrictionRepository.HasBlock(ctx, session.UserID, userId)
+	if err != nil {
+		return fmt.Errorf("error to check block: %w", err)
+	}
+
+	if blocked {
+		return domain.ErrFollowBlocked
+	}
+
 	following, err := f.followerRepository.GetFollower(ctx, userId, session.UserID)
 	if err != nil {
 		return fmt.Errorf("error to get follower: %w", err)
@@ -71,6 +96,8 @@ func (f *followerService) FollowUser(ctx context.Context, userId uuid.UUID) erro
 		return fmt.Errorf("error to create follower: %w", err)
 	}
 
+	syncFollowCounts(ctx, f.memoryCacheRepository, *following, 1)
+
 	return nil
 }
 
@@ -106,6 +133,8 @@ func (f *followerService) UnfollowUser(ctx context.Context, userID uuid.UUID) er
 		return fmt.Errorf("error to delete follower: %w", err)
 	}
 
+	syncFollowCounts(ctx, f.memoryCacheRepository, *follower, -1)
+
 	return nil
 }
 
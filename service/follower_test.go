@@ -16,10 +16,14 @@ func TestFollowUser_WhenSuccessful_ShouldFollowUser(t *testing.T) {
 	ctx := context.Background()
 	userRepoMock := new(mocks.UserRepository)
 	followerRepoMock := new(mocks.FollowerRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
 
 	followerService := &followerService{
-		userRepository:     userRepoMock,
-		followerRepository: followerRepoMock,
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
+		memoryCacheRepository:     memoryCacheRepoMock,
 	}
 
 	userID := uuid.New()
@@ -27,14 +31,19 @@ func TestFollowUser_WhenSuccessful_ShouldFollowUser(t *testing.T) {
 	ctx = context.WithValue(ctx, domain.SessionKey, session)
 
 	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	userRestrictionRepoMock.On("HasBlock", ctx, session.UserID, userID).Return(false, nil)
 	followerRepoMock.On("GetFollower", ctx, userID, session.UserID).Return(nil, nil)
 	followerRepoMock.On("CreateFollower", ctx, mock.Anything).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, userID, domain.ProfileCountFollowers, int64(1)).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, session.UserID, domain.ProfileCountFollowings, int64(1)).Return(nil)
 
 	err := followerService.FollowUser(ctx, userID)
 
 	assert.NoError(t, err)
 	userRepoMock.AssertExpectations(t)
 	followerRepoMock.AssertExpectations(t)
+	userRestrictionRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
 }
 
 func TestFollowUser_WhenUserFollowsItself_ShouldReturnError(t *testing.T) {
@@ -77,6 +86,33 @@ func TestFollowUser_WhenUserNotFound_ShouldReturnError(t *testing.T) {
 	userRepoMock.AssertExpectations(t)
 }
 
+func TestFollowUser_WhenUserIsBlockedOrInactive_ShouldReturnErrFollowerNotFound(t *testing.T) {
+	for _, status := range []domain.UserStatus{domain.Block, domain.Inactive} {
+		ctx := context.Background()
+		userRepoMock := new(mocks.UserRepository)
+		followerRepoMock := new(mocks.FollowerRepository)
+		memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+
+		followerService := &followerService{
+			userRepository:        userRepoMock,
+			followerRepository:    followerRepoMock,
+			memoryCacheRepository: memoryCacheRepoMock,
+		}
+
+		userID := uuid.New()
+		session := &domain.Session{UserID: uuid.New()}
+		ctx = context.WithValue(ctx, domain.SessionKey, session)
+
+		userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID, Status: status}, nil)
+
+		err := followerService.FollowUser(ctx, userID)
+
+		assert.Equal(t, domain.ErrFollowerNotFound, err)
+		followerRepoMock.AssertNotCalled(t, "CreateFollower", mock.Anything, mock.Anything)
+		memoryCacheRepoMock.AssertNotCalled(t, "IncrementProfileCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
 func TestFollowUser_WhenSessionNotFound_ShouldReturnError(t *testing.T) {
 	ctx := context.Background()
 	userRepoMock := new(mocks.UserRepository)
@@ -94,14 +130,42 @@ func TestFollowUser_WhenSessionNotFound_ShouldReturnError(t *testing.T) {
 	assert.Equal(t, domain.ErrSessionNotFound, err)
 }
 
+func TestFollowUser_WhenEitherUserBlocked_ShouldReturnErrFollowBlocked(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	followerRepoMock := new(mocks.FollowerRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+
+	followerService := &followerService{
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
+	}
+
+	userID := uuid.New()
+	session := &domain.Session{UserID: uuid.New()}
+	ctx = context.WithValue(ctx, domain.SessionKey, session)
+
+	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	userRestrictionRepoMock.On("HasBlock", ctx, session.UserID, userID).Return(true, nil)
+
+	err := followerService.FollowUser(ctx, userID)
+
+	assert.Equal(t, domain.ErrFollowBlocked, err)
+	followerRepoMock.AssertNotCalled(t, "CreateFollower", mock.Anything, mock.Anything)
+	userRestrictionRepoMock.AssertExpectations(t)
+}
+
 func TestFollowUser_WhenFollowerAlreadyExists_ShouldReturnError(t *testing.T) {
 	ctx := context.Background()
 	userRepoMock := new(mocks.UserRepository)
 	followerRepoMock := new(mocks.FollowerRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
 
 	followerService := &followerService{
-		userRepository:     userRepoMock,
-		followerRepository: followerRepoMock,
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
 	}
 
 	userID := uuid.New()
@@ -109,6 +173,7 @@ func TestFollowUser_WhenFollowerAlreadyExists_ShouldReturnError(t *testing.T) {
 	ctx = context.WithValue(ctx, domain.SessionKey, session)
 
 	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	userRestrictionRepoMock.On("HasBlock", ctx, session.UserID, userID).Return(false, nil)
 	followerRepoMock.On("GetFollower", ctx, userID, session.UserID).Return(&domain.Follower{UserID: userID, FollowerID: session.UserID}, nil)
 
 	err := followerService.FollowUser(ctx, userID)
@@ -122,10 +187,12 @@ func TestFollowUser_WhenCreateFollowerFails_ShouldReturnError(t *testing.T) {
 	ctx := context.Background()
 	userRepoMock := new(mocks.UserRepository)
 	followerRepoMock := new(mocks.FollowerRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
 
 	followerService := &followerService{
-		userRepository:     userRepoMock,
-		followerRepository: followerRepoMock,
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
 	}
 
 	userID := uuid.New()
@@ -133,6 +200,7 @@ func TestFollowUser_WhenCreateFollowerFails_ShouldReturnError(t *testing.T) {
 	ctx = context.WithValue(ctx, domain.SessionKey, session)
 
 	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+	userRestrictionRepoMock.On("HasBlock", ctx, session.UserID, userID).Return(false, nil)
 	followerRepoMock.On("GetFollower", ctx, userID, session.UserID).Return(nil, nil)
 	followerRepoMock.On("CreateFollower", ctx, mock.Anything).Return(errors.New("database error"))
 
@@ -255,10 +323,12 @@ func TestUnfollowUser_WhenSuccessful_ShouldUnfollowUser(t *testing.T) {
 	ctx := context.Background()
 	userRepoMock := new(mocks.UserRepository)
 	followerRepoMock := new(mocks.FollowerRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
 
 	followerService := &followerService{
-		userRepository:     userRepoMock,
-		followerRepository: followerRepoMock,
+		userRepository:        userRepoMock,
+		followerRepository:    followerRepoMock,
+		memoryCacheRepository: memoryCacheRepoMock,
 	}
 
 	userID := uuid.New()
@@ -268,12 +338,15 @@ func TestUnfollowUser_WhenSuccessful_ShouldUnfollowUser(t *testing.T) {
 	userRepoMock.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
 	followerRepoMock.On("GetFollower", ctx, userID, session.UserID).Return(&domain.Follower{ID: uuid.New(), UserID: userID, FollowerID: session.UserID}, nil)
 	followerRepoMock.On("DeleteFollower", ctx, mock.Anything).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, userID, domain.ProfileCountFollowers, int64(-1)).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, session.UserID, domain.ProfileCountFollowings, int64(-1)).Return(nil)
 
 	err := followerService.UnfollowUser(ctx, userID)
 
 	assert.NoError(t, err)
 	userRepoMock.AssertExpectations(t)
 	followerRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
 }
 
 func TestGetFollowers_WhenSessionNotFound_ShouldReturnError(t *testing.T) {
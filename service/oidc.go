@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
+)
+
+type oidcService struct {
+	di                  *internal.Di
+	oidcClient          client.OIDCClient
+	oidcStateRepository domain.OIDCStateRepository
+}
+
+func NewOIDCService(di *internal.Di) (domain.OIDCService, error) {
+	oidcClient, err := internal.Invoke[client.OIDCClient](di)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcStateRepository, err := internal.Invoke[domain.OIDCStateRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidcService{
+		di:                  di,
+		oidcClient:          oidcClient,
+		oidcStateRepository: oidcStateRepository,
+	}, nil
+}
+
+func (o *oidcService) GetAuthorizationURL(ctx context.Context, provider string) (string, error) {
+	if !o.oidcClient.HasProvider(provider) {
+		return "", domain.ErrOIDCProviderNotFound
+	}
+
+	state, err := secure.GenerateToken(32)
+	if err != nil {
+		return "", fmt.Errorf("generate state: %w", err)
+	}
+
+	nonce, err := secure.GenerateToken(32)
+	if err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	codeVerifier, err := secure.GenerateCodeVerifier()
+	if err != nil {
+		return "", fmt.Errorf("generate code verifier: %w", err)
+	}
+
+	authState := domain.OIDCAuthState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	}
+
+	if err := o.oidcStateRepository.SetState(ctx, secure.HashToken(state), authState); err != nil {
+		return "", fmt.Errorf("set OIDC state: %w", err)
+	}
+
+	authorizationURL, err := o.oidcClient.AuthCodeURL(ctx, provider, state, nonce, secure.CodeChallengeS256(codeVerifier))
+	if err != nil {
+		return "", fmt.Errorf("build authorization URL: %w", err)
+	}
+
+	return authorizationURL, nil
+}
+
+// Authenticate completes the authorization code flow. The state is consumed
+// on first use, so a callback can never be replayed.
+func (o *oidcService) Authenticate(ctx context.Context, provider string, payload domain.OIDCCallbackPayload) (*domain.OIDCClaims, error) {
+	if !o.oidcClient.HasProvider(provider) {
+		return nil, domain.ErrOIDCProviderNotFound
+	}
+
+	authState, err := o.oidcStateRepository.ConsumeState(ctx, secure.HashToken(payload.State))
+	if err != nil {
+		return nil, fmt.Errorf("consume OIDC state: %w", err)
+	}
+
+	if authState == nil || authState.Provider != provider {
+		return nil, domain.ErrOIDCStateInvalid
+	}
+
+	tokens, err := o.oidcClient.Exchange(ctx, provider, payload.Code, authState.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	if tokens.IDToken == "" {
+		return nil, domain.ErrOIDCIDTokenInvalid
+	}
+
+	claims, err := o.oidcClient.VerifyIDToken(ctx, provider, tokens.IDToken, authState.Nonce)
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCIDTokenInvalid) {
+			slog.Warn("reject OIDC ID token", slog.String("provider", provider), slog.String("error", err.Error()))
+			return nil, domain.ErrOIDCIDTokenInvalid
+		}
+		return nil, fmt.Errorf("verify ID token: %w", err)
+	}
+
+	return claims, nil
+}
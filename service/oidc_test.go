@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/client"
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/config/model"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	fakeOIDCProvider = "fake"
+	fakeOIDCClientID = "social-network"
+	fakeOIDCCode     = "authorization-code"
+)
+
+// fakeOIDCServer is a minimal OpenID Connect provider serving discovery, a
+// JWKS and a token endpoint that enforces PKCE.
+type fakeOIDCServer struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newFakeOIDCServer(t *testing.T) *fakeOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	fake := &fakeOIDCServer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 fake.server.URL,
+			"authorization_endpoint": fake.server.URL + "/authorize",
+			"token_endpoint":         fake.server.URL + "/token",
+			"jwks_uri":               fake.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, secure.JSONWebKeySet{Keys: []secure.JSONWebKey{{
+			Kty: "RSA",
+			Kid: "fake-key",
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil ||
+			r.PostForm.Get("code") != fakeOIDCCode ||
+			secure.CodeChallengeS256(r.PostForm.Get("code_verifier")) != fake.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, fake.claims)
+		token.Header["kid"] = "fake-key"
+		idToken, err := token.SignedString(fake.key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, domain.OIDCTokenResponse{AccessToken: "access", IDToken: idToken, TokenType: "Bearer"})
+	})
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+
+	previous := config.Env.OIDCProviders
+	config.Env.OIDCProviders = map[string]model.OIDCProviderEnvironment{
+		fakeOIDCProvider: {
+			Issuer:      fake.server.URL,
+			ClientID:    fakeOIDCClientID,
+			RedirectURL: "http://localhost:3000/auth/callback",
+			Scopes:      []string{"openid", "email", "profile"},
+		},
+	}
+	t.Cleanup(func() { config.Env.OIDCProviders = previous })
+
+	return fake
+}
+
+// validClaims returns the claims of an ID token answering an authorization
+// request made with nonce.
+func (f *fakeOIDCServer) validClaims(nonce string) jwt.MapClaims {
+	now := time.Now().UTC()
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"aud":            []string{fakeOIDCClientID},
+		"sub":            "provider-user-1",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "Gabriel@Test.com",
+		"email_verified": true,
+		"given_name":     "Gabriel",
+		"family_name":    "Soares",
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = jsoniter.NewEncoder(w).Encode(value)
+}
+
+// authorize runs GetAuthorizationURL and records what the browser would send
+// to the fake provider, returning the state and the stored auth state.
+func authorize(t *testing.T, ctx context.Context, service *oidcService, stateRepoMock *mocks.OIDCStateRepository, fake *fakeOIDCServer) (string, domain.OIDCAuthState) {
+	var storedState domain.OIDCAuthState
+	stateRepoMock.On("SetState", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("domain.OIDCAuthState")).
+		Run(func(args mock.Arguments) {
+			storedState = args.Get(2).(domain.OIDCAuthState)
+		}).Return(nil)
+
+	authorizationURL, err := service.GetAuthorizationURL(ctx, fakeOIDCProvider)
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+
+	query := parsed.Query()
+	fake.codeChallenge = query.Get("code_challenge")
+
+	return query.Get("state"), storedState
+}
+
+func newTestOIDCService(t *testing.T, stateRepoMock *mocks.OIDCStateRepository) *oidcService {
+	oidcClient, err := client.NewOIDCClient(nil)
+	assert.NoError(t, err)
+
+	return &oidcService{
+		oidcClient:          oidcClient,
+		oidcStateRepository: stateRepoMock,
+	}
+}
+
+func TestGetAuthorizationURL_WhenProviderIsConfigured_ShouldUsePKCEAndStoreState(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	var stateHash string
+	var storedState domain.OIDCAuthState
+	stateRepoMock.On("SetState", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("domain.OIDCAuthState")).
+		Run(func(args mock.Arguments) {
+			stateHash = args.String(1)
+			storedState = args.Get(2).(domain.OIDCAuthState)
+		}).Return(nil)
+
+	authorizationURL, err := service.GetAuthorizationURL(ctx, fakeOIDCProvider)
+
+	assert.NoError(t, err)
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, fake.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, fakeOIDCClientID, query.Get("client_id"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, secure.CodeChallengeS256(storedState.CodeVerifier), query.Get("code_challenge"))
+	assert.Equal(t, storedState.Nonce, query.Get("nonce"))
+	assert.Equal(t, secure.HashToken(query.Get("state")), stateHash)
+	assert.Equal(t, fakeOIDCProvider, storedState.Provider)
+}
+
+func TestGetAuthorizationURL_WhenProviderIsUnknown_ShouldReturnErrorProviderNotFound(t *testing.T) {
+	newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	authorizationURL, err := service.GetAuthorizationURL(context.Background(), "unknown")
+
+	assert.Equal(t, domain.ErrOIDCProviderNotFound, err)
+	assert.Empty(t, authorizationURL)
+	stateRepoMock.AssertNotCalled(t, "SetState", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_WhenIDTokenIsValid_ShouldReturnClaims(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	state, authState := authorize(t, ctx, service, stateRepoMock, fake)
+	fake.claims = fake.validClaims(authState.Nonce)
+	stateRepoMock.On("ConsumeState", ctx, secure.HashToken(state)).Return(&authState, nil)
+
+	claims, err := service.Authenticate(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: state})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "provider-user-1", claims.Subject)
+	assert.Equal(t, "gabriel@test.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "Gabriel", claims.GivenName)
+}
+
+func TestAuthenticate_WhenStateIsUnknown_ShouldReturnErrorStateInvalid(t *testing.T) {
+	ctx := context.Background()
+	newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	stateRepoMock.On("ConsumeState", ctx, secure.HashToken("forged")).Return(nil, nil)
+
+	claims, err := service.Authenticate(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: "forged"})
+
+	assert.Equal(t, domain.ErrOIDCStateInvalid, err)
+	assert.Nil(t, claims)
+}
+
+func TestAuthenticate_WhenCodeVerifierDoesNotMatch_ShouldReturnError(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	state, authState := authorize(t, ctx, service, stateRepoMock, fake)
+	fake.claims = fake.validClaims(authState.Nonce)
+	authState.CodeVerifier = "intercepted-code-without-verifier"
+	stateRepoMock.On("ConsumeState", ctx, secure.HashToken(state)).Return(&authState, nil)
+
+	claims, err := service.Authenticate(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: state})
+
+	assert.ErrorContains(t, err, "invalid_grant")
+	assert.Nil(t, claims)
+}
+
+func TestAuthenticate_WhenIDTokenClaimsAreInvalid_ShouldReturnErrorIDTokenInvalid(t *testing.T) {
+	tests := map[string]func(claims jwt.MapClaims){
+		"nonce mismatch":  func(claims jwt.MapClaims) { claims["nonce"] = "replayed" },
+		"wrong audience":  func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+		"wrong issuer":    func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example" },
+		"expired":         func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"missing subject": func(claims jwt.MapClaims) { delete(claims, "sub") },
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := newFakeOIDCServer(t)
+			stateRepoMock := new(mocks.OIDCStateRepository)
+			service := newTestOIDCService(t, stateRepoMock)
+
+			state, authState := authorize(t, ctx, service, stateRepoMock, fake)
+			fake.claims = fake.validClaims(authState.Nonce)
+			tamper(fake.claims)
+			stateRepoMock.On("ConsumeState", ctx, secure.HashToken(state)).Return(&authState, nil)
+
+			claims, err := service.Authenticate(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: state})
+
+			assert.Equal(t, domain.ErrOIDCIDTokenInvalid, err)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestAuthenticate_WhenIDTokenIsSignedByAnotherKey_ShouldReturnErrorIDTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	service := newTestOIDCService(t, stateRepoMock)
+
+	state, authState := authorize(t, ctx, service, stateRepoMock, fake)
+	fake.claims = fake.validClaims(authState.Nonce)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	fake.key = otherKey
+	stateRepoMock.On("ConsumeState", ctx, secure.HashToken(state)).Return(&authState, nil)
+
+	claims, err := service.Authenticate(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: state})
+
+	assert.Equal(t, domain.ErrOIDCIDTokenInvalid, err)
+	assert.Nil(t, claims)
+}
+
+func TestSignInWithOIDC_WhenFakeProviderVerifiesSquattedEmail_ShouldRevokeSquatterAccess(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeOIDCServer(t)
+	stateRepoMock := new(mocks.OIDCStateRepository)
+	userRepoMock := new(mocks.UserRepository)
+	sessionServiceMock := new(mocks.SessionService)
+	identityRepoMock := new(mocks.ExternalIdentityRepository)
+	securityEventServiceMock := new(mocks.SecurityEventService)
+	clientInfoServiceMock := new(mocks.ClientInfoService)
+
+	oidcService := newTestOIDCService(t, stateRepoMock)
+	userService := &userService{
+		userRepository:             userRepoMock,
+		sessionService:             sessionServiceMock,
+		oidcService:                oidcService,
+		externalIdentityRepository: identityRepoMock,
+		securityEventService:       securityEventServiceMock,
+		clientInfoService:          clientInfoServiceMock,
+	}
+
+	squatterPassword, err := secure.HashPassword("Squatter@123")
+	assert.NoError(t, err)
+	squatted := &domain.User{ID: uuid.New(), Email: "gabriel@test.com", Password: string(squatterPassword)}
+
+	state, authState := authorize(t, ctx, oidcService, stateRepoMock, fake)
+	fake.claims = fake.validClaims(authState.Nonce)
+	stateRepoMock.On("ConsumeState", ctx, secure.HashToken(state)).Return(&authState, nil)
+	identityRepoMock.On("GetIdentity", ctx, fakeOIDCProvider, "provider-user-1").Return(nil, nil)
+	userRepoMock.On("GetUserByEmail", ctx, "gabriel@test.com").Return(squatted, nil)
+
+	var linked domain.User
+	userRepoMock.On("UpdateUser", ctx, mock.AnythingOfType("domain.User")).
+		Run(func(args mock.Arguments) {
+			linked = args.Get(1).(domain.User)
+		}).Return(nil)
+	sessionServiceMock.On("DeleteAllSessions", ctx, squatted.ID).Return(nil)
+	identityRepoMock.On("CreateIdentity", ctx, mock.AnythingOfType("domain.ExternalIdentity")).Return(nil)
+	sessionServiceMock.On("CreateSession", ctx, mock.AnythingOfType("domain.User")).Return(&domain.AuthTokens{}, nil)
+	securityEventServiceMock.On("Record", ctx, squatted.ID, domain.SecurityEventSignInSuccess, "oidc:"+fakeOIDCProvider).Return()
+	clientInfoServiceMock.On("GetClientInfo", mock.Anything).Return(nil, errors.New("client info error"))
+
+	_, err = userService.SignInWithOIDC(ctx, fakeOIDCProvider, domain.OIDCCallbackPayload{Code: fakeOIDCCode, State: state})
+
+	assert.NoError(t, err)
+	assert.True(t, linked.IsEmailConfirmed())
+	assert.Error(t, secure.CheckPassword(linked.Password, "Squatter@123"), "the squatter's password must no longer work")
+	sessionServiceMock.AssertCalled(t, "DeleteAllSessions", ctx, squatted.ID)
+	identityRepoMock.AssertExpectations(t)
+}
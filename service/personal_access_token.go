@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+)
+
+type personalAccessTokenService struct {
+	di                            *internal.Di
+	personalAccessTokenRepository domain.PersonalAccessTokenRepository
+	contextService                domain.ContextService
+}
+
+func NewPersonalAccessTokenService(di *internal.Di) (domain.PersonalAccessTokenService, error) {
+	personalAccessTokenRepository, err := internal.Invoke[domain.PersonalAccessTokenRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &personalAccessTokenService{
+		di:                            di,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		contextService:                contextService,
+	}, nil
+}
+
+func (p *personalAccessTokenService) CreateToken(ctx context.Context, payload domain.PersonalAccessTokenPayload) (*domain.PersonalAccessTokenCreatedResponse, error) {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secure.GenerateToken(domain.PersonalAccessTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("generate personal access token: %w", err)
+	}
+
+	plainToken := domain.PersonalAccessTokenPrefix + secret
+	token := payload.ToPersonalAccessToken(session.UserID, secure.HashToken(plainToken))
+
+	if err := p.personalAccessTokenRepository.CreateToken(ctx, *token); err != nil {
+		return nil, fmt.Errorf("create personal access token: %w", err)
+	}
+
+	return &domain.PersonalAccessTokenCreatedResponse{
+		PersonalAccessTokenResponse: *token.ToPersonalAccessTokenResponse(),
+		Token:                       plainToken,
+	}, nil
+}
+
+func (p *personalAccessTokenService) GetTokens(ctx context.Context) ([]*domain.PersonalAccessTokenResponse, error) {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := p.personalAccessTokenRepository.GetTokensByUserID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get personal access tokens: %w", err)
+	}
+
+	response := make([]*domain.PersonalAccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, token.ToPersonalAccessTokenResponse())
+	}
+
+	return response, nil
+}
+
+func (p *personalAccessTokenService) RevokeToken(ctx context.Context, tokenID uuid.UUID) error {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := p.personalAccessTokenRepository.DeleteToken(ctx, session.UserID, tokenID)
+	if err != nil {
+		return fmt.Errorf("delete personal access token: %w", err)
+	}
+
+	if !deleted {
+		return domain.ErrPersonalAccessTokenNotFound
+	}
+
+	return nil
+}
+
+func (p *personalAccessTokenService) GetSessionByToken(ctx context.Context, plainToken string) (*domain.Session, error) {
+	token, err := p.personalAccessTokenRepository.GetTokenByHash(ctx, secure.HashToken(plainToken))
+	if err != nil {
+		return nil, fmt.Errorf("get personal access token: %w", err)
+	}
+
+	if token == nil || token.IsExpired() {
+		return nil, domain.ErrTokenInvalid
+	}
+
+	if err := token.User.CheckStatus(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > domain.SessionLastSeenInterval {
+		if err := p.personalAccessTokenRepository.UpdateLastUsedAt(ctx, token.ID, now); err != nil {
+			slog.Warn("update personal access token last use", slog.String("error", err.Error()))
+		}
+	}
+
+	return token.ToSession(), nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePersonalAccessToken_WhenSuccessful_ShouldStoreOnlyTheHash(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.PersonalAccessTokenRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	service := &personalAccessTokenService{
+		personalAccessTokenRepository: repoMock,
+		contextService:                contextServiceMock,
+	}
+
+	session := &domain.Session{UserID: uuid.New()}
+	payload := domain.PersonalAccessTokenPayload{
+		Name:          "deploy bot",
+		Scopes:        []string{domain.ScopePostsWrite, domain.ScopeFeedRead, domain.ScopePostsWrite},
+		ExpiresInDays: 30,
+	}
+
+	var storedToken domain.PersonalAccessToken
+	contextServiceMock.On("Session", ctx).Return(session, nil)
+	repoMock.On("CreateToken", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		storedToken = args.Get(1).(domain.PersonalAccessToken)
+	}).Return(nil)
+
+	response, err := service.CreateToken(ctx, payload)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.Token, domain.PersonalAccessTokenPrefix))
+	assert.Equal(t, secure.HashToken(response.Token), storedToken.TokenHash)
+	assert.NotContains(t, storedToken.TokenHash, response.Token)
+	assert.Equal(t, session.UserID, storedToken.UserID)
+	assert.Equal(t, "feed:read posts:write", storedToken.Scopes)
+	assert.NotNil(t, storedToken.ExpiresAt)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreatePersonalAccessToken_WhenScopeIsUnknown_ShouldFailValidation(t *testing.T) {
+	payload := domain.PersonalAccessTokenPayload{
+		Name:   "deploy bot",
+		Scopes: []string{"users:delete"},
+	}
+
+	validationErrors := payload.Validate()
+
+	assert.NotNil(t, validationErrors)
+}
+
+func TestRevokePersonalAccessToken_WhenTokenNotFound_ShouldReturnErrorNotFound(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.PersonalAccessTokenRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	service := &personalAccessTokenService{
+		personalAccessTokenRepository: repoMock,
+		contextService:                contextServiceMock,
+	}
+
+	session := &domain.Session{UserID: uuid.New()}
+	tokenID := uuid.New()
+
+	contextServiceMock.On("Session", ctx).Return(session, nil)
+	repoMock.On("DeleteToken", ctx, session.UserID, tokenID).Return(false, nil)
+
+	err := service.RevokeToken(ctx, tokenID)
+
+	assert.Equal(t, domain.ErrPersonalAccessTokenNotFound, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetSessionByPersonalAccessToken_WhenTokenIsExpired_ShouldReturnErrorTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.PersonalAccessTokenRepository)
+
+	service := &personalAccessTokenService{
+		personalAccessTokenRepository: repoMock,
+	}
+
+	plainToken := domain.PersonalAccessTokenPrefix + "expired"
+	expiresAt := time.Now().Add(-time.Hour)
+
+	repoMock.On("GetTokenByHash", ctx, secure.HashToken(plainToken)).
+		Return(&domain.PersonalAccessToken{ID: uuid.New(), ExpiresAt: &expiresAt}, nil)
+
+	session, err := service.GetSessionByToken(ctx, plainToken)
+
+	assert.Equal(t, domain.ErrTokenInvalid, err)
+	assert.Nil(t, session)
+	repoMock.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSessionByPersonalAccessToken_WhenOwnerIsBlocked_ShouldReturnErrorUserBlocked(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.PersonalAccessTokenRepository)
+
+	service := &personalAccessTokenService{
+		personalAccessTokenRepository: repoMock,
+	}
+
+	plainToken := domain.PersonalAccessTokenPrefix + "blocked"
+
+	repoMock.On("GetTokenByHash", ctx, secure.HashToken(plainToken)).
+		Return(&domain.PersonalAccessToken{ID: uuid.New(), User: domain.User{Status: domain.Block}}, nil)
+
+	session, err := service.GetSessionByToken(ctx, plainToken)
+
+	assert.Equal(t, domain.ErrUserBlocked, err)
+	assert.Nil(t, session)
+	repoMock.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSessionByPersonalAccessToken_WhenSuccessful_ShouldReturnScopedSession(t *testing.T) {
+	ctx := context.Background()
+	repoMock := new(mocks.PersonalAccessTokenRepository)
+
+	service := &personalAccessTokenService{
+		personalAccessTokenRepository: repoMock,
+	}
+
+	plainToken := domain.PersonalAccessTokenPrefix + "valid"
+	token := &domain.PersonalAccessToken{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		User:   domain.User{Username: "bot"},
+		Scopes: "feed:read posts:write",
+	}
+
+	repoMock.On("GetTokenByHash", ctx, secure.HashToken(plainToken)).Return(token, nil)
+	repoMock.On("UpdateLastUsedAt", ctx, token.ID, mock.Anything).Return(nil)
+
+	session, err := service.GetSessionByToken(ctx, plainToken)
+
+	assert.NoError(t, err)
+	assert.Equal(t, token.UserID, session.UserID)
+	assert.Equal(t, "bot", session.Username)
+	assert.True(t, session.HasScopes(domain.ScopePostsWrite))
+	assert.False(t, session.HasScopes(domain.ScopeFollowersWrite))
+	assert.False(t, session.HasScopes())
+	repoMock.AssertExpectations(t)
+}
@@ -19,6 +19,7 @@ type postService struct {
 	likeRepository        domain.LikeRepository
 	memoryCacheRepository domain.MemoryCacheRepository
 	queueService          domain.QueueService
+	moderationRepository  domain.ModerationRepository
 }
 
 func NewPostService(di *internal.Di) (domain.PostService, error) {
@@ -47,6 +48,11 @@ func NewPostService(di *internal.Di) (domain.PostService, error) {
 		return nil, err
 	}
 
+	moderationRepository, err := internal.Invoke[domain.ModerationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &postService{
 		di:                    di,
 		postRepository:        postRepository,
@@ -54,6 +60,7 @@ func NewPostService(di *internal.Di) (domain.PostService, error) {
 		memoryCacheRepository: memoryCacheRepository,
 		queueService:          queueService,
 		likeRepository:        likeRepository,
+		moderationRepository:  moderationRepository,
 	}, nil
 }
 
@@ -64,6 +71,8 @@ func (p *postService) CreatePost(ctx context.Context, payload domain.PostPayload
 		return fmt.Errorf("create post: %w", err)
 	}
 
+	syncProfileCount(ctx, p.memoryCacheRepository, post.AuthorID, domain.ProfileCountPosts, 1)
+
 	return nil
 }
 
@@ -141,7 +150,7 @@ func (p *postService) UpdatePost(ctx context.Context, ID uuid.UUID, payload doma
 }
 
 func (p *postService) DeletePost(ctx context.Context, ID uuid.UUID) error {
-	post, err := p.postRepository.GetPostById(ctx, ID, false)
+	post, err := p.postRepository.GetPostByIdIncludingHidden(ctx, ID)
 	if err != nil {
 		return fmt.Errorf("error to get post by ID: %w", err)
 	}
@@ -151,13 +160,44 @@ func (p *postService) DeletePost(ctx context.Context, ID uuid.UUID) error {
 	}
 
 	if post.AuthorID != p.contextService.GetUserID(ctx) {
-		return domain.ErrPostNotBelongToUser
+		return p.deletePostAsModerator(ctx, post)
 	}
 
 	if err := p.postRepository.DeletePost(ctx, ID); err != nil {
 		return fmt.Errorf("error to delete post: %w", err)
 	}
 
+	syncProfileCount(ctx, p.memoryCacheRepository, post.AuthorID, domain.ProfileCountPosts, -1)
+
+	return nil
+}
+
+// deletePostAsModerator deletes a post the caller does not own. The override is
+// recorded in the same transaction that removes the post so that no deletion
+// goes unaudited.
+func (p *postService) deletePostAsModerator(ctx context.Context, post *domain.Post) error {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !session.Role.Includes(domain.RoleModerator) {
+		return domain.ErrPostNotBelongToUser
+	}
+
+	action := domain.NewModerationAction(session, domain.ModerationActionDeletePost, post.ID, post.AuthorID, "")
+	if err := p.moderationRepository.DeletePost(ctx, *action, post.ID); err != nil {
+		return fmt.Errorf("error to delete post: %w", err)
+	}
+
+	syncProfileCount(ctx, p.memoryCacheRepository, post.AuthorID, domain.ProfileCountPosts, -1)
+
+	slog.Info("post deleted by moderator",
+		slog.String("moderatorId", session.UserID.String()),
+		slog.String("postId", post.ID.String()),
+		slog.String("authorId", post.AuthorID.String()),
+	)
+
 	return nil
 }
 
@@ -12,6 +12,34 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func TestCreatePost_Success_IncrementsCachedPostCount(t *testing.T) {
+	ctx := context.Background()
+	postRepoMock := new(mocks.PostRepository)
+	contextServiceMock := new(mocks.ContextService)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+
+	postService := &postService{
+		postRepository:        postRepoMock,
+		contextService:        contextServiceMock,
+		memoryCacheRepository: memoryCacheRepoMock,
+	}
+
+	userID := uuid.New()
+	payload := domain.PostPayload{Title: "Title", Content: "Content"}
+
+	contextServiceMock.On("GetUserID", ctx).Return(userID)
+	postRepoMock.On("CreatePost", ctx, mock.MatchedBy(func(post domain.Post) bool {
+		return post.AuthorID == userID
+	})).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, userID, domain.ProfileCountPosts, int64(1)).Return(nil)
+
+	err := postService.CreatePost(ctx, payload)
+
+	assert.NoError(t, err)
+	postRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
+}
+
 func TestGetPosts_WhenSuccessFromCache_ShouldReturnPosts(t *testing.T) {
 	ctx := context.Background()
 	cacheMock := new(mocks.MemoryCacheRepository)
@@ -453,7 +481,7 @@ func TestDeletePost_PostNotFound_ReturnsError(t *testing.T) {
 
 	postID := uuid.New()
 
-	postRepoMock.On("GetPostById", ctx, postID, false).Return(nil, nil)
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(nil, nil)
 
 	err := postService.DeletePost(ctx, postID)
 
@@ -473,7 +501,7 @@ func TestDeletePost_GetPostByIdRepositoryError_ReturnsError(t *testing.T) {
 
 	postID := uuid.New()
 
-	postRepoMock.On("GetPostById", ctx, postID, false).Return(nil, errors.New("repository error"))
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(nil, errors.New("repository error"))
 
 	err := postService.DeletePost(ctx, postID)
 
@@ -496,8 +524,9 @@ func TestDeletePost_PostNotBelongToUser_ReturnsError(t *testing.T) {
 	otherUserID := uuid.New()
 	post := &domain.Post{AuthorID: otherUserID}
 
-	postRepoMock.On("GetPostById", ctx, postID, false).Return(post, nil)
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
 	contextServiceMock.On("GetUserID", ctx).Return(userID)
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID, Role: domain.RoleUser}, nil)
 
 	err := postService.DeletePost(ctx, postID)
 
@@ -520,7 +549,7 @@ func TestDeletePost_DeletePostRepositoryError_ReturnsError(t *testing.T) {
 	userID := uuid.New()
 	post := &domain.Post{AuthorID: userID}
 
-	postRepoMock.On("GetPostById", ctx, postID, false).Return(post, nil)
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
 	contextServiceMock.On("GetUserID", ctx).Return(userID)
 	postRepoMock.On("DeletePost", ctx, postID).Return(errors.New("delete error"))
 
@@ -535,25 +564,162 @@ func TestDeletePost_Success(t *testing.T) {
 	ctx := context.Background()
 	postRepoMock := new(mocks.PostRepository)
 	contextServiceMock := new(mocks.ContextService)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
 
 	postService := &postService{
-		postRepository: postRepoMock,
-		contextService: contextServiceMock,
+		postRepository:        postRepoMock,
+		contextService:        contextServiceMock,
+		memoryCacheRepository: memoryCacheRepoMock,
 	}
 
 	postID := uuid.New()
 	userID := uuid.New()
 	post := &domain.Post{AuthorID: userID}
 
-	postRepoMock.On("GetPostById", ctx, postID, false).Return(post, nil)
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
 	contextServiceMock.On("GetUserID", ctx).Return(userID)
 	postRepoMock.On("DeletePost", ctx, postID).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, userID, domain.ProfileCountPosts, int64(-1)).Return(nil)
+
+	err := postService.DeletePost(ctx, postID)
+
+	assert.NoError(t, err)
+	postRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
+	contextServiceMock.AssertExpectations(t)
+}
+
+func TestDeletePost_Moderator_RecordsActionAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	postRepoMock := new(mocks.PostRepository)
+	contextServiceMock := new(mocks.ContextService)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	moderationRepoMock := new(mocks.ModerationRepository)
+
+	postService := &postService{
+		postRepository:        postRepoMock,
+		contextService:        contextServiceMock,
+		moderationRepository:  moderationRepoMock,
+		memoryCacheRepository: memoryCacheRepoMock,
+	}
+
+	postID := uuid.New()
+	moderatorID := uuid.New()
+	authorID := uuid.New()
+	post := &domain.Post{ID: postID, AuthorID: authorID}
+
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
+	contextServiceMock.On("GetUserID", ctx).Return(moderatorID)
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: moderatorID, Role: domain.RoleModerator}, nil)
+	moderationRepoMock.On("DeletePost", ctx, mock.MatchedBy(func(action domain.ModerationAction) bool {
+		return action.ActorID == moderatorID &&
+			action.ActorRole == domain.RoleModerator &&
+			action.Action == domain.ModerationActionDeletePost &&
+			action.TargetID == postID &&
+			action.TargetOwnerID == authorID
+	}), postID).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, authorID, domain.ProfileCountPosts, int64(-1)).Return(nil)
 
 	err := postService.DeletePost(ctx, postID)
 
 	assert.NoError(t, err)
 	postRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
 	contextServiceMock.AssertExpectations(t)
+	moderationRepoMock.AssertExpectations(t)
+}
+
+func TestDeletePost_ModeratorAndAuthorBlocked_DeletesHiddenPost(t *testing.T) {
+	ctx := context.Background()
+	postRepoMock := new(mocks.PostRepository)
+	contextServiceMock := new(mocks.ContextService)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	moderationRepoMock := new(mocks.ModerationRepository)
+
+	postService := &postService{
+		postRepository:        postRepoMock,
+		contextService:        contextServiceMock,
+		moderationRepository:  moderationRepoMock,
+		memoryCacheRepository: memoryCacheRepoMock,
+	}
+
+	postID := uuid.New()
+	moderatorID := uuid.New()
+	authorID := uuid.New()
+	post := &domain.Post{ID: postID, AuthorID: authorID, Author: domain.User{ID: authorID, Status: domain.Block}}
+
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
+	contextServiceMock.On("GetUserID", ctx).Return(moderatorID)
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: moderatorID, Role: domain.RoleModerator}, nil)
+	moderationRepoMock.On("DeletePost", ctx, mock.AnythingOfType("domain.ModerationAction"), postID).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, authorID, domain.ProfileCountPosts, int64(-1)).Return(nil)
+
+	err := postService.DeletePost(ctx, postID)
+
+	assert.NoError(t, err)
+	postRepoMock.AssertExpectations(t)
+	postRepoMock.AssertNotCalled(t, "GetPostById", mock.Anything, mock.Anything, mock.Anything)
+	moderationRepoMock.AssertExpectations(t)
+}
+
+func TestDeletePost_Admin_RecordsActionAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	postRepoMock := new(mocks.PostRepository)
+	contextServiceMock := new(mocks.ContextService)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	moderationRepoMock := new(mocks.ModerationRepository)
+
+	postService := &postService{
+		postRepository:        postRepoMock,
+		contextService:        contextServiceMock,
+		moderationRepository:  moderationRepoMock,
+		memoryCacheRepository: memoryCacheRepoMock,
+	}
+
+	postID := uuid.New()
+	adminID := uuid.New()
+	post := &domain.Post{ID: postID, AuthorID: uuid.New()}
+
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
+	contextServiceMock.On("GetUserID", ctx).Return(adminID)
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: adminID, Role: domain.RoleAdmin}, nil)
+	moderationRepoMock.On("DeletePost", ctx, mock.AnythingOfType("domain.ModerationAction"), postID).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, post.AuthorID, domain.ProfileCountPosts, int64(-1)).Return(nil)
+
+	err := postService.DeletePost(ctx, postID)
+
+	assert.NoError(t, err)
+	postRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
+	moderationRepoMock.AssertExpectations(t)
+}
+
+func TestDeletePost_ModeratorTransactionFails_ReturnsErrorWithoutSyncingCount(t *testing.T) {
+	ctx := context.Background()
+	postRepoMock := new(mocks.PostRepository)
+	contextServiceMock := new(mocks.ContextService)
+	moderationRepoMock := new(mocks.ModerationRepository)
+
+	postService := &postService{
+		postRepository:       postRepoMock,
+		contextService:       contextServiceMock,
+		moderationRepository: moderationRepoMock,
+	}
+
+	postID := uuid.New()
+	moderatorID := uuid.New()
+	post := &domain.Post{ID: postID, AuthorID: uuid.New()}
+
+	postRepoMock.On("GetPostByIdIncludingHidden", ctx, postID).Return(post, nil)
+	contextServiceMock.On("GetUserID", ctx).Return(moderatorID)
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: moderatorID, Role: domain.RoleModerator}, nil)
+	moderationRepoMock.On("DeletePost", ctx, mock.AnythingOfType("domain.ModerationAction"), postID).Return(errors.New("insert error"))
+
+	err := postService.DeletePost(ctx, postID)
+
+	assert.ErrorContains(t, err, "insert error")
+	postRepoMock.AssertNotCalled(t, "DeletePost", ctx, postID)
+	moderationRepoMock.AssertExpectations(t)
 }
 
 func TestGetByUserID_SessionNotFound_ReturnsError(t *testing.T) {
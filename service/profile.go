@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+)
+
+type profileService struct {
+	di                        *internal.Di
+	profileRepository         domain.ProfileRepository
+	userRepository            domain.UserRepository
+	followerRepository        domain.FollowerRepository
+	userRestrictionRepository domain.UserRestrictionRepository
+	memoryCacheRepository     domain.MemoryCacheRepository
+	contextService            domain.ContextService
+}
+
+func NewProfileService(di *internal.Di) (domain.ProfileService, error) {
+	profileRepository, err := internal.Invoke[domain.ProfileRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	followerRepository, err := internal.Invoke[domain.FollowerRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRestrictionRepository, err := internal.Invoke[domain.UserRestrictionRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryCacheRepository, err := internal.Invoke[domain.MemoryCacheRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profileService{
+		di:                        di,
+		profileRepository:         profileRepository,
+		userRepository:            userRepository,
+		followerRepository:        followerRepository,
+		userRestrictionRepository: userRestrictionRepository,
+		memoryCacheRepository:     memoryCacheRepository,
+		contextService:            contextService,
+	}, nil
+}
+
+// GetProfile returns the public profile of a user as seen by the signed in
+// user. Blocked and deactivated accounts, and users who blocked the viewer,
+// are reported as not found. The birthday is only shown as far as its
+// visibility allows.
+func (p *profileService) GetProfile(ctx context.Context, username string) (*domain.ProfileResponse, error) {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := p.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user by username: %w", err)
+	}
+
+	if user == nil || user.Status == domain.Block || user.Status == domain.Inactive {
+		return nil, domain.ErrUserNotFound
+	}
+
+	response := user.ToProfileResponse()
+
+	if user.ID == session.UserID {
+		response.Birthday = user.FormattedBirthday()
+	} else {
+		relationship, err := p.relationship(ctx, session.UserID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if relationship == nil {
+			return nil, domain.ErrUserNotFound
+		}
+
+		response.Relationship = *relationship
+
+		if user.BirthdayVisibleTo(relationship.YouFollow) {
+			response.Birthday = user.FormattedBirthday()
+		}
+	}
+
+	counts, err := p.counts(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Counts = *counts
+
+	return response, nil
+}
+
+// SearchUsers finds active users by username, first or last name. Users who
+// blocked the viewer or were blocked by them are left out.
+func (p *profileService) SearchUsers(ctx context.Context, query string, page, limit int) (*domain.Pagination[*domain.UserSearchResponse], error) {
+	session, err := p.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > domain.MaxUserSearchQueryLength {
+		return nil, domain.ErrInvalidSearchQuery
+	}
+
+	results, err := p.profileRepository.SearchUsers(ctx, session.UserID, query, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search users: %w", err)
+	}
+
+	return domain.Map(results, func(result *domain.UserSearchResult) *domain.UserSearchResponse {
+		return result.ToUserSearchResponse()
+	}), nil
+}
+
+// relationship returns nil when the profile owner has blocked the viewer.
+func (p *profileService) relationship(ctx context.Context, viewerID, userID uuid.UUID) (*domain.ProfileRelationship, error) {
+	restrictionsOnViewer, err := p.userRestrictionRepository.GetRestrictionTypes(ctx, userID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("get restrictions on viewer: %w", err)
+	}
+
+	if domain.HasRestriction(restrictionsOnViewer, domain.RestrictionBlock) {
+		return nil, nil
+	}
+
+	restrictionsOnUser, err := p.userRestrictionRepository.GetRestrictionTypes(ctx, viewerID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get restrictions on user: %w", err)
+	}
+
+	following, err := p.followerRepository.GetFollower(ctx, userID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("get following: %w", err)
+	}
+
+	follower, err := p.followerRepository.GetFollower(ctx, viewerID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get follower: %w", err)
+	}
+
+	return &domain.ProfileRelationship{
+		FollowsYou: follower != nil,
+		YouFollow:  following != nil,
+		Blocked:    domain.HasRestriction(restrictionsOnUser, domain.RestrictionBlock),
+		Muted:      domain.HasRestriction(restrictionsOnUser, domain.RestrictionMute),
+	}, nil
+}
+
+func (p *profileService) counts(ctx context.Context, userID uuid.UUID) (*domain.ProfileCounts, error) {
+	cachedCounts, err := p.memoryCacheRepository.GetProfileCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get profile counts from cache: %w", err)
+	}
+
+	if cachedCounts != nil {
+		return cachedCounts, nil
+	}
+
+	counts, err := p.profileRepository.GetProfileCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get profile counts: %w", err)
+	}
+
+	if err := p.memoryCacheRepository.SetProfileCounts(ctx, userID, *counts); err != nil {
+		return nil, fmt.Errorf("set profile counts in cache: %w", err)
+	}
+
+	return counts, nil
+}
+
+// syncProfileCount applies a change that was already written to the database
+// to the cached counts. A failure only leaves the cache stale until it
+// expires, so it is logged rather than failing the request.
+func syncProfileCount(ctx context.Context, memoryCacheRepository domain.MemoryCacheRepository, userID uuid.UUID, count domain.ProfileCount, delta int64) {
+	if err := memoryCacheRepository.IncrementProfileCount(ctx, userID, count, delta); err != nil {
+		slog.Warn("error to update cached profile count",
+			slog.String("userId", userID.String()),
+			slog.String("count", string(count)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func syncFollowCounts(ctx context.Context, memoryCacheRepository domain.MemoryCacheRepository, follower domain.Follower, delta int64) {
+	syncProfileCount(ctx, memoryCacheRepository, follower.UserID, domain.ProfileCountFollowers, delta)
+	syncProfileCount(ctx, memoryCacheRepository, follower.FollowerID, domain.ProfileCountFollowings, delta)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProfile_WhenCountsCached_ShouldReturnProfileWithRelationship(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	followerRepoMock := new(mocks.FollowerRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	profileRepoMock := new(mocks.ProfileRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	profileService := &profileService{
+		profileRepository:         profileRepoMock,
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
+		memoryCacheRepository:     memoryCacheRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	viewerID := uuid.New()
+	user := &domain.User{ID: uuid.New(), Username: "jane", Status: domain.Active}
+	counts := &domain.ProfileCounts{Followers: 10, Followings: 4, Posts: 7}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: viewerID}, nil)
+	userRepoMock.On("GetUserByUsername", ctx, "jane").Return(user, nil)
+	userRestrictionRepoMock.On("GetRestrictionTypes", ctx, user.ID, viewerID).Return([]domain.RestrictionType{domain.RestrictionMute}, nil)
+	userRestrictionRepoMock.On("GetRestrictionTypes", ctx, viewerID, user.ID).Return([]domain.RestrictionType{domain.RestrictionMute}, nil)
+	followerRepoMock.On("GetFollower", ctx, user.ID, viewerID).Return(&domain.Follower{}, nil)
+	followerRepoMock.On("GetFollower", ctx, viewerID, user.ID).Return(nil, nil)
+	memoryCacheRepoMock.On("GetProfileCounts", ctx, user.ID).Return(counts, nil)
+
+	response, err := profileService.GetProfile(ctx, "jane")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", response.Username)
+	assert.Equal(t, *counts, response.Counts)
+	assert.Equal(t, domain.ProfileRelationship{YouFollow: true, Muted: true}, response.Relationship)
+	profileRepoMock.AssertNotCalled(t, "GetProfileCounts", ctx, user.ID)
+	memoryCacheRepoMock.AssertExpectations(t)
+}
+
+func TestGetProfile_WhenCountsNotCached_ShouldLoadAndCacheCounts(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	profileRepoMock := new(mocks.ProfileRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	profileService := &profileService{
+		profileRepository:     profileRepoMock,
+		userRepository:        userRepoMock,
+		memoryCacheRepository: memoryCacheRepoMock,
+		contextService:        contextServiceMock,
+	}
+
+	user := &domain.User{ID: uuid.New(), Username: "jane", Status: domain.Active}
+	counts := &domain.ProfileCounts{Followers: 2, Followings: 3, Posts: 1}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByUsername", ctx, "jane").Return(user, nil)
+	memoryCacheRepoMock.On("GetProfileCounts", ctx, user.ID).Return(nil, nil)
+	profileRepoMock.On("GetProfileCounts", ctx, user.ID).Return(counts, nil)
+	memoryCacheRepoMock.On("SetProfileCounts", ctx, user.ID, *counts).Return(nil)
+
+	response, err := profileService.GetProfile(ctx, "jane")
+
+	assert.NoError(t, err)
+	assert.Equal(t, *counts, response.Counts)
+	assert.Equal(t, domain.ProfileRelationship{}, response.Relationship)
+	profileRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
+}
+
+func TestGetProfile_WhenUserHidden_ShouldReturnErrUserNotFound(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	profileService := &profileService{
+		userRepository: userRepoMock,
+		contextService: contextServiceMock,
+	}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: uuid.New()}, nil)
+	userRepoMock.On("GetUserByUsername", ctx, "jane").Return(&domain.User{ID: uuid.New(), Status: domain.Inactive}, nil)
+
+	response, err := profileService.GetProfile(ctx, "jane")
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrUserNotFound, err)
+}
+
+func TestGetProfile_WhenViewerBlockedByUser_ShouldReturnErrUserNotFound(t *testing.T) {
+	ctx := context.Background()
+	userRepoMock := new(mocks.UserRepository)
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	profileService := &profileService{
+		userRepository:            userRepoMock,
+		userRestrictionRepository: userRestrictionRepoMock,
+		memoryCacheRepository:     memoryCacheRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	viewerID := uuid.New()
+	user := &domain.User{ID: uuid.New(), Status: domain.Active}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: viewerID}, nil)
+	userRepoMock.On("GetUserByUsername", ctx, "jane").Return(user, nil)
+	userRestrictionRepoMock.On("GetRestrictionTypes", ctx, user.ID, viewerID).Return([]domain.RestrictionType{domain.RestrictionBlock}, nil)
+
+	response, err := profileService.GetProfile(ctx, "jane")
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrUserNotFound, err)
+	memoryCacheRepoMock.AssertNotCalled(t, "GetProfileCounts", ctx, user.ID)
+}
+
+func TestGetProfile_WhenBirthdayVisibleToFollowers_ShouldHideItFromOthers(t *testing.T) {
+	birthday := time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC)
+
+	for _, follows := range []bool{true, false} {
+		ctx := context.Background()
+		userRepoMock := new(mocks.UserRepository)
+		followerRepoMock := new(mocks.FollowerRepository)
+		userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+		memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+		contextServiceMock := new(mocks.ContextService)
+
+		profileService := &profileService{
+			userRepository:            userRepoMock,
+			followerRepository:        followerRepoMock,
+			userRestrictionRepository: userRestrictionRepoMock,
+			memoryCacheRepository:     memoryCacheRepoMock,
+			contextService:            contextServiceMock,
+		}
+
+		viewerID := uuid.New()
+		user := &domain.User{ID: uuid.New(), Status: domain.Active, Birthday: &birthday, BirthdayVisibility: domain.VisibilityFollowers}
+
+		var following *domain.Follower
+		if follows {
+			following = &domain.Follower{}
+		}
+
+		contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: viewerID}, nil)
+		userRepoMock.On("GetUserByUsername", ctx, "jane").Return(user, nil)
+		userRestrictionRepoMock.On("GetRestrictionTypes", ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		followerRepoMock.On("GetFollower", ctx, user.ID, viewerID).Return(following, nil)
+		followerRepoMock.On("GetFollower", ctx, viewerID, user.ID).Return(nil, nil)
+		memoryCacheRepoMock.On("GetProfileCounts", ctx, user.ID).Return(&domain.ProfileCounts{}, nil)
+
+		response, err := profileService.GetProfile(ctx, "jane")
+
+		assert.NoError(t, err)
+		if follows {
+			assert.Equal(t, "1990-04-12", *response.Birthday)
+		} else {
+			assert.Nil(t, response.Birthday)
+		}
+	}
+}
+
+func TestSearchUsers_WhenQueryMatches_ShouldReturnPaginatedResults(t *testing.T) {
+	ctx := context.Background()
+	profileRepoMock := new(mocks.ProfileRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	profileService := &profileService{
+		profileRepository: profileRepoMock,
+		contextService:    contextServiceMock,
+	}
+
+	viewerID := uuid.New()
+	followed := &domain.UserSearchResult{User: domain.User{ID: uuid.New(), Username: "janed"}, YouFollow: true}
+	other := &domain.UserSearchResult{User: domain.User{ID: uuid.New(), Username: "jane_doe"}}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: viewerID}, nil)
+	profileRepoMock.On("SearchUsers", ctx, viewerID, "jane", 2, 5).Return(&domain.Pagination[*domain.UserSearchResult]{
+		Limit:      5,
+		Page:       2,
+		TotalRows:  7,
+		TotalPages: 2,
+		Rows:       []*domain.UserSearchResult{followed, other},
+	}, nil)
+
+	response, err := profileService.SearchUsers(ctx, "  jane ", 2, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), response.TotalRows)
+	assert.Equal(t, 2, response.TotalPages)
+	assert.Len(t, response.Rows, 2)
+	assert.Equal(t, "janed", response.Rows[0].Username)
+	assert.True(t, response.Rows[0].YouFollow)
+	assert.False(t, response.Rows[1].YouFollow)
+	profileRepoMock.AssertExpectations(t)
+}
+
+func TestSearchUsers_WhenQueryIsInvalid_ShouldReturnError(t *testing.T) {
+	for _, query := range []string{"", "   ", strings.Repeat("a", domain.MaxUserSearchQueryLength+1)} {
+		ctx := context.Background()
+		profileRepoMock := new(mocks.ProfileRepository)
+		contextServiceMock := new(mocks.ContextService)
+
+		profileService := &profileService{
+			profileRepository: profileRepoMock,
+			contextService:    contextServiceMock,
+		}
+
+		contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: uuid.New()}, nil)
+
+		response, err := profileService.SearchUsers(ctx, query, 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidSearchQuery)
+		assert.Nil(t, response)
+		profileRepoMock.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
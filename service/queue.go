@@ -28,7 +28,7 @@ func NewQueueService(di *internal.Di) (domain.QueueService, error) {
 
 func (q *queueService) Publish(queueName string, message []byte) error {
 	if err := q.rabbitMQClient.Publish(queueName, message); err != nil {
-		return fmt.Errorf("publishing message to queue: %w", err)
+		return fmt.Errorf("error publishing message to queue: %w", err)
 	}
 
 	return nil
@@ -37,7 +37,7 @@ func (q *queueService) Publish(queueName string, message []byte) error {
 func (q *queueService) Consume(queueName string) (<-chan []byte, error) {
 	messages, err := q.rabbitMQClient.Consume(queueName)
 	if err != nil {
-		return nil, fmt.Errorf("consuming message from queue: %w", err)
+		return nil, fmt.Errorf("error consuming message from queue: %w", err)
 	}
 
 	return messages, nil
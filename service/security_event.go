@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+)
+
+type securityEventService struct {
+	di                      *internal.Di
+	securityEventRepository domain.SecurityEventRepository
+	clientInfoService       domain.ClientInfoService
+	contextService          domain.ContextService
+}
+
+func NewSecurityEventService(di *internal.Di) (domain.SecurityEventService, error) {
+	securityEventRepository, err := internal.Invoke[domain.SecurityEventRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	clientInfoService, err := internal.Invoke[domain.ClientInfoService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &securityEventService{
+		di:                      di,
+		securityEventRepository: securityEventRepository,
+		clientInfoService:       clientInfoService,
+		contextService:          contextService,
+	}, nil
+}
+
+// Record stores the event with the client found in ctx. Audit logging must not
+// break the flow it observes, so failures are only logged.
+func (s *securityEventService) Record(ctx context.Context, userID uuid.UUID, eventType domain.SecurityEventType, details string) {
+	log := slog.With(
+		slog.String("service", "securityEvent"),
+		slog.String("func", "Record"),
+		slog.String("userId", userID.String()),
+		slog.String("type", string(eventType)),
+	)
+
+	event := domain.NewSecurityEvent(userID, eventType, details)
+
+	clientInfo, err := s.clientInfoService.GetClientInfo(ctx)
+	if err != nil {
+		log.Warn("get client info for security event", slog.String("error", err.Error()))
+	} else {
+		event.Device = clientInfo.Device
+		event.Location = clientInfo.Location
+		event.IP = clientInfo.IP
+	}
+
+	if err := s.securityEventRepository.CreateEvent(ctx, *event); err != nil {
+		log.Error("create security event", slog.String("error", err.Error()))
+	}
+}
+
+func (s *securityEventService) GetSecurityEvents(ctx context.Context, page, limit int) (*domain.Pagination[*domain.SecurityEventResponse], error) {
+	session, err := s.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.securityEventRepository.GetEvents(ctx, session.UserID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get security events: %w", err)
+	}
+
+	return domain.Map(events, func(event *domain.SecurityEvent) *domain.SecurityEventResponse {
+		return event.ToSecurityEventResponse()
+	}), nil
+}
+
+// PurgeExpiredEvents deletes events older than the retention window in
+// batches, keeping each DELETE short enough not to hold locks on the table.
+func (s *securityEventService) PurgeExpiredEvents(ctx context.Context) (int64, error) {
+	retentionDays := config.Env.SecurityEvent.RetentionDays
+	if retentionDays <= 0 {
+		retentionDays = domain.DefaultSecurityEventRetentionDays
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+
+	var total int64
+	for {
+		deleted, err := s.securityEventRepository.DeleteEventsBefore(ctx, cutoff, domain.SecurityEventPurgeBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("delete security events before %s: %w", cutoff.Format(time.RFC3339), err)
+		}
+
+		total += deleted
+		if deleted < domain.SecurityEventPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
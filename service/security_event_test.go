@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecord_WhenClientInfoIsAvailable_ShouldStoreEventWithClientInfo(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	clientInfoServiceMock := new(mocks.ClientInfoService)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+		clientInfoService:       clientInfoServiceMock,
+	}
+
+	userID := uuid.New()
+	clientInfoServiceMock.On("GetClientInfo", ctx).Return(&domain.ClientInfoResponse{
+		Device:   "Chrome (Linux 120.0)",
+		Location: "Rio de Janeiro, RJ, Brazil",
+		IP:       testClientIP,
+	}, nil)
+	securityEventRepoMock.On("CreateEvent", ctx, mock.MatchedBy(func(event domain.SecurityEvent) bool {
+		return event.UserID == userID &&
+			event.Type == domain.SecurityEventSignInSuccess &&
+			event.Device == "Chrome (Linux 120.0)" &&
+			event.Location == "Rio de Janeiro, RJ, Brazil" &&
+			event.IP == testClientIP
+	})).Return(nil)
+
+	securityEventService.Record(ctx, userID, domain.SecurityEventSignInSuccess, "")
+
+	securityEventRepoMock.AssertExpectations(t)
+}
+
+func TestRecord_WhenClientInfoFails_ShouldStoreEventAsUnknownClient(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	clientInfoServiceMock := new(mocks.ClientInfoService)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+		clientInfoService:       clientInfoServiceMock,
+	}
+
+	userID := uuid.New()
+	clientInfoServiceMock.On("GetClientInfo", ctx).Return(nil, errors.New("user agent not found"))
+	securityEventRepoMock.On("CreateEvent", ctx, mock.MatchedBy(func(event domain.SecurityEvent) bool {
+		return event.Device == "Unknown" && event.Location == "Unknown" && event.IP == "Unknown" && event.Details == "session revoked"
+	})).Return(nil)
+
+	securityEventService.Record(ctx, userID, domain.SecurityEventSessionRevoke, "session revoked")
+
+	securityEventRepoMock.AssertExpectations(t)
+}
+
+func TestRecord_WhenRepositoryFails_ShouldNotPanic(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	clientInfoServiceMock := new(mocks.ClientInfoService)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+		clientInfoService:       clientInfoServiceMock,
+	}
+
+	clientInfoServiceMock.On("GetClientInfo", ctx).Return(&domain.ClientInfoResponse{}, nil)
+	securityEventRepoMock.On("CreateEvent", ctx, mock.Anything).Return(errors.New("database error"))
+
+	assert.NotPanics(t, func() {
+		securityEventService.Record(ctx, uuid.New(), domain.SecurityEventSignOut, "")
+	})
+}
+
+func TestGetSecurityEvents_WhenSuccessful_ShouldReturnEventsOfCurrentUser(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+		contextService:          contextServiceMock,
+	}
+
+	session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+	event := &domain.SecurityEvent{ID: uuid.New(), UserID: session.UserID, Type: domain.SecurityEventPasswordChange, IP: testClientIP}
+
+	contextServiceMock.On("Session", ctx).Return(session, nil)
+	securityEventRepoMock.On("GetEvents", ctx, session.UserID, 2, 5).Return(&domain.Pagination[*domain.SecurityEvent]{
+		Page:       2,
+		Limit:      5,
+		TotalRows:  6,
+		TotalPages: 2,
+		Rows:       []*domain.SecurityEvent{event},
+	}, nil)
+
+	response, err := securityEventService.GetSecurityEvents(ctx, 2, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), response.TotalRows)
+	assert.Len(t, response.Rows, 1)
+	assert.Equal(t, event.ID, response.Rows[0].ID)
+	assert.Equal(t, domain.SecurityEventPasswordChange, response.Rows[0].Type)
+}
+
+func TestGetSecurityEvents_WhenSessionIsMissing_ShouldReturnErrorSessionNotFound(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+		contextService:          contextServiceMock,
+	}
+
+	contextServiceMock.On("Session", ctx).Return(nil, domain.ErrSessionNotFound)
+
+	response, err := securityEventService.GetSecurityEvents(ctx, 1, 10)
+
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+	assert.Nil(t, response)
+	securityEventRepoMock.AssertNotCalled(t, "GetEvents", ctx, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurgeExpiredEvents_WhenBatchesAreFull_ShouldDeleteUntilExhausted(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+	}
+
+	config.Env.SecurityEvent.RetentionDays = 30
+	t.Cleanup(func() { config.Env.SecurityEvent.RetentionDays = 0 })
+
+	expectedCutoff := time.Now().UTC().AddDate(0, 0, -30)
+	cutoffMatcher := mock.MatchedBy(func(before time.Time) bool {
+		return before.Sub(expectedCutoff).Abs() < time.Minute
+	})
+
+	securityEventRepoMock.On("DeleteEventsBefore", ctx, cutoffMatcher, domain.SecurityEventPurgeBatchSize).
+		Return(int64(domain.SecurityEventPurgeBatchSize), nil).Twice()
+	securityEventRepoMock.On("DeleteEventsBefore", ctx, cutoffMatcher, domain.SecurityEventPurgeBatchSize).
+		Return(int64(7), nil).Once()
+
+	deleted, err := securityEventService.PurgeExpiredEvents(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2*domain.SecurityEventPurgeBatchSize+7), deleted)
+	securityEventRepoMock.AssertNumberOfCalls(t, "DeleteEventsBefore", 3)
+}
+
+func TestPurgeExpiredEvents_WhenRetentionIsNotConfigured_ShouldUseDefaultRetention(t *testing.T) {
+	ctx := context.Background()
+	securityEventRepoMock := new(mocks.SecurityEventRepository)
+
+	securityEventService := &securityEventService{
+		securityEventRepository: securityEventRepoMock,
+	}
+
+	expectedCutoff := time.Now().UTC().AddDate(0, 0, -domain.DefaultSecurityEventRetentionDays)
+	securityEventRepoMock.On("DeleteEventsBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return before.Sub(expectedCutoff).Abs() < time.Minute
+	}), domain.SecurityEventPurgeBatchSize).Return(int64(0), nil)
+
+	deleted, err := securityEventService.PurgeExpiredEvents(ctx)
+
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+	securityEventRepoMock.AssertExpectations(t)
+}
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/G-Villarinho/social-network/config"
 	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
@@ -14,8 +18,12 @@ import (
 )
 
 type sessionService struct {
-	di                *internal.Di
-	sessionRepository domain.SessionRepository
+	di                      *internal.Di
+	sessionRepository       domain.SessionRepository
+	clientInfoService       domain.ClientInfoService
+	contextService          domain.ContextService
+	accountStatusRepository domain.AccountStatusRepository
+	securityEventService    domain.SecurityEventService
 }
 
 func NewSessionService(di *internal.Di) (domain.SessionService, error) {
@@ -24,43 +32,135 @@ func NewSessionService(di *internal.Di) (domain.SessionService, error) {
 		return nil, err
 	}
 
+	clientInfoService, err := internal.Invoke[domain.ClientInfoService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	accountStatusRepository, err := internal.Invoke[domain.AccountStatusRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	securityEventService, err := internal.Invoke[domain.SecurityEventService](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &sessionService{
-		di:                di,
-		sessionRepository: sessionRepository,
+		di:                      di,
+		sessionRepository:       sessionRepository,
+		clientInfoService:       clientInfoService,
+		contextService:          contextService,
+		accountStatusRepository: accountStatusRepository,
+		securityEventService:    securityEventService,
 	}, nil
 }
 
-func (s *sessionService) CreateSession(ctx context.Context, user domain.User) (string, error) {
-	token, err := s.createToken(user)
+func (s *sessionService) CreateSession(ctx context.Context, user domain.User) (*domain.AuthTokens, error) {
+	now := time.Now().UTC()
+	session := &domain.Session{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Username:   user.Username,
+		Email:      user.Email,
+		Avatar:     user.Avatar,
+		Role:       user.Role,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+
+	tokens, err := s.issueTokens(session)
 	if err != nil {
-		return "", fmt.Errorf("error to create token for user ID %s: %w", user.ID, err)
+		return nil, fmt.Errorf("error to create token for user ID %s: %w", user.ID, err)
 	}
 
-	session := &domain.Session{
-		UserID:    user.ID,
-		Token:     token,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
-		Avatar:    user.Avatar,
+	clientInfo, err := s.clientInfoService.GetClientInfo(ctx)
+	if err != nil {
+		slog.Warn("get client info for session", slog.String("error", err.Error()))
+	} else {
+		session.Device = clientInfo.Device
+		session.IP = clientInfo.IP
+		session.Location = clientInfo.Location
 	}
 
 	if err := s.sessionRepository.CreateSession(ctx, *session); err != nil {
-		return "", fmt.Errorf("error to create session for user ID %s: %w", user.ID, err)
+		return nil, fmt.Errorf("error to create session for user ID %s: %w", user.ID, err)
+	}
+
+	return tokens, nil
+}
+
+func (s *sessionService) RefreshSession(ctx context.Context, refreshToken string) (*domain.AuthTokens, error) {
+	tokenHash := secure.HashToken(refreshToken)
+
+	record, err := s.sessionRepository.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("error to get refresh token: %w", err)
+	}
+
+	if record == nil {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+
+	if err := s.checkAccountStatus(ctx, record.UserID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepository.GetSession(ctx, record.UserID, record.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("error to get session for user ID: %w", err)
 	}
 
-	return token, nil
+	if session == nil {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
 
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(tokenHash)) != 1 {
+		slog.Warn("refresh token reuse detected, revoking session",
+			slog.String("user_id", session.UserID.String()),
+			slog.String("session_id", session.ID.String()),
+		)
+
+		if err := s.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+			return nil, err
+		}
+
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	tokens, err := s.issueTokens(session)
+	if err != nil {
+		return nil, fmt.Errorf("error to create token for user ID %s: %w", session.UserID, err)
+	}
+
+	session.LastSeenAt = time.Now().UTC()
+	if err := s.sessionRepository.RenewSession(ctx, *session); err != nil {
+		return nil, fmt.Errorf("error to renew session: %w", err)
+	}
+
+	return tokens, nil
 }
 
 func (s *sessionService) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
 	sessionFromToken, err := s.extractSessionFromToken(token)
 	if err != nil {
-		return nil, fmt.Errorf("error to extract session from token: %w", err)
+		slog.Debug("extract session from token", slog.String("error", err.Error()))
+		return nil, domain.ErrTokenInvalid
 	}
 
-	session, err := s.sessionRepository.GetSessionByUserID(ctx, sessionFromToken.UserID)
+	if err := s.checkAccountStatus(ctx, sessionFromToken.UserID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepository.GetSession(ctx, sessionFromToken.UserID, sessionFromToken.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error to get session for user ID: %w", err)
 	}
@@ -73,45 +173,168 @@ func (s *sessionService) GetSessionByToken(ctx context.Context, token string) (*
 		return nil, domain.ErrSessionMismatch
 	}
 
+	if time.Since(session.LastSeenAt) > domain.SessionLastSeenInterval {
+		session.LastSeenAt = time.Now().UTC()
+		if err := s.sessionRepository.UpdateSession(ctx, *session); err != nil {
+			return nil, fmt.Errorf("error to update session last seen: %w", err)
+		}
+	}
+
 	return session, nil
 }
 
-func (s *sessionService) DeleteSession(ctx context.Context, userID uuid.UUID) error {
-	if err := s.sessionRepository.DeleteSession(ctx, userID); err != nil {
+func (s *sessionService) GetSessions(ctx context.Context) ([]*domain.SessionResponse, error) {
+	currentSession, err := s.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionRepository.GetSessionsByUserID(ctx, currentSession.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error to get sessions for user ID: %w", err)
+	}
+
+	sessionsResponse := make([]*domain.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsResponse = append(sessionsResponse, session.ToSessionResponse(currentSession.ID))
+	}
+
+	return sessionsResponse, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	currentSession, err := s.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.sessionRepository.GetSession(ctx, currentSession.UserID, sessionID)
+	if err != nil {
+		return fmt.Errorf("error to get session: %w", err)
+	}
+
+	if session == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := s.DeleteSession(ctx, currentSession.UserID, sessionID); err != nil {
+		return err
+	}
+
+	s.securityEventService.Record(ctx, currentSession.UserID, domain.SecurityEventSessionRevoke, fmt.Sprintf("session %s on %s", session.ID, session.Device))
+
+	return nil
+}
+
+func (s *sessionService) RevokeOtherSessions(ctx context.Context) error {
+	currentSession, err := s.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := s.sessionRepository.GetSessionsByUserID(ctx, currentSession.UserID)
+	if err != nil {
+		return fmt.Errorf("error to get sessions for user ID: %w", err)
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == currentSession.ID {
+			continue
+		}
+
+		if err := s.DeleteSession(ctx, currentSession.UserID, session.ID); err != nil {
+			return err
+		}
+		revoked++
+	}
+
+	if revoked > 0 {
+		s.securityEventService.Record(ctx, currentSession.UserID, domain.SecurityEventOtherSessionsRevoke, fmt.Sprintf("%d session(s)", revoked))
+	}
+
+	return nil
+}
+
+func (s *sessionService) GetJWKS() (*secure.JSONWebKeySet, error) {
+	keySet, err := config.Env.KeyRing.JWKS()
+	if err != nil {
+		return nil, fmt.Errorf("error to build JWKS: %w", err)
+	}
+
+	return keySet, nil
+}
+
+func (s *sessionService) DeleteSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	if err := s.sessionRepository.DeleteSession(ctx, userID, sessionID); err != nil {
 		return fmt.Errorf("error to delete session for user ID: %w", err)
 	}
 
 	return nil
 }
 
-func (s *sessionService) createToken(user domain.User) (string, error) {
+func (s *sessionService) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := s.sessionRepository.DeleteSessionsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("error to delete sessions for user ID: %w", err)
+	}
+
+	return nil
+}
+
+// issueTokens signs a new access token and generates a new refresh token for
+// the session, storing the access token and the refresh token hash on it.
+func (s *sessionService) issueTokens(session *domain.Session) (*domain.AuthTokens, error) {
+	accessToken, err := s.createToken(*session)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := secure.GenerateToken(domain.RefreshTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("error to generate refresh token: %w", err)
+	}
+
+	session.Token = accessToken
+	session.RefreshTokenHash = secure.HashToken(refreshToken)
+
+	return &domain.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		SessionID:    session.ID,
+	}, nil
+}
+
+func (s *sessionService) createToken(session domain.Session) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"id":        user.ID,
-		"firstName": user.FirstName,
-		"lastName":  user.LastName,
-		"email":     user.Email,
-		"username":  user.Username,
-		"avatar":    user.Avatar,
+		"sub":       session.UserID.String(),
+		"jti":       uuid.NewString(),
+		"iat":       now.Unix(),
+		"exp":       now.Add(time.Duration(config.Env.Cache.AccessTokenExp) * time.Minute).Unix(),
+		"id":        session.UserID,
+		"sid":       session.ID,
+		"firstName": session.FirstName,
+		"lastName":  session.LastName,
+		"email":     session.Email,
+		"username":  session.Username,
+		"avatar":    session.Avatar,
+		"role":      session.Role,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	if config.Env.JWT.Issuer != "" {
+		claims["iss"] = config.Env.JWT.Issuer
+	}
 
-	tokenString, err := token.SignedString(config.Env.PrivateKey)
+	tokenString, err := config.Env.KeyRing.Sign(claims)
 	if err != nil {
-		return "", fmt.Errorf("error to sign token for user ID %s: %w", user.ID, err)
+		return "", fmt.Errorf("error to sign token for user ID %s: %w", session.UserID, err)
 	}
 
 	return tokenString, nil
 }
 
 func (s *sessionService) extractSessionFromToken(tokenString string) (*domain.Session, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
-			return nil, domain.ErrorUnexpectedMethod
-		}
-		return config.Env.PublicKey, nil
-	})
-
+	token, err := jwt.Parse(tokenString, config.Env.KeyRing.Keyfunc)
 	if err != nil {
 		return nil, fmt.Errorf("error to parse token: %w", err)
 	}
@@ -125,6 +348,14 @@ func (s *sessionService) extractSessionFromToken(tokenString string) (*domain.Se
 		return nil, domain.ErrTokenInvalid
 	}
 
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, domain.ErrTokenInvalid
+	}
+
+	if config.Env.JWT.Issuer != "" && !claims.VerifyIssuer(config.Env.JWT.Issuer, true) {
+		return nil, domain.ErrTokenInvalid
+	}
+
 	sessionJSON, err := jsoniter.Marshal(claims)
 	if err != nil {
 		return nil, fmt.Errorf("error to marshal claims into JSON: %w", err)
@@ -137,3 +368,12 @@ func (s *sessionService) extractSessionFromToken(tokenString string) (*domain.Se
 
 	return &session, nil
 }
+
+func (s *sessionService) checkAccountStatus(ctx context.Context, userID uuid.UUID) error {
+	status, err := s.accountStatusRepository.GetStatus(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get account status: %w", err)
+	}
+
+	return domain.CheckUserStatus(status)
+}
@@ -2,12 +2,21 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteSession_WhenSessionDeletedSuccessfully_ShouldNotReturnError(t *testing.T) {
@@ -15,13 +24,14 @@ func TestDeleteSession_WhenSessionDeletedSuccessfully_ShouldNotReturnError(t *te
 	sessionRepoMock := new(mocks.SessionRepository)
 
 	userID := uuid.New()
+	sessionID := uuid.New()
 	sessionService := &sessionService{
 		sessionRepository: sessionRepoMock,
 	}
 
-	sessionRepoMock.On("DeleteSession", ctx, userID).Return(nil)
+	sessionRepoMock.On("DeleteSession", ctx, userID, sessionID).Return(nil)
 
-	err := sessionService.DeleteSession(ctx, userID)
+	err := sessionService.DeleteSession(ctx, userID, sessionID)
 
 	assert.NoError(t, err)
 	sessionRepoMock.AssertExpectations(t)
@@ -32,13 +42,14 @@ func TestDeleteSession_WhenRepositoryFails_ShouldReturnError(t *testing.T) {
 	sessionRepoMock := new(mocks.SessionRepository)
 
 	userID := uuid.New()
+	sessionID := uuid.New()
 	sessionService := &sessionService{
 		sessionRepository: sessionRepoMock,
 	}
 
-	sessionRepoMock.On("DeleteSession", ctx, userID).Return(errors.New("repository error"))
+	sessionRepoMock.On("DeleteSession", ctx, userID, sessionID).Return(errors.New("repository error"))
 
-	err := sessionService.DeleteSession(ctx, userID)
+	err := sessionService.DeleteSession(ctx, userID, sessionID)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository error")
@@ -56,3 +67,387 @@ func TestExtractSessionFromToken_WhenTokenIsInvalid_ShouldReturnError(t *testing
 	assert.Contains(t, err.Error(), "error to parse token")
 	assert.Nil(t, session)
 }
+
+func TestExtractSessionFromToken_WhenTokenIsExpired_ShouldReturnErrorTokenInvalid(t *testing.T) {
+	setupTestKeys(t)
+	config.Env.Cache.AccessTokenExp = -1
+
+	sessionService := &sessionService{}
+	token, err := sessionService.createToken(domain.Session{ID: uuid.New(), UserID: uuid.New()})
+	assert.NoError(t, err)
+
+	session, err := sessionService.extractSessionFromToken(token)
+
+	assert.Error(t, err)
+	assert.Nil(t, session)
+}
+
+func TestExtractSessionFromToken_WhenSignedWithRetiredKey_ShouldReturnSession(t *testing.T) {
+	setupTestKeys(t)
+	retiredKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	config.Env.KeyRing, err = secure.NewKeyRing("retired", retiredKey, nil)
+	assert.NoError(t, err)
+
+	sessionService := &sessionService{}
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	token, err := sessionService.createToken(domain.Session{ID: sessionID, UserID: userID})
+	assert.NoError(t, err)
+
+	activeKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	config.Env.KeyRing, err = secure.NewKeyRing("active", activeKey, map[string]*ecdsa.PublicKey{
+		"retired": &retiredKey.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	session, err := sessionService.extractSessionFromToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, sessionID, session.ID)
+}
+
+func TestExtractSessionFromToken_WhenKeyIDIsUnknown_ShouldReturnError(t *testing.T) {
+	setupTestKeys(t)
+	sessionService := &sessionService{}
+
+	token, err := sessionService.createToken(domain.Session{ID: uuid.New(), UserID: uuid.New()})
+	assert.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	config.Env.KeyRing, err = secure.NewKeyRing("other-key", otherKey, nil)
+	assert.NoError(t, err)
+
+	session, err := sessionService.extractSessionFromToken(token)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), secure.ErrUnknownKeyID.Error())
+	assert.Nil(t, session)
+}
+
+func TestCreateToken_WhenSuccessful_ShouldSetRegisteredClaimsAndKeyID(t *testing.T) {
+	setupTestKeys(t)
+	sessionService := &sessionService{}
+
+	userID := uuid.New()
+	tokenString, err := sessionService.createToken(domain.Session{ID: uuid.New(), UserID: userID})
+	assert.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, config.Env.KeyRing.Keyfunc)
+	assert.NoError(t, err)
+
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, config.Env.KeyRing.ActiveKeyID(), token.Header["kid"])
+	assert.Equal(t, userID.String(), claims["sub"])
+	assert.NotEmpty(t, claims["jti"])
+	assert.NotNil(t, claims["iat"])
+	assert.NotNil(t, claims["exp"])
+}
+
+func TestGetJWKS_WhenSuccessful_ShouldReturnActiveAndRetiredKeys(t *testing.T) {
+	activeKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	retiredKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	config.Env.KeyRing, err = secure.NewKeyRing("active", activeKey, map[string]*ecdsa.PublicKey{
+		"retired": &retiredKey.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	sessionService := &sessionService{}
+
+	keySet, err := sessionService.GetJWKS()
+
+	assert.NoError(t, err)
+	assert.Len(t, keySet.Keys, 2)
+	assert.Equal(t, "active", keySet.Keys[0].Kid)
+	assert.Equal(t, "retired", keySet.Keys[1].Kid)
+	assert.Equal(t, "EC", keySet.Keys[0].Kty)
+	assert.Equal(t, "ES256", keySet.Keys[0].Alg)
+}
+
+func TestCreateSession_WhenSuccessful_ShouldStoreSessionWithClientInfo(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	clientInfoServiceMock := new(mocks.ClientInfoService)
+
+	sessionService := &sessionService{
+		sessionRepository: sessionRepoMock,
+		clientInfoService: clientInfoServiceMock,
+	}
+
+	user := domain.User{ID: uuid.New(), Username: "gabriel"}
+	clientInfo := &domain.ClientInfoResponse{Device: "Chrome (Linux 120)", IP: "10.0.0.1", Location: "Rio de Janeiro"}
+
+	var storedSession domain.Session
+	clientInfoServiceMock.On("GetClientInfo", ctx).Return(clientInfo, nil)
+	sessionRepoMock.On("CreateSession", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		storedSession = args.Get(1).(domain.Session)
+	}).Return(nil)
+
+	tokens, err := sessionService.CreateSession(ctx, user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, storedSession.Token, tokens.AccessToken)
+	assert.Equal(t, secure.HashToken(tokens.RefreshToken), storedSession.RefreshTokenHash)
+	assert.NotEqual(t, uuid.Nil, storedSession.ID)
+	assert.Equal(t, clientInfo.Device, storedSession.Device)
+	assert.Equal(t, clientInfo.IP, storedSession.IP)
+	assert.Equal(t, clientInfo.Location, storedSession.Location)
+
+	sessionFromToken, err := sessionService.extractSessionFromToken(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, storedSession.ID, sessionFromToken.ID)
+	assert.Equal(t, user.ID, sessionFromToken.UserID)
+}
+
+func TestRefreshSession_WhenTokenIsUnknown_ShouldReturnErrorRefreshTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+
+	sessionService := &sessionService{
+		sessionRepository: sessionRepoMock,
+	}
+
+	sessionRepoMock.On("GetRefreshToken", ctx, secure.HashToken("unknown")).Return(nil, nil)
+
+	tokens, err := sessionService.RefreshSession(ctx, "unknown")
+
+	assert.Equal(t, domain.ErrRefreshTokenInvalid, err)
+	assert.Nil(t, tokens)
+	sessionRepoMock.AssertExpectations(t)
+}
+
+func TestRefreshSession_WhenSuccessful_ShouldRotateTokens(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	refreshToken := "current-refresh-token"
+	storedSession := &domain.Session{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		Token:            "old-access-token",
+		RefreshTokenHash: secure.HashToken(refreshToken),
+	}
+
+	var renewedSession domain.Session
+	sessionRepoMock.On("GetRefreshToken", ctx, storedSession.RefreshTokenHash).
+		Return(&domain.RefreshToken{UserID: storedSession.UserID, SessionID: storedSession.ID}, nil)
+	accountStatusRepoMock.On("GetStatus", ctx, storedSession.UserID).Return(domain.Active, nil)
+	sessionRepoMock.On("GetSession", ctx, storedSession.UserID, storedSession.ID).Return(storedSession, nil)
+	sessionRepoMock.On("RenewSession", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		renewedSession = args.Get(1).(domain.Session)
+	}).Return(nil)
+
+	tokens, err := sessionService.RefreshSession(ctx, refreshToken)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+	assert.Equal(t, tokens.AccessToken, renewedSession.Token)
+	assert.Equal(t, secure.HashToken(tokens.RefreshToken), renewedSession.RefreshTokenHash)
+	sessionRepoMock.AssertExpectations(t)
+}
+
+func TestRefreshSession_WhenTokenWasAlreadyUsed_ShouldRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	usedToken := "used-refresh-token"
+	storedSession := &domain.Session{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		RefreshTokenHash: secure.HashToken("rotated-refresh-token"),
+	}
+
+	sessionRepoMock.On("GetRefreshToken", ctx, secure.HashToken(usedToken)).
+		Return(&domain.RefreshToken{UserID: storedSession.UserID, SessionID: storedSession.ID}, nil)
+	accountStatusRepoMock.On("GetStatus", ctx, storedSession.UserID).Return(domain.Active, nil)
+	sessionRepoMock.On("GetSession", ctx, storedSession.UserID, storedSession.ID).Return(storedSession, nil)
+	sessionRepoMock.On("DeleteSession", ctx, storedSession.UserID, storedSession.ID).Return(nil)
+
+	tokens, err := sessionService.RefreshSession(ctx, usedToken)
+
+	assert.Equal(t, domain.ErrRefreshTokenReused, err)
+	assert.Nil(t, tokens)
+	sessionRepoMock.AssertExpectations(t)
+	sessionRepoMock.AssertNotCalled(t, "RenewSession", ctx, mock.Anything)
+}
+
+func TestGetSessionByToken_WhenSessionWasRevoked_ShouldReturnErrorSessionNotFound(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	user := domain.User{ID: uuid.New()}
+	sessionID := uuid.New()
+	token, err := sessionService.createToken(domain.Session{ID: sessionID, UserID: user.ID})
+	assert.NoError(t, err)
+
+	accountStatusRepoMock.On("GetStatus", ctx, user.ID).Return(domain.Active, nil)
+	sessionRepoMock.On("GetSession", ctx, user.ID, sessionID).Return(nil, nil)
+
+	session, err := sessionService.GetSessionByToken(ctx, token)
+
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+	assert.Nil(t, session)
+	sessionRepoMock.AssertExpectations(t)
+}
+
+func TestGetSessionByToken_WhenLastSeenIsStale_ShouldUpdateLastSeen(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	user := domain.User{ID: uuid.New()}
+	sessionID := uuid.New()
+	token, err := sessionService.createToken(domain.Session{ID: sessionID, UserID: user.ID})
+	assert.NoError(t, err)
+
+	storedSession := &domain.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		Token:      token,
+		LastSeenAt: time.Now().UTC().Add(-time.Hour),
+	}
+
+	accountStatusRepoMock.On("GetStatus", ctx, user.ID).Return(domain.Active, nil)
+	sessionRepoMock.On("GetSession", ctx, user.ID, sessionID).Return(storedSession, nil)
+	sessionRepoMock.On("UpdateSession", ctx, mock.MatchedBy(func(session domain.Session) bool {
+		return time.Since(session.LastSeenAt) < time.Minute
+	})).Return(nil)
+
+	session, err := sessionService.GetSessionByToken(ctx, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, session.ID)
+	sessionRepoMock.AssertExpectations(t)
+}
+
+func TestRevokeSession_WhenSessionNotFound_ShouldReturnErrorSessionNotFound(t *testing.T) {
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	sessionService := &sessionService{
+		sessionRepository: sessionRepoMock,
+		contextService:    contextServiceMock,
+	}
+
+	currentSession := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+	sessionID := uuid.New()
+
+	contextServiceMock.On("Session", ctx).Return(currentSession, nil)
+	sessionRepoMock.On("GetSession", ctx, currentSession.UserID, sessionID).Return(nil, nil)
+
+	err := sessionService.RevokeSession(ctx, sessionID)
+
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+	sessionRepoMock.AssertNotCalled(t, "DeleteSession", ctx, currentSession.UserID, sessionID)
+}
+
+func TestRevokeOtherSessions_WhenSuccessful_ShouldKeepCurrentSession(t *testing.T) {
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	contextServiceMock := new(mocks.ContextService)
+	securityEventServiceMock := new(mocks.SecurityEventService)
+
+	sessionService := &sessionService{
+		sessionRepository:    sessionRepoMock,
+		contextService:       contextServiceMock,
+		securityEventService: securityEventServiceMock,
+	}
+
+	userID := uuid.New()
+	currentSession := &domain.Session{ID: uuid.New(), UserID: userID}
+	otherSession := &domain.Session{ID: uuid.New(), UserID: userID}
+
+	contextServiceMock.On("Session", ctx).Return(currentSession, nil)
+	sessionRepoMock.On("GetSessionsByUserID", ctx, userID).Return([]*domain.Session{currentSession, otherSession}, nil)
+	sessionRepoMock.On("DeleteSession", ctx, userID, otherSession.ID).Return(nil)
+	securityEventServiceMock.On("Record", ctx, userID, domain.SecurityEventOtherSessionsRevoke, "1 session(s)").Return()
+
+	err := sessionService.RevokeOtherSessions(ctx)
+
+	assert.NoError(t, err)
+	sessionRepoMock.AssertExpectations(t)
+	sessionRepoMock.AssertNotCalled(t, "DeleteSession", ctx, userID, currentSession.ID)
+	securityEventServiceMock.AssertExpectations(t)
+}
+
+func TestRefreshSession_WhenUserIsBlocked_ShouldReturnErrorUserBlocked(t *testing.T) {
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	refreshToken := "current-refresh-token"
+	record := &domain.RefreshToken{UserID: uuid.New(), SessionID: uuid.New()}
+
+	sessionRepoMock.On("GetRefreshToken", ctx, secure.HashToken(refreshToken)).Return(record, nil)
+	accountStatusRepoMock.On("GetStatus", ctx, record.UserID).Return(domain.Block, nil)
+
+	tokens, err := sessionService.RefreshSession(ctx, refreshToken)
+
+	assert.Equal(t, domain.ErrUserBlocked, err)
+	assert.Nil(t, tokens)
+	sessionRepoMock.AssertNotCalled(t, "RenewSession", ctx, mock.Anything)
+}
+
+func TestGetSessionByToken_WhenUserIsInactive_ShouldReturnErrorUserInactive(t *testing.T) {
+	setupTestKeys(t)
+	ctx := context.Background()
+	sessionRepoMock := new(mocks.SessionRepository)
+	accountStatusRepoMock := new(mocks.AccountStatusRepository)
+
+	sessionService := &sessionService{
+		sessionRepository:       sessionRepoMock,
+		accountStatusRepository: accountStatusRepoMock,
+	}
+
+	userID := uuid.New()
+	token, err := sessionService.createToken(domain.Session{ID: uuid.New(), UserID: userID})
+	assert.NoError(t, err)
+
+	accountStatusRepoMock.On("GetStatus", ctx, userID).Return(domain.Inactive, nil)
+
+	session, err := sessionService.GetSessionByToken(ctx, token)
+
+	assert.Equal(t, domain.ErrUserInactive, err)
+	assert.Nil(t, session)
+	sessionRepoMock.AssertNotCalled(t, "GetSession", ctx, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+)
+
+type twoFactorService struct {
+	di                   *internal.Di
+	twoFactorRepository  domain.TwoFactorRepository
+	userRepository       domain.UserRepository
+	contextService       domain.ContextService
+	securityEventService domain.SecurityEventService
+}
+
+func NewTwoFactorService(di *internal.Di) (domain.TwoFactorService, error) {
+	twoFactorRepository, err := internal.Invoke[domain.TwoFactorRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	securityEventService, err := internal.Invoke[domain.SecurityEventService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactorService{
+		di:                   di,
+		twoFactorRepository:  twoFactorRepository,
+		userRepository:       userRepository,
+		contextService:       contextService,
+		securityEventService: securityEventService,
+	}, nil
+}
+
+// BeginEnrollment generates a new TOTP secret for the signed in user. It only
+// takes effect once ConfirmEnrollment receives a code from it, starting over
+// replaces a previous unconfirmed secret.
+func (t *twoFactorService) BeginEnrollment(ctx context.Context) (*domain.TwoFactorEnrollmentResponse, error) {
+	user, err := t.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := twoFactorKey()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secure.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generate TOTP secret: %w", err)
+	}
+
+	encryptedSecret, err := secure.Encrypt(key, secret)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt TOTP secret: %w", err)
+	}
+
+	if err := t.twoFactorRepository.DeleteTwoFactor(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("delete pending two-factor enrollment: %w", err)
+	}
+
+	if err := t.twoFactorRepository.SaveTwoFactor(ctx, domain.TwoFactor{UserID: user.ID, Secret: encryptedSecret}); err != nil {
+		return nil, fmt.Errorf("save two-factor enrollment: %w", err)
+	}
+
+	return &domain.TwoFactorEnrollmentResponse{
+		Secret: secret,
+		URI:    secure.TOTPURI(twoFactorIssuer(), user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables two-factor authentication once the user proves
+// the authenticator app was set up, and returns the recovery codes. They are
+// only stored hashed, so this is the one time they can be shown.
+func (t *twoFactorService) ConfirmEnrollment(ctx context.Context, payload domain.ConfirmTwoFactorPayload) (*domain.RecoveryCodesResponse, error) {
+	user, err := t.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	twoFactor, err := t.twoFactorRepository.GetTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get two-factor enrollment: %w", err)
+	}
+
+	if twoFactor == nil || twoFactor.IsEnabled() {
+		return nil, domain.ErrTwoFactorEnrollmentNotFound
+	}
+
+	secret, err := t.decryptSecret(twoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	step, valid, err := secure.ValidateTOTP(secret, payload.Code, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("validate TOTP code: %w", err)
+	}
+
+	if !valid {
+		return nil, domain.ErrTwoFactorCodeWrong
+	}
+
+	recoveryCodes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.twoFactorRepository.ReplaceRecoveryCodes(ctx, user.ID, codeHashes); err != nil {
+		return nil, fmt.Errorf("replace recovery codes: %w", err)
+	}
+
+	now := time.Now().UTC()
+	twoFactor.EnabledAt = &now
+	twoFactor.LastUsedStep = step
+	if err := t.twoFactorRepository.SaveTwoFactor(ctx, *twoFactor); err != nil {
+		return nil, fmt.Errorf("enable two-factor: %w", err)
+	}
+
+	user.TwoFactorEnabled = true
+	if err := t.userRepository.UpdateUser(ctx, *user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	t.securityEventService.Record(ctx, user.ID, domain.SecurityEventTwoFactorEnable, "totp")
+
+	return &domain.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. The user must sign in
+// again within the request: the current password and a TOTP or recovery code.
+func (t *twoFactorService) DisableTwoFactor(ctx context.Context, payload domain.DisableTwoFactorPayload) error {
+	user, err := t.sessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	if err := secure.CheckPassword(user.Password, payload.Password); err != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	if err := t.VerifyCode(ctx, user.ID, payload.Code, payload.RecoveryCode); err != nil {
+		return err
+	}
+
+	if err := t.twoFactorRepository.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+
+	if err := t.twoFactorRepository.DeleteTwoFactor(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete two-factor: %w", err)
+	}
+
+	user.TwoFactorEnabled = false
+	if err := t.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	t.securityEventService.Record(ctx, user.ID, domain.SecurityEventTwoFactorDisable, "")
+
+	return nil
+}
+
+// VerifyCode accepts either a TOTP code, once per time step, or an unused
+// recovery code, which is then spent.
+func (t *twoFactorService) VerifyCode(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	twoFactor, err := t.twoFactorRepository.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get two-factor: %w", err)
+	}
+
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	if recoveryCode != "" {
+		used, err := t.twoFactorRepository.UseRecoveryCode(ctx, userID, secure.HashToken(secure.NormalizeRecoveryCode(recoveryCode)))
+		if err != nil {
+			return fmt.Errorf("use recovery code: %w", err)
+		}
+
+		if !used {
+			return domain.ErrTwoFactorCodeWrong
+		}
+
+		return nil
+	}
+
+	secret, err := t.decryptSecret(twoFactor)
+	if err != nil {
+		return err
+	}
+
+	step, valid, err := secure.ValidateTOTP(secret, code, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("validate TOTP code: %w", err)
+	}
+
+	if !valid {
+		return domain.ErrTwoFactorCodeWrong
+	}
+
+	fresh, err := t.twoFactorRepository.UseStep(ctx, userID, step)
+	if err != nil {
+		return fmt.Errorf("use TOTP step: %w", err)
+	}
+
+	if !fresh {
+		return domain.ErrTwoFactorCodeWrong
+	}
+
+	return nil
+}
+
+func (t *twoFactorService) sessionUser(ctx context.Context) (*domain.User, error) {
+	session, err := t.contextService.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := t.userRepository.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (t *twoFactorService) decryptSecret(twoFactor *domain.TwoFactor) (string, error) {
+	key, err := twoFactorKey()
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := secure.Decrypt(key, twoFactor.Secret)
+	if err != nil {
+		return "", fmt.Errorf("decrypt TOTP secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, domain.RecoveryCodeCount)
+	codeHashes := make([]string, domain.RecoveryCodeCount)
+
+	for i := range codes {
+		code, err := secure.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes[i] = code
+		codeHashes[i] = secure.HashToken(secure.NormalizeRecoveryCode(code))
+	}
+
+	return codes, codeHashes, nil
+}
+
+func twoFactorKey() ([]byte, error) {
+	if len(config.Env.TwoFactorKey) == 0 {
+		return nil, domain.ErrTwoFactorKeyMissing
+	}
+
+	return config.Env.TwoFactorKey, nil
+}
+
+func twoFactorIssuer() string {
+	if config.Env.TwoFactor.Issuer == "" {
+		return domain.DefaultTwoFactorIssuer
+	}
+
+	return config.Env.TwoFactor.Issuer
+}
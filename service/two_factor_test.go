@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/social-network/config"
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/G-Villarinho/social-network/secure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setTestTwoFactorKey(t *testing.T) []byte {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	config.Env.TwoFactorKey = key
+	t.Cleanup(func() { config.Env.TwoFactorKey = nil })
+	return key
+}
+
+func newEnabledTwoFactor(t *testing.T, userID uuid.UUID) (*domain.TwoFactor, string) {
+	key := setTestTwoFactorKey(t)
+
+	secret, err := secure.GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	encryptedSecret, err := secure.Encrypt(key, secret)
+	assert.NoError(t, err)
+
+	enabledAt := time.Now().UTC()
+	return &domain.TwoFactor{ID: uuid.New(), UserID: userID, Secret: encryptedSecret, EnabledAt: &enabledAt}, secret
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := secure.TOTPCode(secret, secure.TOTPStep(time.Now().UTC()))
+	assert.NoError(t, err)
+	return code
+}
+
+func TestBeginEnrollment_WhenAlreadyEnabled_ShouldReturnErrTwoFactorAlreadyEnabled(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+
+	twoFactorService := &twoFactorService{
+		contextService:      contextServiceMock,
+		userRepository:      userRepoMock,
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	user := &domain.User{ID: uuid.New(), TwoFactorEnabled: true}
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+
+	response, err := twoFactorService.BeginEnrollment(ctx)
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrTwoFactorAlreadyEnabled, err)
+	twoFactorRepoMock.AssertNotCalled(t, "SaveTwoFactor", mock.Anything, mock.Anything)
+}
+
+func TestBeginEnrollment_WhenSuccessful_ShouldStoreEncryptedSecretAndReturnURI(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+	key := setTestTwoFactorKey(t)
+
+	twoFactorService := &twoFactorService{
+		contextService:      contextServiceMock,
+		userRepository:      userRepoMock,
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	user := &domain.User{ID: uuid.New(), Email: "gabriel@test.com"}
+	var saved domain.TwoFactor
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	twoFactorRepoMock.On("DeleteTwoFactor", ctx, user.ID).Return(nil)
+	twoFactorRepoMock.On("SaveTwoFactor", ctx, mock.AnythingOfType("domain.TwoFactor")).Return(nil).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(domain.TwoFactor)
+	})
+
+	response, err := twoFactorService.BeginEnrollment(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.URI, "otpauth://totp/"))
+	assert.Contains(t, response.URI, "secret="+response.Secret)
+	assert.Nil(t, saved.EnabledAt)
+	assert.NotEqual(t, response.Secret, saved.Secret)
+
+	secret, err := secure.Decrypt(key, saved.Secret)
+	assert.NoError(t, err)
+	assert.Equal(t, response.Secret, secret)
+}
+
+func TestConfirmEnrollment_WhenCodeIsWrong_ShouldReturnErrTwoFactorCodeWrong(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+
+	twoFactorService := &twoFactorService{
+		contextService:      contextServiceMock,
+		userRepository:      userRepoMock,
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	twoFactor, secret := newEnabledTwoFactor(t, user.ID)
+	twoFactor.EnabledAt = nil
+
+	wrongCode := "000000"
+	if currentTOTPCode(t, secret) == wrongCode {
+		wrongCode = "111111"
+	}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	twoFactorRepoMock.On("GetTwoFactor", ctx, user.ID).Return(twoFactor, nil)
+
+	response, err := twoFactorService.ConfirmEnrollment(ctx, domain.ConfirmTwoFactorPayload{Code: wrongCode})
+
+	assert.Nil(t, response)
+	assert.Equal(t, domain.ErrTwoFactorCodeWrong, err)
+	userRepoMock.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestConfirmEnrollment_WhenCodeIsValid_ShouldEnableAndReturnRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+	securityEventServiceMock := new(mocks.SecurityEventService)
+
+	twoFactorService := &twoFactorService{
+		contextService:       contextServiceMock,
+		userRepository:       userRepoMock,
+		twoFactorRepository:  twoFactorRepoMock,
+		securityEventService: securityEventServiceMock,
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	twoFactor, secret := newEnabledTwoFactor(t, user.ID)
+	twoFactor.EnabledAt = nil
+
+	var codeHashes []string
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	twoFactorRepoMock.On("GetTwoFactor", ctx, user.ID).Return(twoFactor, nil)
+	twoFactorRepoMock.On("ReplaceRecoveryCodes", ctx, user.ID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		codeHashes = args.Get(2).([]string)
+	})
+	twoFactorRepoMock.On("SaveTwoFactor", ctx, mock.MatchedBy(func(tf domain.TwoFactor) bool {
+		return tf.IsEnabled() && tf.LastUsedStep > 0
+	})).Return(nil)
+	userRepoMock.On("UpdateUser", ctx, mock.MatchedBy(func(u domain.User) bool {
+		return u.TwoFactorEnabled
+	})).Return(nil)
+	securityEventServiceMock.On("Record", ctx, user.ID, domain.SecurityEventTwoFactorEnable, "totp").Return()
+
+	response, err := twoFactorService.ConfirmEnrollment(ctx, domain.ConfirmTwoFactorPayload{Code: currentTOTPCode(t, secret)})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.RecoveryCodes, domain.RecoveryCodeCount)
+	assert.Len(t, codeHashes, domain.RecoveryCodeCount)
+	assert.Equal(t, secure.HashToken(secure.NormalizeRecoveryCode(response.RecoveryCodes[0])), codeHashes[0])
+	twoFactorRepoMock.AssertExpectations(t)
+	userRepoMock.AssertExpectations(t)
+	securityEventServiceMock.AssertExpectations(t)
+}
+
+func TestVerifyCode_WhenTOTPStepWasAlreadyUsed_ShouldReturnErrTwoFactorCodeWrong(t *testing.T) {
+	ctx := context.Background()
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+
+	twoFactorService := &twoFactorService{
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	userID := uuid.New()
+	twoFactor, secret := newEnabledTwoFactor(t, userID)
+	twoFactorRepoMock.On("GetTwoFactor", ctx, userID).Return(twoFactor, nil)
+	twoFactorRepoMock.On("UseStep", ctx, userID, mock.AnythingOfType("int64")).Return(false, nil)
+
+	err := twoFactorService.VerifyCode(ctx, userID, currentTOTPCode(t, secret), "")
+
+	assert.Equal(t, domain.ErrTwoFactorCodeWrong, err)
+}
+
+func TestVerifyCode_WhenRecoveryCodeIsUnused_ShouldSpendIt(t *testing.T) {
+	ctx := context.Background()
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+
+	twoFactorService := &twoFactorService{
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	userID := uuid.New()
+	twoFactor, _ := newEnabledTwoFactor(t, userID)
+	twoFactorRepoMock.On("GetTwoFactor", ctx, userID).Return(twoFactor, nil)
+	twoFactorRepoMock.On("UseRecoveryCode", ctx, userID, secure.HashToken("abcdefghjk")).Return(true, nil)
+
+	err := twoFactorService.VerifyCode(ctx, userID, "", "ABCDE-FGHJK")
+
+	assert.NoError(t, err)
+	twoFactorRepoMock.AssertExpectations(t)
+	twoFactorRepoMock.AssertNotCalled(t, "UseStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDisableTwoFactor_WhenPasswordIsWrong_ShouldReturnErrInvalidPassword(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+
+	twoFactorService := &twoFactorService{
+		contextService:      contextServiceMock,
+		userRepository:      userRepoMock,
+		twoFactorRepository: twoFactorRepoMock,
+	}
+
+	user := &domain.User{ID: uuid.New(), Password: testPasswordHash, TwoFactorEnabled: true}
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+
+	err := twoFactorService.DisableTwoFactor(ctx, domain.DisableTwoFactorPayload{Password: "Wrong@123456", Code: "123456"})
+
+	assert.Equal(t, domain.ErrInvalidPassword, err)
+	twoFactorRepoMock.AssertNotCalled(t, "DeleteTwoFactor", mock.Anything, mock.Anything)
+}
+
+func TestDisableTwoFactor_WhenReauthenticated_ShouldRemoveTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+	userRepoMock := new(mocks.UserRepository)
+	twoFactorRepoMock := new(mocks.TwoFactorRepository)
+	securityEventServiceMock := new(mocks.SecurityEventService)
+
+	twoFactorService := &twoFactorService{
+		contextService:       contextServiceMock,
+		userRepository:       userRepoMock,
+		twoFactorRepository:  twoFactorRepoMock,
+		securityEventService: securityEventServiceMock,
+	}
+
+	user := &domain.User{ID: uuid.New(), Password: testPasswordHash, TwoFactorEnabled: true}
+	twoFactor, secret := newEnabledTwoFactor(t, user.ID)
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: user.ID}, nil)
+	userRepoMock.On("GetUserByID", ctx, user.ID).Return(user, nil)
+	twoFactorRepoMock.On("GetTwoFactor", ctx, user.ID).Return(twoFactor, nil)
+	twoFactorRepoMock.On("UseStep", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+	twoFactorRepoMock.On("DeleteRecoveryCodes", ctx, user.ID).Return(nil)
+	twoFactorRepoMock.On("DeleteTwoFactor", ctx, user.ID).Return(nil)
+	userRepoMock.On("UpdateUser", ctx, mock.MatchedBy(func(u domain.User) bool {
+		return !u.TwoFactorEnabled
+	})).Return(nil)
+	securityEventServiceMock.On("Record", ctx, user.ID, domain.SecurityEventTwoFactorDisable, "").Return()
+
+	err := twoFactorService.DisableTwoFactor(ctx, domain.DisableTwoFactorPayload{Password: "Abc@123456", Code: currentTOTPCode(t, secret)})
+
+	assert.NoError(t, err)
+	twoFactorRepoMock.AssertExpectations(t)
+	userRepoMock.AssertExpectations(t)
+	securityEventServiceMock.AssertExpectations(t)
+}
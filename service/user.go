@@ -2,23 +2,41 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
+	"github.com/G-Villarinho/social-network/config"
 	"github.com/G-Villarinho/social-network/domain"
 	"github.com/G-Villarinho/social-network/internal"
 	"github.com/G-Villarinho/social-network/secure"
 	"github.com/G-Villarinho/social-network/utils"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 )
 
 type userService struct {
-	di                *internal.Di
-	userRepository    domain.UserRepository
-	queueService      domain.QueueService
-	clientInfoService domain.ClientInfoService
-	sessionService    domain.SessionService
-	contextService    domain.ContextService
+	di                         *internal.Di
+	userRepository             domain.UserRepository
+	otpRepository              domain.OTPRepository
+	passwordResetRepository    domain.PasswordResetRepository
+	queueService               domain.QueueService
+	clientInfoService          domain.ClientInfoService
+	sessionService             domain.SessionService
+	contextService             domain.ContextService
+	emailConfirmationService   domain.EmailConfirmationService
+	signInAttemptRepository    domain.SignInAttemptRepository
+	moderationRepository       domain.ModerationRepository
+	accountStatusRepository    domain.AccountStatusRepository
+	memoryCacheRepository      domain.MemoryCacheRepository
+	oidcService                domain.OIDCService
+	externalIdentityRepository domain.ExternalIdentityRepository
+	securityEventService       domain.SecurityEventService
+	knownDeviceRepository      domain.KnownDeviceRepository
+	signInReportRepository     domain.SignInReportRepository
+	twoFactorService           domain.TwoFactorService
 }
 
 func NewUserService(di *internal.Di) (domain.UserService, error) {
@@ -27,6 +45,16 @@ func NewUserService(di *internal.Di) (domain.UserService, error) {
 		return nil, err
 	}
 
+	otpRepository, err := internal.Invoke[domain.OTPRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordResetRepository, err := internal.Invoke[domain.PasswordResetRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionService, err := internal.Invoke[domain.SessionService](di)
 	if err != nil {
 		return nil, err
@@ -47,187 +75,1233 @@ func NewUserService(di *internal.Di) (domain.UserService, error) {
 		return nil, err
 	}
 
+	emailConfirmationService, err := internal.Invoke[domain.EmailConfirmationService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	signInAttemptRepository, err := internal.Invoke[domain.SignInAttemptRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	moderationRepository, err := internal.Invoke[domain.ModerationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	accountStatusRepository, err := internal.Invoke[domain.AccountStatusRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryCacheRepository, err := internal.Invoke[domain.MemoryCacheRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcService, err := internal.Invoke[domain.OIDCService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	externalIdentityRepository, err := internal.Invoke[domain.ExternalIdentityRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	securityEventService, err := internal.Invoke[domain.SecurityEventService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	knownDeviceRepository, err := internal.Invoke[domain.KnownDeviceRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	signInReportRepository, err := internal.Invoke[domain.SignInReportRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorService, err := internal.Invoke[domain.TwoFactorService](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &userService{
-		di:                di,
-		userRepository:    userRepository,
-		sessionService:    sessionService,
-		contextService:    contextService,
-		queueService:      queueService,
-		clientInfoService: clientInfoService,
+		di:                         di,
+		userRepository:             userRepository,
+		otpRepository:              otpRepository,
+		passwordResetRepository:    passwordResetRepository,
+		sessionService:             sessionService,
+		contextService:             contextService,
+		queueService:               queueService,
+		clientInfoService:          clientInfoService,
+		emailConfirmationService:   emailConfirmationService,
+		signInAttemptRepository:    signInAttemptRepository,
+		moderationRepository:       moderationRepository,
+		accountStatusRepository:    accountStatusRepository,
+		memoryCacheRepository:      memoryCacheRepository,
+		oidcService:                oidcService,
+		externalIdentityRepository: externalIdentityRepository,
+		securityEventService:       securityEventService,
+		knownDeviceRepository:      knownDeviceRepository,
+		signInReportRepository:     signInReportRepository,
+		twoFactorService:           twoFactorService,
 	}, nil
 }
 
-func (u *userService) CreateUser(ctx context.Context, payload domain.UserPayload) (string, error) {
+func (u *userService) CreateUser(ctx context.Context, payload domain.UserPayload) error {
 	user, err := u.userRepository.GetUserByUsernameOrEmail(ctx, payload.Username, payload.Email)
 	if err != nil {
-		return "", fmt.Errorf("error to get user by email: %w", err)
+		return fmt.Errorf("error to get user by email: %w", err)
 	}
 
 	if user != nil {
 		if user.Email == payload.Email {
-			return "", domain.ErrEmailAlreadyRegister
+			return domain.ErrEmailAlreadyRegister
 		}
 
 		if user.Username == payload.Username {
-			return "", domain.ErrUsernameAlreadyExists
+			return domain.ErrUsernameAlreadyExists
 		}
 	}
 
 	passwordHash, err := secure.HashPassword(payload.Password)
 	if err != nil {
-		return "", fmt.Errorf("error to hash password: %w", err)
+		return fmt.Errorf("error to hash password: %w", err)
 	}
 
 	user = payload.ToUser(string(passwordHash))
 	if err := u.userRepository.CreateUser(ctx, *user); err != nil {
-		return "", err
+		return err
+	}
+
+	if err := u.emailConfirmationService.SendConfirmation(ctx, *user); err != nil {
+		return fmt.Errorf("send email confirmation: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userService) ConfirmEmail(ctx context.Context, payload domain.ConfirmEmailPayload) error {
+	userID, err := u.emailConfirmationService.Confirm(ctx, payload.Token)
+	if err != nil {
+		return err
 	}
 
-	token, err := u.sessionService.CreateSession(ctx, *user)
+	user, err := u.userRepository.GetUserByID(ctx, userID)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("get user by ID: %w", err)
 	}
 
-	return token, nil
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if user.IsEmailConfirmed() {
+		return domain.ErrEmailAlreadyConfirmed
+	}
+
+	user.ConfirmEmail()
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userService) ResendEmailConfirmation(ctx context.Context, payload domain.ResendEmailConfirmationPayload) error {
+	user, err := u.userRepository.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user == nil || user.IsEmailConfirmed() {
+		return nil
+	}
+
+	if err := u.emailConfirmationService.ResendConfirmation(ctx, *user); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (u *userService) SignIn(ctx context.Context, payload domain.SignInPayload) (string, error) {
+func (u *userService) SignIn(ctx context.Context, payload domain.SignInPayload) (*domain.SignInResponse, error) {
+	clientIP, err := u.contextService.GetClientIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ipFailures, err := u.signInAttemptRepository.GetIPFailures(ctx, clientIP)
+	if err != nil {
+		return nil, fmt.Errorf("get sign-in failures for IP: %w", err)
+	}
+
+	if ipFailures >= domain.MaxSignInFailuresPerIP {
+		return nil, domain.ErrTooManySignInAttempts
+	}
+
 	user, err := u.userRepository.GetUserByEmailOrUsername(ctx, payload.EmailOrUsername)
 	if err != nil {
-		return "", fmt.Errorf("error to get user by email or username: %w", err)
+		return nil, fmt.Errorf("error to get user by email or username: %w", err)
 	}
 
 	if user == nil {
-		return "", domain.ErrUserNotFound
+		if _, err := u.signInAttemptRepository.IncrementIPFailures(ctx, clientIP); err != nil {
+			return nil, fmt.Errorf("increment sign-in failures for IP: %w", err)
+		}
+		return nil, domain.ErrUserNotFound
 	}
 
-	if err := secure.CheckPassword(user.Password, payload.Password); err != nil {
-		return "", domain.ErrInvalidPassword
+	locked, err := u.signInAttemptRepository.IsAccountLocked(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check account lock: %w", err)
+	}
+
+	if locked {
+		u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInFailure, "account locked")
+		return nil, domain.ErrAccountLocked
 	}
 
-	token, err := u.sessionService.CreateSession(ctx, *user)
+	accountFailures, err := u.signInAttemptRepository.GetAccountFailures(ctx, user.ID)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("get sign-in failures for account: %w", err)
 	}
 
-	go func() {
-		clientInfo, err := u.clientInfoService.GetClientInfo(ctx)
-		if err != nil {
-			slog.Error("get client info", slog.String("error", err.Error()))
-			return
-		}
+	if err := waitFor(ctx, domain.SignInDelay(accountFailures)); err != nil {
+		return nil, err
+	}
 
-		message, err := jsoniter.Marshal(getEmailNotificationTask(user, *clientInfo))
-		if err != nil {
-			slog.Error("marshal email task event", slog.String("error", err.Error()))
-			return
+	if err := secure.CheckPassword(user.Password, payload.Password); err != nil {
+		if err := u.registerSignInFailure(ctx, user, clientIP, "invalid password"); err != nil {
+			return nil, err
 		}
+		return nil, domain.ErrInvalidPassword
+	}
 
-		if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
-			slog.Error("publish email event", slog.String("error", err.Error()))
-			return
+	// wrong TOTP codes count against the same lockout, so for those accounts
+	// the failures are only cleared once the second factor is verified too
+	if accountFailures > 0 && !user.TwoFactorEnabled {
+		if err := u.signInAttemptRepository.ResetAccountFailures(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("reset sign-in failures for account: %w", err)
 		}
-	}()
+	}
+
+	if secure.PasswordNeedsRehash(user.Password) {
+		u.rehashPassword(ctx, user, payload.Password)
+	}
+
+	if err := user.CheckStatus(); err != nil && !user.IsPendingDeletion() {
+		return nil, err
+	}
+
+	if !user.IsEmailConfirmed() {
+		return nil, domain.ErrEmailConfirmationPending
+	}
+
+	if user.PasswordResetRequired {
+		return nil, domain.ErrPasswordResetRequired
+	}
+
+	hash, err := secure.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("generate 2FA hash: %w", err)
+	}
+
+	if err := u.otpRepository.SetHash(ctx, hash, user.ID); err != nil {
+		return nil, fmt.Errorf("set 2FA hash: %w", err)
+	}
+
+	if user.TwoFactorEnabled {
+		return &domain.SignInResponse{Hash: hash, Method: domain.SignInMethodTOTP}, nil
+	}
+
+	code, err := secure.GenerateOTP(domain.OTPDigits)
+	if err != nil {
+		return nil, fmt.Errorf("generate OTP code: %w", err)
+	}
+
+	if err := u.otpRepository.SetCode(ctx, hash, code); err != nil {
+		return nil, fmt.Errorf("set OTP code: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(getOTPEmailTask(user, code))
+	if err != nil {
+		return nil, fmt.Errorf("marshal OTP email task: %w", err)
+	}
 
-	return token, nil
+	if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		return nil, fmt.Errorf("publish OTP email: %w", err)
+	}
+
+	return &domain.SignInResponse{Hash: hash, Method: domain.SignInMethodEmail}, nil
 }
 
-func (u *userService) SignOut(ctx context.Context) error {
-	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
-	if !ok {
-		return domain.ErrSessionNotFound
+// rehashPassword upgrades a hash produced by an older algorithm or older
+// parameters while the plain password is at hand. A failure only delays the
+// upgrade to the next sign-in, so it does not fail the current one.
+func (u *userService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	passwordHash, err := secure.HashPassword(password)
+	if err != nil {
+		slog.Warn("rehash password", slog.String("userId", user.ID.String()), slog.String("error", err.Error()))
+		return
 	}
 
-	if err := u.sessionService.DeleteSession(ctx, session.UserID); err != nil {
-		return err
+	user.Password = string(passwordHash)
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		slog.Warn("persist rehashed password", slog.String("userId", user.ID.String()), slog.String("error", err.Error()))
 	}
+}
 
-	return nil
+// registerSignInFailure counts a wrong credential against the account and the
+// client IP, locking the account once it reaches MaxSignInFailuresPerAccount.
+// It returns domain.ErrAccountLocked when it did.
+func (u *userService) registerSignInFailure(ctx context.Context, user *domain.User, clientIP string, reason string) error {
+	if _, err := u.signInAttemptRepository.IncrementIPFailures(ctx, clientIP); err != nil {
+		return fmt.Errorf("increment sign-in failures for IP: %w", err)
+	}
+
+	failures, err := u.signInAttemptRepository.IncrementAccountFailures(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("increment sign-in failures for account: %w", err)
+	}
+
+	if failures < domain.MaxSignInFailuresPerAccount {
+		u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInFailure, reason)
+		return nil
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInFailure, reason+", account locked")
+
+	if err := u.signInAttemptRepository.LockAccount(ctx, user.ID, domain.SignInLockoutDuration*time.Minute); err != nil {
+		return fmt.Errorf("lock account: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(getAccountLockedEmailTask(user, clientIP))
+	if err != nil {
+		slog.Error("marshal account locked email task", slog.String("error", err.Error()))
+		return domain.ErrAccountLocked
+	}
+
+	if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		slog.Error("publish account locked email", slog.String("error", err.Error()))
+	}
+
+	return domain.ErrAccountLocked
 }
 
-func (u *userService) GetUser(ctx context.Context) (*domain.UserResponse, error) {
-	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
-	if !ok {
-		return nil, domain.ErrSessionNotFound
+func (u *userService) VerifySignIn(ctx context.Context, payload domain.VerifySignInPayload) (*domain.AuthTokens, error) {
+	userID, err := u.otpRepository.GetUserIDByHash(ctx, payload.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("get user ID by 2FA hash: %w", err)
 	}
 
-	user, err := u.userRepository.GetUserByID(ctx, session.UserID)
+	if userID == uuid.Nil {
+		return nil, domain.ErrHashExpired
+	}
+
+	code, err := u.otpRepository.GetCode(ctx, payload.Hash)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("get OTP code: %w", err)
+	}
+
+	if code == "" {
+		return nil, domain.ErrCodeOTPExpired
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code), []byte(payload.Code)) != 1 {
+		u.securityEventService.Record(ctx, userID, domain.SecurityEventSignInFailure, "invalid verification code")
+		return nil, u.registerCodeFailure(ctx, payload.Hash)
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by ID: %w", err)
 	}
 
 	if user == nil {
 		return nil, domain.ErrUserNotFound
 	}
 
-	return user.ToUserResponse(), nil
+	if err := user.CheckStatus(); err != nil && !user.IsPendingDeletion() {
+		return nil, err
+	}
+
+	if user.PasswordResetRequired {
+		return nil, domain.ErrPasswordResetRequired
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, domain.ErrHashExpired
+	}
+
+	if err := u.otpRepository.DeleteCode(ctx, payload.Hash); err != nil {
+		return nil, fmt.Errorf("delete OTP code: %w", err)
+	}
+
+	return u.completeSignIn(ctx, user, payload.Hash)
 }
 
-func (u *userService) UpdateUser(ctx context.Context, payload domain.UserUpdatePayload) error {
-	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
-	if !ok {
-		return domain.ErrSessionNotFound
+// VerifyTwoFactorSignIn finishes the sign-in of a user enrolled in TOTP with a
+// code from the authenticator app or one of the recovery codes.
+func (u *userService) VerifyTwoFactorSignIn(ctx context.Context, payload domain.VerifyTwoFactorSignInPayload) (*domain.AuthTokens, error) {
+	userID, err := u.otpRepository.GetUserIDByHash(ctx, payload.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("get user ID by 2FA hash: %w", err)
 	}
 
-	user, err := u.userRepository.GetUserByID(ctx, session.UserID)
+	if userID == uuid.Nil {
+		return nil, domain.ErrHashExpired
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("error to get user by ID: %w", err)
+		return nil, fmt.Errorf("get user by ID: %w", err)
 	}
 
-	if payload.Username != "" {
-		username, err := u.userRepository.GetUserByUsername(ctx, payload.Username)
-		if err != nil {
-			return fmt.Errorf("error to get user by username: %w", err)
-		}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
 
-		if username != nil {
-			return domain.ErrUsernameAlreadyExists
+	if !user.TwoFactorEnabled {
+		return nil, domain.ErrTwoFactorNotEnabled
+	}
+
+	locked, err := u.signInAttemptRepository.IsAccountLocked(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check account lock: %w", err)
+	}
+
+	if locked {
+		return nil, domain.ErrAccountLocked
+	}
+
+	if err := u.twoFactorService.VerifyCode(ctx, user.ID, payload.Code, payload.RecoveryCode); err != nil {
+		if err == domain.ErrTwoFactorCodeWrong {
+			return nil, u.registerTwoFactorFailure(ctx, user, payload.Hash)
 		}
+		return nil, err
 	}
 
-	if user == nil {
-		return domain.ErrUserNotFound
+	if err := u.signInAttemptRepository.ResetAccountFailures(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("reset sign-in failures for account: %w", err)
 	}
 
-	user.Update(payload)
-	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
-		return err
+	if err := user.CheckStatus(); err != nil && !user.IsPendingDeletion() {
+		return nil, err
 	}
 
-	return nil
+	if user.PasswordResetRequired {
+		return nil, domain.ErrPasswordResetRequired
+	}
+
+	if payload.RecoveryCode != "" {
+		u.securityEventService.Record(ctx, user.ID, domain.SecurityEventTwoFactorRecoveryCodeUsed, "")
+	}
+
+	return u.completeSignIn(ctx, user, payload.Hash)
 }
 
-func (u *userService) DeleteUser(ctx context.Context) error {
-	userID := u.contextService.GetUserID(ctx)
+// registerCodeFailure counts a wrong sign-in code. Once the request reaches
+// domain.MaxOTPCodeFailures its hash and code are discarded, so the six digit
+// code cannot be guessed by trying them all.
+func (u *userService) registerCodeFailure(ctx context.Context, hash string) error {
+	failures, err := u.otpRepository.IncrementCodeFailures(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("increment OTP code failures: %w", err)
+	}
+
+	if failures < domain.MaxOTPCodeFailures {
+		return domain.ErrCodeOTPWrong
+	}
+
+	if err := u.otpRepository.DeleteCode(ctx, hash); err != nil {
+		return fmt.Errorf("delete OTP code: %w", err)
+	}
+
+	if err := u.otpRepository.DeleteHash(ctx, hash); err != nil {
+		return fmt.Errorf("delete 2FA hash: %w", err)
+	}
 
-	if err := u.userRepository.DeleteUser(ctx, userID); err != nil {
+	return domain.ErrCodeOTPAttemptsExceeded
+}
+
+// registerTwoFactorFailure counts a wrong TOTP or recovery code against the
+// sign-in hash, as registerCodeFailure does for email codes, and against the
+// account lockout shared with wrong passwords. Reaching either limit discards
+// the hash.
+func (u *userService) registerTwoFactorFailure(ctx context.Context, user *domain.User, hash string) error {
+	clientIP, err := u.contextService.GetClientIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := u.registerSignInFailure(ctx, user, clientIP, "invalid two-factor code"); err != nil {
+		if err == domain.ErrAccountLocked {
+			if err := u.otpRepository.DeleteHash(ctx, hash); err != nil {
+				return fmt.Errorf("delete 2FA hash: %w", err)
+			}
+		}
 		return err
 	}
 
-	if err := u.sessionService.DeleteSession(ctx, userID); err != nil {
+	if err := u.registerCodeFailure(ctx, hash); err != domain.ErrCodeOTPWrong {
 		return err
 	}
 
-	return nil
+	return domain.ErrTwoFactorCodeWrong
 }
 
-func (u *userService) CheckUsername(ctx context.Context, payload domain.CheckUsernamePayload) (*domain.UsernameSuggestionResponse, error) {
-	exits, err := u.userRepository.CheckUsername(ctx, payload.Username)
-	if err != nil {
-		return nil, fmt.Errorf("check username: %w", err)
+// completeSignIn opens the session once every factor has been verified.
+func (u *userService) completeSignIn(ctx context.Context, user *domain.User, hash string) (*domain.AuthTokens, error) {
+	if err := u.otpRepository.DeleteHash(ctx, hash); err != nil {
+		return nil, fmt.Errorf("delete 2FA hash: %w", err)
 	}
 
-	if exits {
-		return &domain.UsernameSuggestionResponse{
-			Suggestions: utils.GenerateSuggestions(payload.Username, 3),
-		}, domain.ErrUsernameAlreadyExists
+	if user.IsPendingDeletion() {
+		if err := u.restoreAccount(ctx, user); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil, nil
+	tokens, err := u.sessionService.CreateSession(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInSuccess, "")
+	go u.notifyIfNewDevice(context.WithoutCancel(ctx), user, tokens.SessionID)
+
+	return tokens, nil
 }
 
-func getEmailNotificationTask(user *domain.User, clientInfo domain.ClientInfoResponse) domain.EmailPayloadTask {
-	return domain.EmailPayloadTask{
+// notifyIfNewDevice remembers the device the user signed in from and sends the
+// sign-in notification only when it was not seen before. The first device of an
+// account is remembered silently. It runs after the sign-in response, so
+// failures are only logged.
+func (u *userService) notifyIfNewDevice(ctx context.Context, user *domain.User, sessionID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(ctx, domain.NewDeviceCheckTimeout)
+	defer cancel()
+
+	log := slog.With(
+		slog.String("service", "user"),
+		slog.String("func", "notifyIfNewDevice"),
+		slog.String("userId", user.ID.String()),
+	)
+
+	clientInfo, err := u.clientInfoService.GetClientInfo(ctx)
+	if err != nil {
+		log.Warn("get client info", slog.String("error", err.Error()))
+		return
+	}
+
+	fingerprint := domain.DeviceFingerprint(*clientInfo)
+
+	device, err := u.knownDeviceRepository.GetDevice(ctx, user.ID, fingerprint)
+	if err != nil {
+		log.Error("get known device", slog.String("error", err.Error()))
+		return
+	}
+
+	if device != nil {
+		if err := u.knownDeviceRepository.UpdateLastSeen(ctx, device.ID); err != nil {
+			log.Warn("update known device", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	hasDevices, err := u.knownDeviceRepository.HasDevices(ctx, user.ID)
+	if err != nil {
+		log.Error("check known devices", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := u.knownDeviceRepository.CreateDevice(ctx, *domain.NewKnownDevice(user.ID, *clientInfo)); err != nil {
+		log.Error("create known device", slog.String("error", err.Error()))
+		return
+	}
+
+	if !hasDevices {
+		return
+	}
+
+	token, err := secure.GenerateToken(32)
+	if err != nil {
+		log.Error("generate sign-in report token", slog.String("error", err.Error()))
+		return
+	}
+
+	report := domain.SignInReport{UserID: user.ID, SessionID: sessionID, Fingerprint: fingerprint}
+	if err := u.signInReportRepository.SetToken(ctx, secure.HashToken(token), report); err != nil {
+		log.Error("set sign-in report token", slog.String("error", err.Error()))
+		return
+	}
+
+	message, err := jsoniter.Marshal(getEmailNotificationTask(user, *clientInfo, token))
+	if err != nil {
+		log.Error("marshal email task event", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		log.Error("publish email event", slog.String("error", err.Error()))
+	}
+}
+
+func (u *userService) ForgotPassword(ctx context.Context, payload domain.ForgotPasswordPayload) error {
+	user, err := u.userRepository.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	return u.sendPasswordResetEmail(ctx, user)
+}
+
+func (u *userService) sendPasswordResetEmail(ctx context.Context, user *domain.User) error {
+	token, err := secure.GenerateToken(32)
+	if err != nil {
+		return fmt.Errorf("generate password reset token: %w", err)
+	}
+
+	if err := u.passwordResetRepository.SetToken(ctx, secure.HashToken(token), user.ID); err != nil {
+		return fmt.Errorf("set password reset token: %w", err)
+	}
+
+	message, err := jsoniter.Marshal(getPasswordResetEmailTask(user, token))
+	if err != nil {
+		return fmt.Errorf("marshal password reset email task: %w", err)
+	}
+
+	if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		return fmt.Errorf("publish password reset email: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userService) ResetPassword(ctx context.Context, payload domain.ResetPasswordPayload) error {
+	userID, err := u.passwordResetRepository.ConsumeToken(ctx, secure.HashToken(payload.Token))
+	if err != nil {
+		return fmt.Errorf("consume password reset token: %w", err)
+	}
+
+	if userID == uuid.Nil {
+		return domain.ErrPasswordResetTokenInvalid
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrPasswordResetTokenInvalid
+	}
+
+	passwordHash, err := secure.HashPassword(payload.Password)
+	if err != nil {
+		return fmt.Errorf("error to hash password: %w", err)
+	}
+
+	user.Password = string(passwordHash)
+	user.PasswordResetRequired = false
+	if !user.IsEmailConfirmed() {
+		user.ConfirmEmail()
+	}
+
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventPasswordReset, "")
+
+	return nil
+}
+
+// ReportSignIn handles the "this wasn't me" link of a sign-in notification. The
+// password has to be considered leaked, so every session is revoked and no
+// sign-in is accepted until the password is reset.
+func (u *userService) ReportSignIn(ctx context.Context, payload domain.ReportSignInPayload) error {
+	report, err := u.signInReportRepository.ConsumeToken(ctx, secure.HashToken(payload.Token))
+	if err != nil {
+		return fmt.Errorf("consume sign-in report token: %w", err)
+	}
+
+	if report == nil {
+		return domain.ErrSignInReportTokenInvalid
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, report.UserID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrSignInReportTokenInvalid
+	}
+
+	user.PasswordResetRequired = true
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+
+	if err := u.knownDeviceRepository.DeleteDevice(ctx, user.ID, report.Fingerprint); err != nil {
+		return fmt.Errorf("delete known device: %w", err)
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInReported, fmt.Sprintf("session %s", report.SessionID))
+
+	return u.sendPasswordResetEmail(ctx, user)
+}
+
+func (u *userService) ChangePassword(ctx context.Context, payload domain.ChangePasswordPayload) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if err := secure.CheckPassword(user.Password, payload.CurrentPassword); err != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	if err := secure.CheckPassword(user.Password, payload.NewPassword); err == nil {
+		return domain.ErrPasswordReused
+	}
+
+	passwordHash, err := secure.HashPassword(payload.NewPassword)
+	if err != nil {
+		return fmt.Errorf("error to hash password: %w", err)
+	}
+
+	user.Password = string(passwordHash)
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.sessionService.RevokeOtherSessions(ctx); err != nil {
+		return fmt.Errorf("revoke other sessions: %w", err)
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventPasswordChange, "")
+
+	clientInfo, err := u.clientInfoService.GetClientInfo(ctx)
+	if err != nil {
+		slog.Warn("get client info for password change notice", slog.String("error", err.Error()))
+		clientInfo = &domain.ClientInfoResponse{
+			Device:    "Unknown",
+			Location:  "Unknown",
+			LoginTime: time.Now().UTC().Format("January 2, 2006, 3:04 PM"),
+		}
+	}
+
+	message, err := jsoniter.Marshal(getPasswordChangedEmailTask(user, *clientInfo))
+	if err != nil {
+		slog.Error("marshal password changed email task", slog.String("error", err.Error()))
+		return nil
+	}
+
+	if err := u.queueService.Publish(domain.QueueSendEmail, message); err != nil {
+		slog.Error("publish password changed email", slog.String("error", err.Error()))
+	}
+
+	return nil
+}
+
+func (u *userService) SignOut(ctx context.Context) error {
+	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
+	if !ok {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := u.sessionService.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+		return err
+	}
+
+	u.securityEventService.Record(ctx, session.UserID, domain.SecurityEventSignOut, "")
+
+	return nil
+}
+
+func (u *userService) GetUser(ctx context.Context) (*domain.UserResponse, error) {
+	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user.ToUserResponse(), nil
+}
+
+func (u *userService) UpdateUser(ctx context.Context, payload domain.UserUpdatePayload) error {
+	session, ok := ctx.Value(domain.SessionKey).(*domain.Session)
+	if !ok {
+		return domain.ErrSessionNotFound
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return fmt.Errorf("error to get user by ID: %w", err)
+	}
+
+	if payload.Username != "" {
+		username, err := u.userRepository.GetUserByUsername(ctx, payload.Username)
+		if err != nil {
+			return fmt.Errorf("error to get user by username: %w", err)
+		}
+
+		if username != nil {
+			return domain.ErrUsernameAlreadyExists
+		}
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	user.Update(payload)
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUser deactivates the account and schedules its purge. Until then the
+// profile and posts are hidden and signing back in restores the account.
+func (u *userService) DeleteUser(ctx context.Context) error {
+	userID := u.contextService.GetUserID(ctx)
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	user.ScheduleDeletion(accountDeletionGracePeriod())
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.accountStatusRepository.SetStatus(ctx, user.ID, domain.Inactive); err != nil {
+		return fmt.Errorf("set account status: %w", err)
+	}
+
+	if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete all sessions: %w", err)
+	}
+
+	if err := u.memoryCacheRepository.InvalidateFeeds(ctx); err != nil {
+		slog.Warn("invalidate cached feeds", slog.String("error", err.Error()))
+	}
+
+	if err := u.memoryCacheRepository.InvalidateProfileCounts(ctx); err != nil {
+		slog.Warn("invalidate cached profile counts", slog.String("error", err.Error()))
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventAccountDelete, fmt.Sprintf("purge scheduled for %s", user.ScheduledPurgeAt.Format(time.RFC3339)))
+
+	return nil
+}
+
+// restoreAccount cancels a pending deletion once its owner signs back in
+// during the grace period.
+func (u *userService) restoreAccount(ctx context.Context, user *domain.User) error {
+	user.RestoreDeletion()
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.accountStatusRepository.SetStatus(ctx, user.ID, domain.Active); err != nil {
+		return fmt.Errorf("set account status: %w", err)
+	}
+
+	if err := u.memoryCacheRepository.InvalidateFeeds(ctx); err != nil {
+		slog.Warn("invalidate cached feeds", slog.String("error", err.Error()))
+	}
+
+	if err := u.memoryCacheRepository.InvalidateProfileCounts(ctx); err != nil {
+		slog.Warn("invalidate cached profile counts", slog.String("error", err.Error()))
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventAccountRestore, "")
+
+	return nil
+}
+
+func (u *userService) CheckUsername(ctx context.Context, payload domain.CheckUsernamePayload) (*domain.UsernameSuggestionResponse, error) {
+	exits, err := u.userRepository.CheckUsername(ctx, payload.Username)
+	if err != nil {
+		return nil, fmt.Errorf("check username: %w", err)
+	}
+
+	if exits {
+		return &domain.UsernameSuggestionResponse{
+			Suggestions: utils.GenerateSuggestions(payload.Username, 3),
+		}, domain.ErrUsernameAlreadyExists
+	}
+
+	return nil, nil
+}
+
+func (u *userService) GetOIDCAuthorizationURL(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	authorizationURL, err := u.oidcService.GetAuthorizationURL(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OIDCAuthorizationResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+// SignInWithOIDC signs a user in with a verified ID token. A known provider
+// identity resolves its linked user; otherwise the identity is linked to the
+// user owning the provider-verified email, or to a new user.
+func (u *userService) SignInWithOIDC(ctx context.Context, provider string, payload domain.OIDCCallbackPayload) (*domain.AuthTokens, error) {
+	claims, err := u.oidcService.Authenticate(ctx, provider, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := u.externalIdentityRepository.GetIdentity(ctx, provider, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("get external identity: %w", err)
+	}
+
+	var user *domain.User
+	if identity != nil {
+		user, err = u.userRepository.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("get user by ID: %w", err)
+		}
+
+		if user == nil {
+			return nil, domain.ErrUserNotFound
+		}
+	} else {
+		user, err = u.resolveOIDCUser(ctx, *claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := user.CheckStatus(); err != nil && !user.IsPendingDeletion() {
+		return nil, err
+	}
+
+	if user.PasswordResetRequired {
+		return nil, domain.ErrPasswordResetRequired
+	}
+
+	if identity == nil {
+		if err := u.externalIdentityRepository.CreateIdentity(ctx, *domain.NewExternalIdentity(user.ID, provider, *claims)); err != nil {
+			return nil, fmt.Errorf("create external identity: %w", err)
+		}
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, u.requireTwoFactor(ctx, user)
+	}
+
+	if user.IsPendingDeletion() {
+		if err := u.restoreAccount(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	tokens, err := u.sessionService.CreateSession(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	u.securityEventService.Record(ctx, user.ID, domain.SecurityEventSignInSuccess, "oidc:"+provider)
+	go u.notifyIfNewDevice(context.WithoutCancel(ctx), user, tokens.SessionID)
+
+	return tokens, nil
+}
+
+// requireTwoFactor stops an OIDC sign-in of a user enrolled in TOTP and hands
+// back a hash to finish it through VerifyTwoFactorSignIn, as SignIn does.
+func (u *userService) requireTwoFactor(ctx context.Context, user *domain.User) error {
+	hash, err := secure.GenerateToken(32)
+	if err != nil {
+		return fmt.Errorf("generate 2FA hash: %w", err)
+	}
+
+	if err := u.otpRepository.SetHash(ctx, hash, user.ID); err != nil {
+		return fmt.Errorf("set 2FA hash: %w", err)
+	}
+
+	return &domain.TwoFactorRequiredError{Hash: hash}
+}
+
+// resolveOIDCUser finds the user owning the verified email, or creates one.
+//
+// An unconfirmed account with that email may have been registered by someone
+// who never owned the address, so linking it also replaces its password and
+// revokes its sessions: only the provider-verified owner keeps access.
+func (u *userService) resolveOIDCUser(ctx context.Context, claims domain.OIDCClaims) (*domain.User, error) {
+	if !claims.EmailVerified || claims.Email == "" {
+		return nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	user, err := u.userRepository.GetUserByEmail(ctx, claims.Email)
+	if err != nil {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user != nil {
+		if !user.IsEmailConfirmed() {
+			if err := u.claimUnconfirmedUser(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+
+		return user, nil
+	}
+
+	username, err := u.availableUsername(ctx, domain.UsernameFromEmail(claims.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := randomPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	user = domain.NewUserFromOIDC(claims, username, passwordHash)
+	if err := u.userRepository.CreateUser(ctx, *user); err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *userService) claimUnconfirmedUser(ctx context.Context, user *domain.User) error {
+	passwordHash, err := randomPasswordHash()
+	if err != nil {
+		return err
+	}
+
+	user.Password = passwordHash
+	user.ConfirmEmail()
+	if err := u.userRepository.UpdateUser(ctx, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete all sessions: %w", err)
+	}
+
+	return nil
+}
+
+// randomPasswordHash returns the hash of a password nobody knows, for accounts
+// that sign in through a provider until their owner sets one.
+func randomPasswordHash() (string, error) {
+	password, err := secure.GenerateToken(32)
+	if err != nil {
+		return "", fmt.Errorf("generate password: %w", err)
+	}
+
+	passwordHash, err := secure.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("error to hash password: %w", err)
+	}
+
+	return string(passwordHash), nil
+}
+
+// availableUsername returns base when it is free, or the first free and valid
+// suggestion from utils.GenerateSuggestions.
+func (u *userService) availableUsername(ctx context.Context, base string) (string, error) {
+	candidates := append([]string{base}, utils.GenerateSuggestions(base, 10)...)
+
+	for _, candidate := range candidates {
+		if len(candidate) < domain.MinUsernameLength || len(candidate) > domain.MaxUsernameLength {
+			continue
+		}
+
+		exists, err := u.userRepository.CheckUsername(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("check username: %w", err)
+		}
+
+		if !exists {
+			return candidate, nil
+		}
+	}
+
+	return "", domain.ErrUsernameAlreadyExists
+}
+
+func (u *userService) UpdateUserRole(ctx context.Context, userID uuid.UUID, payload domain.UpdateUserRolePayload) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID == userID {
+		return domain.ErrCannotChangeOwnRole
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if user.Role == payload.Role {
+		return nil
+	}
+
+	action := domain.NewModerationAction(session, domain.ModerationActionUpdateRole, user.ID, user.ID, fmt.Sprintf("%s -> %s", user.Role, payload.Role))
+	user.Role = payload.Role
+	if err := u.moderationRepository.UpdateUser(ctx, *action, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	// sessions carry the role they were issued with, so drop them to make the
+	// new role take effect on the next sign-in
+	if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("delete all sessions: %w", err)
+	}
+
+	slog.Info("user role updated",
+		slog.String("actorId", session.UserID.String()),
+		slog.String("userId", user.ID.String()),
+		slog.String("role", string(payload.Role)),
+	)
+
+	return nil
+}
+
+func (u *userService) BlockUser(ctx context.Context, userID uuid.UUID, payload domain.ModerationReasonPayload) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID == userID {
+		return domain.ErrCannotBlockSelf
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if user.Status == domain.Block {
+		return domain.ErrUserAlreadyBlocked
+	}
+
+	return u.setUserStatus(ctx, session, user, domain.Block, domain.ModerationActionBlockUser, payload.Reason)
+}
+
+func (u *userService) UnblockUser(ctx context.Context, userID uuid.UUID, payload domain.ModerationReasonPayload) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if user.Status != domain.Block {
+		return domain.ErrUserNotBlocked
+	}
+
+	return u.setUserStatus(ctx, session, user, domain.Active, domain.ModerationActionUnblockUser, payload.Reason)
+}
+
+// setUserStatus persists the new status together with its moderation action and
+// propagates it to the places that must honour it right away: the status
+// mirror read on every request, the user's live sessions, cached feeds and
+// cached profile counts.
+func (u *userService) setUserStatus(ctx context.Context, actor *domain.Session, user *domain.User, status domain.UserStatus, action string, reason string) error {
+	moderationAction := domain.NewModerationAction(actor, action, user.ID, user.ID, reason)
+	user.SetStatus(status, reason)
+	if err := u.moderationRepository.UpdateUser(ctx, *moderationAction, *user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	if err := u.accountStatusRepository.SetStatus(ctx, user.ID, status); err != nil {
+		return fmt.Errorf("set account status: %w", err)
+	}
+
+	if status != domain.Active {
+		if err := u.sessionService.DeleteAllSessions(ctx, user.ID); err != nil {
+			return fmt.Errorf("delete all sessions: %w", err)
+		}
+	}
+
+	if err := u.memoryCacheRepository.InvalidateFeeds(ctx); err != nil {
+		slog.Warn("invalidate cached feeds", slog.String("error", err.Error()))
+	}
+
+	if err := u.memoryCacheRepository.InvalidateProfileCounts(ctx); err != nil {
+		slog.Warn("invalidate cached profile counts", slog.String("error", err.Error()))
+	}
+
+	slog.Info("user status updated",
+		slog.String("actorId", actor.UserID.String()),
+		slog.String("userId", user.ID.String()),
+		slog.String("status", string(status)),
+	)
+
+	return nil
+}
+
+func getEmailNotificationTask(user *domain.User, clientInfo domain.ClientInfoResponse, reportToken string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
 		Template: domain.SignInNotification,
 		Subject:  "New Sign-In Detected",
 		Recipient: domain.Recipient{
@@ -239,6 +1313,99 @@ func getEmailNotificationTask(user *domain.User, clientInfo domain.ClientInfoRes
 			"device":    clientInfo.Device,
 			"location":  clientInfo.Location,
 			"date_time": clientInfo.LoginTime,
+			"link":      fmt.Sprintf("%s/sign-in/report?token=%s", config.Env.FrontURL, reportToken),
+		},
+	}
+}
+
+func getOTPEmailTask(user *domain.User, code string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.OTP,
+		Subject:  "Your Sign-In Code",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":     fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"otp":      code,
+			"duration": strconv.Itoa(config.Env.Cache.Code2FADuration),
+		},
+	}
+}
+
+func getAccountLockedEmailTask(user *domain.User, clientIP string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.AccountLocked,
+		Subject:  "Your Account Has Been Temporarily Locked",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":     fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"ip":       clientIP,
+			"duration": strconv.Itoa(domain.SignInLockoutDuration),
+			"link":     fmt.Sprintf("%s/forgot-password", config.Env.FrontURL),
+		},
+	}
+}
+
+func getPasswordChangedEmailTask(user *domain.User, clientInfo domain.ClientInfoResponse) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.PasswordChanged,
+		Subject:  "Your Password Was Changed",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":      fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"device":    clientInfo.Device,
+			"location":  clientInfo.Location,
+			"date_time": clientInfo.LoginTime,
+			"link":      fmt.Sprintf("%s/forgot-password", config.Env.FrontURL),
+		},
+	}
+}
+
+func getPasswordResetEmailTask(user *domain.User, token string) domain.EmailPayloadTask {
+	return domain.EmailPayloadTask{
+		Template: domain.PasswordReset,
+		Subject:  "Reset Your Password",
+		Recipient: domain.Recipient{
+			Name:  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email: user.Email,
+		},
+		Params: map[string]string{
+			"name":     fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			"link":     fmt.Sprintf("%s/reset-password?token=%s", config.Env.FrontURL, token),
+			"duration": strconv.Itoa(config.Env.Cache.PasswordResetExp),
 		},
 	}
 }
+
+func accountDeletionGracePeriod() time.Duration {
+	graceDays := config.Env.AccountDeletion.GraceDays
+	if graceDays <= 0 {
+		graceDays = domain.DefaultAccountDeletionGraceDays
+	}
+
+	return time.Duration(graceDays) * 24 * time.Hour
+}
+
+func waitFor(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/internal"
+	"github.com/google/uuid"
+)
+
+type userRestrictionService struct {
+	di                        *internal.Di
+	userRestrictionRepository domain.UserRestrictionRepository
+	userRepository            domain.UserRepository
+	followerRepository        domain.FollowerRepository
+	memoryCacheRepository     domain.MemoryCacheRepository
+	contextService            domain.ContextService
+}
+
+func NewUserRestrictionService(di *internal.Di) (domain.UserRestrictionService, error) {
+	userRestrictionRepository, err := internal.Invoke[domain.UserRestrictionRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[domain.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	followerRepository, err := internal.Invoke[domain.FollowerRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryCacheRepository, err := internal.Invoke[domain.MemoryCacheRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	contextService, err := internal.Invoke[domain.ContextService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userRestrictionService{
+		di:                        di,
+		userRestrictionRepository: userRestrictionRepository,
+		userRepository:            userRepository,
+		followerRepository:        followerRepository,
+		memoryCacheRepository:     memoryCacheRepository,
+		contextService:            contextService,
+	}, nil
+}
+
+// Restrict blocks or mutes a user. Blocking also removes the follows between
+// both users, which is why both feeds are dropped from the cache.
+func (u *userRestrictionService) Restrict(ctx context.Context, targetID uuid.UUID, restrictionType domain.RestrictionType) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID == targetID {
+		return domain.ErrCannotRestrictSelf
+	}
+
+	target, err := u.userRepository.GetUserByID(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("get user by ID: %w", err)
+	}
+
+	if target == nil {
+		return domain.ErrUserNotFound
+	}
+
+	restrictionTypes, err := u.userRestrictionRepository.GetRestrictionTypes(ctx, session.UserID, targetID)
+	if err != nil {
+		return fmt.Errorf("get restrictions: %w", err)
+	}
+
+	if domain.HasRestriction(restrictionTypes, restrictionType) {
+		return domain.ErrUserRestrictionAlreadyExists
+	}
+
+	restriction := domain.UserRestriction{
+		UserID:   session.UserID,
+		TargetID: targetID,
+		Type:     restrictionType,
+	}
+
+	if err := u.userRestrictionRepository.CreateRestriction(ctx, restriction); err != nil {
+		return fmt.Errorf("create restriction: %w", err)
+	}
+
+	if restrictionType == domain.RestrictionBlock {
+		if err := u.removeFollow(ctx, targetID, session.UserID); err != nil {
+			return err
+		}
+
+		if err := u.removeFollow(ctx, session.UserID, targetID); err != nil {
+			return err
+		}
+
+		if err := u.memoryCacheRepository.InvalidateUserFeed(ctx, targetID); err != nil {
+			return fmt.Errorf("invalidate feed: %w", err)
+		}
+	}
+
+	if err := u.memoryCacheRepository.InvalidateUserFeed(ctx, session.UserID); err != nil {
+		return fmt.Errorf("invalidate feed: %w", err)
+	}
+
+	return nil
+}
+
+// Unrestrict lifts a block or mute. Follows removed by a block are not
+// restored.
+func (u *userRestrictionService) Unrestrict(ctx context.Context, targetID uuid.UUID, restrictionType domain.RestrictionType) error {
+	session, err := u.contextService.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := u.userRestrictionRepository.DeleteRestriction(ctx, session.UserID, targetID, restrictionType)
+	if err != nil {
+		return fmt.Errorf("delete restriction: %w", err)
+	}
+
+	if !deleted {
+		return domain.ErrUserRestrictionNotFound
+	}
+
+	if restrictionType == domain.RestrictionMute {
+		if err := u.memoryCacheRepository.InvalidateUserFeed(ctx, session.UserID); err != nil {
+			return fmt.Errorf("invalidate feed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *userRestrictionService) removeFollow(ctx context.Context, userID, followerID uuid.UUID) error {
+	follower, err := u.followerRepository.GetFollower(ctx, userID, followerID)
+	if err != nil {
+		return fmt.Errorf("get follower: %w", err)
+	}
+
+	if follower == nil {
+		return nil
+	}
+
+	if err := u.followerRepository.DeleteFollower(ctx, follower.ID); err != nil {
+		return fmt.Errorf("delete follower: %w", err)
+	}
+
+	syncFollowCounts(ctx, u.memoryCacheRepository, *follower, -1)
+
+	return nil
+}
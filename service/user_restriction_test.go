@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/G-Villarinho/social-network/domain"
+	"github.com/G-Villarinho/social-network/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestrict_WhenBlocking_ShouldRemoveFollowsBothWays(t *testing.T) {
+	ctx := context.Background()
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	userRepoMock := new(mocks.UserRepository)
+	followerRepoMock := new(mocks.FollowerRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	userRestrictionService := &userRestrictionService{
+		userRestrictionRepository: userRestrictionRepoMock,
+		userRepository:            userRepoMock,
+		followerRepository:        followerRepoMock,
+		memoryCacheRepository:     memoryCacheRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	userID := uuid.New()
+	targetID := uuid.New()
+	following := &domain.Follower{ID: uuid.New(), UserID: targetID, FollowerID: userID}
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, targetID).Return(&domain.User{ID: targetID}, nil)
+	userRestrictionRepoMock.On("GetRestrictionTypes", ctx, userID, targetID).Return(nil, nil)
+	userRestrictionRepoMock.On("CreateRestriction", ctx, mock.MatchedBy(func(restriction domain.UserRestriction) bool {
+		return restriction.UserID == userID && restriction.TargetID == targetID && restriction.Type == domain.RestrictionBlock
+	})).Return(nil)
+	followerRepoMock.On("GetFollower", ctx, targetID, userID).Return(following, nil)
+	followerRepoMock.On("GetFollower", ctx, userID, targetID).Return(nil, nil)
+	followerRepoMock.On("DeleteFollower", ctx, following.ID).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, targetID, domain.ProfileCountFollowers, int64(-1)).Return(nil)
+	memoryCacheRepoMock.On("IncrementProfileCount", ctx, userID, domain.ProfileCountFollowings, int64(-1)).Return(nil)
+	memoryCacheRepoMock.On("InvalidateUserFeed", ctx, targetID).Return(nil)
+	memoryCacheRepoMock.On("InvalidateUserFeed", ctx, userID).Return(nil)
+
+	err := userRestrictionService.Restrict(ctx, targetID, domain.RestrictionBlock)
+
+	assert.NoError(t, err)
+	userRestrictionRepoMock.AssertExpectations(t)
+	followerRepoMock.AssertExpectations(t)
+	memoryCacheRepoMock.AssertExpectations(t)
+}
+
+func TestRestrict_WhenAlreadyMuted_ShouldReturnErrUserRestrictionAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	userRepoMock := new(mocks.UserRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	userRestrictionService := &userRestrictionService{
+		userRestrictionRepository: userRestrictionRepoMock,
+		userRepository:            userRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	userRepoMock.On("GetUserByID", ctx, targetID).Return(&domain.User{ID: targetID}, nil)
+	userRestrictionRepoMock.On("GetRestrictionTypes", ctx, userID, targetID).Return([]domain.RestrictionType{domain.RestrictionMute}, nil)
+
+	err := userRestrictionService.Restrict(ctx, targetID, domain.RestrictionMute)
+
+	assert.Equal(t, domain.ErrUserRestrictionAlreadyExists, err)
+	userRestrictionRepoMock.AssertNotCalled(t, "CreateRestriction", mock.Anything, mock.Anything)
+}
+
+func TestRestrict_WhenTargetIsSelf_ShouldReturnErrCannotRestrictSelf(t *testing.T) {
+	ctx := context.Background()
+	contextServiceMock := new(mocks.ContextService)
+
+	userRestrictionService := &userRestrictionService{
+		contextService: contextServiceMock,
+	}
+
+	userID := uuid.New()
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+
+	err := userRestrictionService.Restrict(ctx, userID, domain.RestrictionBlock)
+
+	assert.Equal(t, domain.ErrCannotRestrictSelf, err)
+}
+
+func TestUnrestrict_WhenNotRestricted_ShouldReturnErrUserRestrictionNotFound(t *testing.T) {
+	ctx := context.Background()
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	userRestrictionService := &userRestrictionService{
+		userRestrictionRepository: userRestrictionRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	userRestrictionRepoMock.On("DeleteRestriction", ctx, userID, targetID, domain.RestrictionBlock).Return(false, nil)
+
+	err := userRestrictionService.Unrestrict(ctx, targetID, domain.RestrictionBlock)
+
+	assert.Equal(t, domain.ErrUserRestrictionNotFound, err)
+}
+
+func TestUnrestrict_WhenUnmuting_ShouldInvalidateFeed(t *testing.T) {
+	ctx := context.Background()
+	userRestrictionRepoMock := new(mocks.UserRestrictionRepository)
+	memoryCacheRepoMock := new(mocks.MemoryCacheRepository)
+	contextServiceMock := new(mocks.ContextService)
+
+	userRestrictionService := &userRestrictionService{
+		userRestrictionRepository: userRestrictionRepoMock,
+		memoryCacheRepository:     memoryCacheRepoMock,
+		contextService:            contextServiceMock,
+	}
+
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	contextServiceMock.On("Session", ctx).Return(&domain.Session{UserID: userID}, nil)
+	userRestrictionRepoMock.On("DeleteRestriction", ctx, userID, targetID, domain.RestrictionMute).Return(true, nil)
+	memoryCacheRepoMock.On("InvalidateUserFeed", ctx, userID).Return(nil)
+
+	err := userRestrictionService.Unrestrict(ctx, targetID, domain.RestrictionMute)
+
+	assert.NoError(t, err)
+	memoryCacheRepoMock.AssertExpectations(t)
+}
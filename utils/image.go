@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+// DecodeImage decodes a JPEG or PNG image, refusing to allocate images above
+// maxPixels. JPEGs are turned upright using their EXIF orientation, since the
+// metadata is lost once the image is encoded again.
+func DecodeImage(content []byte, maxPixels int) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	if format != ImageFormatJPEG && format != ImageFormatPNG {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, "", ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	if format == ImageFormatJPEG {
+		img = applyOrientation(img, jpegOrientation(content))
+	}
+
+	return img, format, nil
+}
+
+// EncodeImage writes the pixels only, which drops any metadata the source
+// carried.
+func EncodeImage(w io.Writer, img image.Image, format string) error {
+	if format == ImageFormatPNG {
+		return png.Encode(w, img)
+	}
+
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
+
+// SquareThumbnail crops the center square of the image and scales it down to
+// size pixels by averaging the source pixels each target pixel covers. Images
+// smaller than size are cropped but never enlarged.
+func SquareThumbnail(img image.Image, size int) *image.NRGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	size = min(size, side)
+
+	left := bounds.Min.X + (bounds.Dx()-side)/2
+	top := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	thumbnail := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := top+y*side/size, top+(y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := left+x*side/size, left+(x+1)*side/size
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(img.At(sx, sy)).(color.NRGBA64)
+					r, g, b, a = r+uint64(c.R), g+uint64(c.G), b+uint64(c.B), a+uint64(c.A)
+					n++
+				}
+			}
+
+			thumbnail.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return thumbnail
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG, 1 (upright) when it
+// has none or the metadata cannot be read.
+func jpegOrientation(content []byte) int {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(content); {
+		if content[offset] != 0xFF {
+			return 1
+		}
+
+		marker := content[offset+1]
+		length := int(binary.BigEndian.Uint16(content[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(content) {
+			return 1
+		}
+
+		segment := content[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+
+		// the image data starts at SOS, no metadata after it
+		if marker == 0xDA {
+			return 1
+		}
+
+		offset += 2 + length
+	}
+
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation redraws the image so that it displays upright, following
+// the eight EXIF orientations.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// orientations 5 to 8 swap the axes
+	swap := orientation >= 5
+	outWidth, outHeight := width, height
+	if swap {
+		outWidth, outHeight = height, width
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+
+			out.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return out
+}